@@ -2,6 +2,8 @@ package protavo
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/jmalloc/protavo/src/protavo/document"
 	"github.com/jmalloc/protavo/src/protavo/driver"
@@ -18,18 +20,50 @@ import (
 //
 //	- FetchAll()
 //	- FetchWhere()
+//	- Explain()
+//	- ChangesSince()
 //	- Save()
 //	- ForceSave()
 //	- Delete()
 //	- ForceDelete()
+//	- Restore()
+//	- PurgeDeleted()
+//	- Attempt()
+//	- PutAttachment()
+//	- GetAttachment()
+//	- ListAttachments()
+//	- DeleteAttachment()
+//	- ListKeys()
 type DB struct {
-	ns string
-	d  driver.Driver
+	ns     string
+	d      driver.Driver
+	hooks  *hookRegistry
+	schema *document.Schema
 }
 
 // NewDB returns a new DB that uses the given driver.
-func NewDB(d driver.Driver) (*DB, error) {
-	return &DB{"", d}, nil
+func NewDB(d driver.Driver, opts ...Option) (*DB, error) {
+	db := &DB{"", d, &hookRegistry{}, nil}
+
+	for _, opt := range opts {
+		opt(db)
+	}
+
+	return db, nil
+}
+
+// Option is a function that configures a DB when it is created by NewDB().
+type Option func(*DB)
+
+// WithSchema returns an option that validates each document that is saved
+// against s, in addition to the checks performed by document.Validate(), and
+// derives additional keys from its content using the key extractors in s.
+//
+// The schema applies to every namespace of the DB.
+func WithSchema(s *document.Schema) Option {
+	return func(db *DB) {
+		db.schema = s
+	}
 }
 
 // Load returns the document with the given ID.
@@ -141,6 +175,52 @@ func (db *DB) FetchWhere(
 	)
 }
 
+// IterateAll returns an iterator over every document.
+//
+// The iterator must always be closed, even once it has been exhausted.
+func (db *DB) IterateAll(ctx context.Context) (*Iterator, error) {
+	return db.iterate(ctx, nil)
+}
+
+// IterateWhere returns an iterator over the documents that match the given
+// filter conditions.
+//
+// The iterator must always be closed, even once it has been exhausted.
+func (db *DB) IterateWhere(
+	ctx context.Context,
+	f ...filter.Condition,
+) (*Iterator, error) {
+	return db.iterate(ctx, filter.New(f))
+}
+
+// iterate returns an iterator over the documents that match f.
+func (db *DB) iterate(ctx context.Context, f *filter.Filter) (*Iterator, error) {
+	tx, err := db.d.BeginRead(ctx, db.ns)
+	if err != nil {
+		return nil, err
+	}
+
+	return newIterator(ctx, tx, f)
+}
+
+// Explain returns the query plan used to find the documents that match the
+// given filter conditions.
+//
+// It is intended as a diagnostic tool for finding queries that are not able to
+// make use of an index.
+func (db *DB) Explain(
+	ctx context.Context,
+	f ...filter.Condition,
+) (*driver.QueryPlan, error) {
+	op := Explain(f...)
+
+	if err := db.Read(ctx, op); err != nil {
+		return nil, err
+	}
+
+	return op.Plan, nil
+}
+
 // Save atomically creates or updates multiple documents.
 //
 // The Revision field of each document must be equal to the revision of that
@@ -149,6 +229,13 @@ func (db *DB) FetchWhere(
 //
 // New documents must have a revision of 0.
 //
+// Each document is validated using document.Validate(), and against the DB's
+// schema, if any, before the transaction begins, and therefore before any
+// hooks are invoked. If any document is invalid, a *document.ValidationError
+// that describes every problem with that document is returned and none of the
+// documents are saved. The DerivedKeys field of each document is recomputed
+// using the key extractors in the schema.
+//
 // Each document in docs is updated with its new revision and timestamp.
 func (db *DB) Save(ctx context.Context, docs ...*document.Document) error {
 	ops := make([]driver.Operation, len(docs))
@@ -240,8 +327,106 @@ func (db *DB) DeleteWhere(ctx context.Context, f ...filter.Condition) ([]string,
 	)
 }
 
+// PutAttachment stores a binary attachment of the document with the given ID,
+// replacing any existing attachment with the same name. The document must
+// exist.
+//
+// The attachment's content is read from r.
+func (db *DB) PutAttachment(
+	ctx context.Context,
+	id, name string,
+	r io.Reader,
+) error {
+	return db.Write(
+		ctx,
+		PutAttachment(id, name, r),
+	)
+}
+
+// GetAttachment writes the content of a binary attachment of the document with
+// the given ID to w.
+//
+// It returns false if the attachment does not exist.
+func (db *DB) GetAttachment(
+	ctx context.Context,
+	id, name string,
+	w io.Writer,
+) (bool, error) {
+	op := GetAttachment(id, name, w)
+
+	if err := db.Read(ctx, op); err != nil {
+		return false, err
+	}
+
+	return op.Found, nil
+}
+
+// ListAttachments returns the binary attachments of the document with the
+// given ID, ordered by name.
+func (db *DB) ListAttachments(
+	ctx context.Context,
+	id string,
+) ([]driver.Attachment, error) {
+	op := ListAttachments(id)
+
+	if err := db.Read(ctx, op); err != nil {
+		return nil, err
+	}
+
+	return op.Attachments, nil
+}
+
+// ListKeys returns the indexing keys that begin with the given prefix, ordered
+// by name, along with their types and the number of documents that have each
+// key. If t is non-zero, only keys of that type are returned.
+func (db *DB) ListKeys(
+	ctx context.Context,
+	prefix string,
+	t document.KeyType,
+) ([]driver.Key, error) {
+	op := ListKeys(prefix, t)
+
+	if err := db.Read(ctx, op); err != nil {
+		return nil, err
+	}
+
+	return op.Keys, nil
+}
+
+// DeleteAttachment deletes a binary attachment of the document with the given
+// ID.
+//
+// It is not an error to delete a non-existent attachment.
+func (db *DB) DeleteAttachment(ctx context.Context, id, name string) error {
+	return db.Write(
+		ctx,
+		DeleteAttachment(id, name),
+	)
+}
+
+// ChangesSince calls fn once for each document that has changed since the
+// write with the sequence number seq, in the order of the changes.
+//
+// It stops iterating if fn returns false or a non-nil error. It returns a
+// StaleSequenceError if the namespace has been deleted since seq.
+func (db *DB) ChangesSince(
+	ctx context.Context,
+	seq uint64,
+	fn driver.ChangeFunc,
+) error {
+	return db.Read(
+		ctx,
+		ChangesSince(seq, fn),
+	)
+}
+
 // Namespace returns a DB that operates on a sub-namespace of the current
 // namespace.
+//
+// Hooks registered on db also apply to the returned DB, and are invoked before
+// any hooks registered on the returned DB itself. Hooks registered on the
+// returned DB apply to every DB obtained for the same namespace, and to its
+// sub-namespaces.
 func (db *DB) Namespace(ns string) *DB {
 	if db.ns != "" {
 		ns = db.ns + "." + ns
@@ -252,9 +437,45 @@ func (db *DB) Namespace(ns string) *DB {
 		driver.NoOpCloser{
 			Driver: db.d,
 		},
+		db.hooks,
+		db.schema,
 	}
 }
 
+// Restore restores the soft-deleted document with the given ID.
+//
+// It returns false if there is no soft-deleted document with that ID. Documents
+// are only soft-deleted if the driver is configured to do so.
+func (db *DB) Restore(ctx context.Context, id string) (bool, error) {
+	op := Restore(id)
+
+	if err := db.Write(ctx, op); err != nil {
+		return false, err
+	}
+
+	return op.Found, nil
+}
+
+// PurgeDeleted permanently removes the documents that were soft-deleted more
+// than olderThan ago. If olderThan is zero, every soft-deleted document is
+// removed.
+//
+// It returns the IDs of the purged documents.
+func (db *DB) PurgeDeleted(ctx context.Context, olderThan time.Duration) ([]string, error) {
+	var ids []string
+
+	return ids, db.Write(
+		ctx,
+		PurgeDeleted(
+			func(id string) error {
+				ids = append(ids, id)
+				return nil
+			},
+			time.Now().Add(-olderThan),
+		),
+	)
+}
+
 // DeleteNamespace unconditionally deletes the namespace and all documents
 // and sub-namespaces within it.
 func (db *DB) DeleteNamespace(ctx context.Context) error {
@@ -290,26 +511,21 @@ func (db *DB) Read(
 
 // Write atomically executes a set of read/write operations.
 //
-// The operations are executed in order.
+// The operations are executed in order. The documents saved by the operations
+// are validated before the transaction begins, as described by DB.Save().
 func (db *DB) Write(
 	ctx context.Context,
 	ops ...driver.Operation,
 ) error {
-	tx, err := db.d.BeginWrite(ctx, db.ns)
-	if err != nil {
+	if err := prepare(db.schema, ops); err != nil {
 		return err
 	}
-	defer tx.Close()
 
-	for _, op := range ops {
-		op.ExecuteInWriteTx(ctx, tx)
-
-		if err := op.Err(); err != nil {
-			return err
-		}
+	if h := db.hooks.resolve(db.ns); h != nil {
+		return h.write(ctx, db.d, db.ns, ops)
 	}
 
-	return tx.Commit()
+	return driver.Write(ctx, db.d, db.ns, ops)
 }
 
 // BeginRead starts a new read-only transaction.
@@ -323,9 +539,26 @@ func (db *DB) BeginRead(ctx context.Context) (driver.ReadTx, error) {
 // BeginWrite starts a new transaction.
 //
 // This is a low-level interface to a transaction. Consider using DB.Write()
-// instead.
+// instead. Each document saved within the transaction is validated as it is
+// saved, before any hooks are invoked.
 func (db *DB) BeginWrite(ctx context.Context) (driver.WriteTx, error) {
-	return db.d.BeginWrite(ctx, db.ns)
+	var (
+		tx  driver.WriteTx
+		err error
+	)
+
+	if h := db.hooks.resolve(db.ns); h != nil {
+		d := &hookDriver{db.d, h}
+		tx, err = d.BeginWrite(ctx, db.ns)
+	} else {
+		tx, err = db.d.BeginWrite(ctx, db.ns)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemaTx{tx, db.schema}, nil
 }
 
 // Close closes the DB and the underlying driver, freeing resources and
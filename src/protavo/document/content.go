@@ -16,3 +16,9 @@ func StringContent(s string) proto.Message {
 func GetStringContent(m proto.Message) string {
 	return m.(*StringContentType).Value
 }
+
+// TypeURL returns the URL that identifies the type of m when it is stored as
+// the content of a document.
+func TypeURL(m proto.Message) string {
+	return "type.googleapis.com/" + proto.MessageName(m)
+}
@@ -35,6 +35,12 @@ type Document struct {
 	// UpdatedAt is the time at which the document was last modified. The value
 	// is set automatically when the document is saved.
 	UpdatedAt time.Time
+
+	// DerivedKeys is the set of indexing keys that were derived from the
+	// document's content by the key extractors in the DB's schema. The value is
+	// set automatically each time the document is saved, and any value set by
+	// the user is ignored. Derived keys are not included in Keys.
+	DerivedKeys Keys
 }
 
 // UniqueKeys returns the document's unique indexing keys.
@@ -61,6 +67,32 @@ func (d *Document) KeysByType(t KeyType) []string {
 	return keys
 }
 
+// IndexedKeys returns all of the keys that the document is indexed by, which
+// consists of its keys and its derived keys.
+func (d *Document) IndexedKeys() Keys {
+	return mergeKeys(d.Keys, d.DerivedKeys)
+}
+
+// mergeKeys returns the union of keys and derived. The type of a key in keys
+// takes precedence over the type of the same key in derived.
+func mergeKeys(keys, derived Keys) Keys {
+	if len(derived) == 0 {
+		return keys
+	}
+
+	r := make(Keys, len(keys)+len(derived))
+
+	for k, t := range derived {
+		r[k] = t
+	}
+
+	for k, t := range keys {
+		r[k] = t
+	}
+
+	return r
+}
+
 // Equal returns true if d and doc are equal.
 func (d *Document) Equal(doc *Document) bool {
 	if d.ID != d.ID {
@@ -94,6 +126,17 @@ func (d *Document) Equal(doc *Document) bool {
 		}
 	}
 
+	if len(d.DerivedKeys) != len(doc.DerivedKeys) {
+		return false
+	}
+
+	for k, v := range d.DerivedKeys {
+		x, ok := doc.DerivedKeys[k]
+		if !ok || x != v {
+			return false
+		}
+	}
+
 	for k, v := range d.Headers {
 		x, ok := doc.Headers[k]
 		if !ok || x != v {
@@ -103,18 +146,3 @@ func (d *Document) Equal(doc *Document) bool {
 
 	return proto.Equal(d.Content, doc.Content)
 }
-
-// Validate panics if the document is not valid.
-func (d *Document) Validate() {
-	if d == nil {
-		panic("document must not be nil")
-	}
-
-	if d.ID == "" {
-		panic("document ID must not be empty")
-	}
-
-	if d.Content == nil {
-		panic("document content must not be nil")
-	}
-}
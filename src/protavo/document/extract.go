@@ -0,0 +1,56 @@
+package document
+
+import (
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// KeyExtractor is a function that derives a document's keys from its content.
+type KeyExtractor func(content proto.Message) Keys
+
+// AddKeyExtractor adds a key extractor for documents with content of the same
+// message type as m.
+//
+// It returns s, so that calls can be chained.
+func (s *Schema) AddKeyExtractor(m proto.Message, fn KeyExtractor) *Schema {
+	t := reflect.TypeOf(m)
+
+	if s.extractors == nil {
+		s.extractors = map[reflect.Type][]KeyExtractor{}
+	}
+
+	s.extractors[t] = append(s.extractors[t], fn)
+
+	return s
+}
+
+// DerivedKeys returns the keys derived from the content of d by the key
+// extractors in s for its content type.
+//
+// Keys that are already present in d.Keys are excluded, so an explicit key type
+// takes precedence over a derived one. It returns nil if there are no derived
+// keys, or if s is nil. d is not modified.
+func (s *Schema) DerivedKeys(d *Document) Keys {
+	if s == nil || d.Content == nil {
+		return nil
+	}
+
+	var keys Keys
+
+	for _, fn := range s.extractors[reflect.TypeOf(d.Content)] {
+		for k, t := range fn(d.Content) {
+			if _, ok := d.Keys[k]; ok {
+				continue
+			}
+
+			if keys == nil {
+				keys = Keys{}
+			}
+
+			keys[k] = t
+		}
+	}
+
+	return keys
+}
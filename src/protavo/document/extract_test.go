@@ -0,0 +1,41 @@
+package document_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/jmalloc/protavo/src/protavo/document"
+)
+
+func ExampleSchema_DerivedKeys() {
+	// Add a key extractor that derives a shared key from the first word of
+	// the content.
+	s := (&Schema{}).AddKeyExtractor(
+		&StringContentType{},
+		func(m proto.Message) Keys {
+			w := strings.Fields(GetStringContent(m))
+			if len(w) == 0 {
+				return nil
+			}
+
+			return SharedKeys("word:" + strings.ToLower(w[0]))
+		},
+	)
+
+	doc := &Document{
+		ID:      "quote:1",
+		Keys:    UniqueKeys("quote:first"),
+		Content: StringContent("Hello world"),
+	}
+
+	// The derived keys are kept separate from those that were set explicitly.
+	keys := s.DerivedKeys(doc)
+
+	fmt.Println(keys["word:hello"] == SharedKey)
+	fmt.Println(len(doc.Keys))
+
+	// Output:
+	// true
+	// 1
+}
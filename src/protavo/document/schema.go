@@ -0,0 +1,13 @@
+package document
+
+import "reflect"
+
+// Schema is a set of rules that apply to the documents stored in a database.
+//
+// The zero value has no rules. A Schema must not be modified once it is in use.
+type Schema struct {
+	validators        map[reflect.Type][]Validator
+	keyConstraints    []Constraint
+	headerConstraints map[string][]Constraint
+	extractors        map[reflect.Type][]KeyExtractor
+}
@@ -0,0 +1,251 @@
+package document
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// TextIndex is the configuration of a full-text index. It determines which
+// content fields and headers are searchable.
+//
+// The zero value has no searchable fields or headers. A TextIndex must not be
+// modified once it is in use.
+type TextIndex struct {
+	fields  map[reflect.Type][][]int
+	headers map[string]struct{}
+}
+
+// AddFields makes the given fields of the content message type of m
+// searchable.
+//
+// Fields are identified by their Protocol Buffers field name. Fields of nested
+// messages are identified by a dot-separated path, such as "address.city".
+// Each field must be a string, or a repeated string.
+//
+// It panics if any of the fields do not exist, or are not strings. It returns
+// x, so that calls can be chained.
+func (x *TextIndex) AddFields(m proto.Message, fields ...string) *TextIndex {
+	t := reflect.TypeOf(m)
+
+	if x.fields == nil {
+		x.fields = map[reflect.Type][][]int{}
+	}
+
+	for _, f := range fields {
+		x.fields[t] = append(x.fields[t], resolveTextField(t, f))
+	}
+
+	return x
+}
+
+// AddHeaders makes the values of the headers with the given names searchable.
+//
+// It returns x, so that calls can be chained.
+func (x *TextIndex) AddHeaders(names ...string) *TextIndex {
+	if x.headers == nil {
+		x.headers = map[string]struct{}{}
+	}
+
+	for _, n := range names {
+		x.headers[n] = struct{}{}
+	}
+
+	return x
+}
+
+// Tokenize splits text into the terms that are used for full-text search.
+//
+// Terms are sequences of letters and digits, converted to lower-case. All other
+// characters are treated as separators. The terms are returned in the order
+// that they appear in the text, including any duplicates.
+func Tokenize(text string) []string {
+	terms := strings.FieldsFunc(
+		text,
+		func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		},
+	)
+
+	for i, t := range terms {
+		terms[i] = strings.ToLower(t)
+	}
+
+	return terms
+}
+
+// Terms returns the terms in the searchable text of d, which consists of the
+// fields of its content and the values of its headers that are searchable
+// according to x.
+//
+// The terms are sorted and do not contain duplicates. If x is nil, there is no
+// searchable text.
+func (x *TextIndex) Terms(d *Document) []string {
+	set := map[string]struct{}{}
+
+	if x != nil {
+		for n, v := range d.Headers {
+			if _, ok := x.headers[n]; ok {
+				addTerms(set, v)
+			}
+		}
+
+		if d.Content != nil {
+			v := reflect.ValueOf(d.Content)
+
+			for _, p := range x.fields[v.Type()] {
+				for _, s := range textFieldValues(v, p) {
+					addTerms(set, s)
+				}
+			}
+		}
+	}
+
+	return sortedTerms(set)
+}
+
+// AllTextTerms returns the terms in all of the document's text, which consists
+// of the values of all of its headers and all of the string fields of its
+// content, regardless of which are searchable.
+//
+// The terms are sorted and do not contain duplicates.
+func (d *Document) AllTextTerms() []string {
+	set := map[string]struct{}{}
+
+	for _, v := range d.Headers {
+		addTerms(set, v)
+	}
+
+	if d.Content != nil {
+		allTextValues(set, reflect.ValueOf(d.Content))
+	}
+
+	return sortedTerms(set)
+}
+
+// addTerms adds the terms in text to set.
+func addTerms(set map[string]struct{}, text string) {
+	for _, t := range Tokenize(text) {
+		set[t] = struct{}{}
+	}
+}
+
+// sortedTerms returns the terms in set, in sorted order.
+func sortedTerms(set map[string]struct{}) []string {
+	terms := make([]string, 0, len(set))
+	for t := range set {
+		terms = append(terms, t)
+	}
+
+	sort.Strings(terms)
+
+	return terms
+}
+
+// allTextValues adds the terms in every string within v to set, descending
+// into nested messages, repeated fields and oneof fields.
+func allTextValues(set map[string]struct{}, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		addTerms(set, v.String())
+
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			allTextValues(set, v.Elem())
+		}
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			for i := 0; i < v.Len(); i++ {
+				allTextValues(set, v.Index(i))
+			}
+		}
+
+	case reflect.Struct:
+		t := v.Type()
+
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+
+			// only consider message fields, not internal fields such as
+			// XXX_unrecognized
+			if f.Tag.Get("protobuf") != "" || f.Tag.Get("protobuf_oneof") != "" {
+				allTextValues(set, v.Field(i))
+			}
+		}
+	}
+}
+
+// resolveTextField returns the index sequence of the struct field that
+// represents the field with the given path, within a message of type t.
+func resolveTextField(t reflect.Type, path string) []int {
+	var index []int
+
+	for _, name := range strings.Split(path, ".") {
+		if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+			panic(fmt.Sprintf("can not add text field '%s', '%s' is not a message", path, t))
+		}
+
+		t = t.Elem()
+		found := false
+
+		for i := 0; i < t.NumField(); i++ {
+			if protoFieldName(t.Field(i)) == name {
+				index = append(index, i)
+				t = t.Field(i).Type
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			panic(fmt.Sprintf("can not add text field '%s', there is no such field", path))
+		}
+	}
+
+	if t.Kind() != reflect.String &&
+		!(t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String) {
+		panic(fmt.Sprintf("can not add text field '%s', it is not a string", path))
+	}
+
+	return index
+}
+
+// protoFieldName returns the Protocol Buffers field name of a struct field in
+// a generated message type, or an empty string if it is not a message field.
+func protoFieldName(f reflect.StructField) string {
+	for _, opt := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(opt, "name=") {
+			return opt[len("name="):]
+		}
+	}
+
+	return ""
+}
+
+// textFieldValues returns the string values of the field at the given index
+// sequence within v, which is a pointer to a message.
+func textFieldValues(v reflect.Value, index []int) []string {
+	for _, i := range index {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem().Field(i)
+	}
+
+	if v.Kind() == reflect.String {
+		return []string{v.String()}
+	}
+
+	values := make([]string, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).String()
+	}
+
+	return values
+}
@@ -0,0 +1,28 @@
+package document_test
+
+import (
+	"fmt"
+
+	. "github.com/jmalloc/protavo/src/protavo/document"
+)
+
+func ExampleTextIndex_Terms() {
+	// Make the content of string documents and the "tags" header searchable.
+	x := (&TextIndex{}).
+		AddFields(&StringContentType{}, "value").
+		AddHeaders("tags")
+
+	doc := &Document{
+		ID: "quote:1",
+		Headers: Headers{
+			"tags":   "Greeting",
+			"author": "Alice",
+		},
+		Content: StringContent("Hello, world!"),
+	}
+
+	// The "author" header is not searchable, so its value is not included.
+	fmt.Println(x.Terms(doc))
+
+	// Output: [greeting hello world]
+}
@@ -0,0 +1,254 @@
+package document
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// Validator is a function that checks the content of a document.
+//
+// It returns a non-nil error if the content is not valid. If the error is a
+// *ValidationError, each of its violations is reported separately.
+type Validator func(content proto.Message) error
+
+// Constraint is a restriction on the values of a document's keys or headers.
+type Constraint struct {
+	// MaxLength is the maximum length of the value, in bytes. If it is zero,
+	// the length is not restricted.
+	MaxLength int
+
+	// IsAllowed, if non-nil, returns true if the character r may appear within
+	// the value.
+	IsAllowed func(r rune) bool
+}
+
+// ValidationError is an error that occurs when a document is not valid.
+type ValidationError struct {
+	DocumentID string
+
+	// Violations contains a description of each of the problems that were
+	// found with the document.
+	Violations []string
+}
+
+func (e *ValidationError) Error() string {
+	if e.DocumentID == "" {
+		return "document is invalid: " + strings.Join(e.Violations, ", ")
+	}
+
+	return fmt.Sprintf(
+		"document '%s' is invalid: %s",
+		e.DocumentID,
+		strings.Join(e.Violations, ", "),
+	)
+}
+
+// AddValidator adds a validator for documents with content of the same message
+// type as m.
+//
+// It returns s, so that calls can be chained.
+func (s *Schema) AddValidator(m proto.Message, v Validator) *Schema {
+	t := reflect.TypeOf(m)
+
+	if s.validators == nil {
+		s.validators = map[reflect.Type][]Validator{}
+	}
+
+	s.validators[t] = append(s.validators[t], v)
+
+	return s
+}
+
+// AddKeyConstraint adds a constraint that applies to every key of every
+// document.
+//
+// It returns s, so that calls can be chained.
+func (s *Schema) AddKeyConstraint(c Constraint) *Schema {
+	s.keyConstraints = append(s.keyConstraints, c)
+
+	return s
+}
+
+// AddHeaderConstraint adds a constraint that applies to the value of the header
+// with the given name.
+//
+// It returns s, so that calls can be chained.
+func (s *Schema) AddHeaderConstraint(name string, c Constraint) *Schema {
+	if s.headerConstraints == nil {
+		s.headerConstraints = map[string][]Constraint{}
+	}
+
+	s.headerConstraints[name] = append(s.headerConstraints[name], c)
+
+	return s
+}
+
+// Validate returns an error if the document does not have a non-empty ID and
+// non-nil content.
+//
+// The returned error is a *ValidationError that describes every problem that
+// was found.
+func (d *Document) Validate() error {
+	if d == nil {
+		return &ValidationError{
+			Violations: []string{"document must not be nil"},
+		}
+	}
+
+	return newValidationError(d, d.violations())
+}
+
+// violations returns a description of each way in which d fails the checks
+// performed by d.Validate(). d must not be nil.
+func (d *Document) violations() []string {
+	var v []string
+
+	if d.ID == "" {
+		v = append(v, "document ID must not be empty")
+	}
+
+	if d.Content == nil {
+		v = append(v, "document content must not be nil")
+	}
+
+	return v
+}
+
+// Validate returns an error if d does not follow the rules in s.
+//
+// The keys and headers of d must satisfy the constraints in s, as must any keys
+// derived by the key extractors in s. Its content must be accepted by the
+// validators in s for its message type. If s is nil, every document is valid.
+//
+// The returned error is a *ValidationError that describes every problem that
+// was found.
+func (s *Schema) Validate(d *Document) error {
+	if s == nil {
+		return nil
+	}
+
+	return newValidationError(d, s.violations(nil, d, s.DerivedKeys(d)))
+}
+
+// Prepare validates d and returns the keys derived from its content by the key
+// extractors in s.
+//
+// It performs the checks of both d.Validate() and s.Validate() in a single
+// pass, so the returned error is a *ValidationError that describes every
+// problem that was found. If s is nil, only the checks of d.Validate() are
+// performed, and no keys are derived.
+func (s *Schema) Prepare(d *Document) (Keys, error) {
+	if d == nil {
+		return nil, d.Validate()
+	}
+
+	v := d.violations()
+
+	if s == nil {
+		return nil, newValidationError(d, v)
+	}
+
+	derived := s.DerivedKeys(d)
+	v = s.violations(v, d, derived)
+
+	return derived, newValidationError(d, v)
+}
+
+// violations appends a description of each way in which d does not follow the
+// rules in s to v. derived is the set of keys derived from d's content.
+func (s *Schema) violations(v []string, d *Document, derived Keys) []string {
+	for _, k := range sortedKeys(mergeKeys(d.Keys, derived)) {
+		for _, c := range s.keyConstraints {
+			v = c.check(v, fmt.Sprintf("key '%s'", k), k)
+		}
+	}
+
+	for _, n := range sortedHeaders(d.Headers) {
+		for _, c := range s.headerConstraints[n] {
+			v = c.check(v, fmt.Sprintf("header '%s'", n), d.Headers[n])
+		}
+	}
+
+	if d.Content != nil {
+		for _, fn := range s.validators[reflect.TypeOf(d.Content)] {
+			err := fn(d.Content)
+
+			if e, ok := err.(*ValidationError); ok {
+				v = append(v, e.Violations...)
+			} else if err != nil {
+				v = append(v, err.Error())
+			}
+		}
+	}
+
+	return v
+}
+
+// newValidationError returns a *ValidationError for d with the given
+// violations, or nil if there are none.
+func newValidationError(d *Document, v []string) error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	return &ValidationError{
+		DocumentID: d.ID,
+		Violations: v,
+	}
+}
+
+// check appends a description of each way in which s violates c to v.
+//
+// desc is a description of the value being checked, such as "key 'foo'".
+func (c Constraint) check(v []string, desc, s string) []string {
+	if c.MaxLength > 0 && len(s) > c.MaxLength {
+		v = append(v, fmt.Sprintf(
+			"%s is longer than %d bytes",
+			desc,
+			c.MaxLength,
+		))
+	}
+
+	if c.IsAllowed != nil {
+		for _, r := range s {
+			if !c.IsAllowed(r) {
+				v = append(v, fmt.Sprintf(
+					"%s contains a disallowed character (%q)",
+					desc,
+					r,
+				))
+				break
+			}
+		}
+	}
+
+	return v
+}
+
+// sortedKeys returns the names of the keys in k, in sorted order.
+func sortedKeys(k Keys) []string {
+	names := make([]string, 0, len(k))
+	for n := range k {
+		names = append(names, n)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// sortedHeaders returns the names of the headers in h, in sorted order.
+func sortedHeaders(h Headers) []string {
+	names := make([]string, 0, len(h))
+	for n := range h {
+		names = append(names, n)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
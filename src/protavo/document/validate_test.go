@@ -0,0 +1,57 @@
+package document_test
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/jmalloc/protavo/src/protavo/document"
+)
+
+func ExampleSchema_Validate() {
+	s := (&Schema{}).
+		// Reject empty strings as content.
+		AddValidator(
+			&StringContentType{},
+			func(m proto.Message) error {
+				if GetStringContent(m) == "" {
+					return errors.New("content must not be empty")
+				}
+
+				return nil
+			},
+		).
+		// Keys may only contain lower-case letters, digits and colons.
+		AddKeyConstraint(
+			Constraint{
+				IsAllowed: func(r rune) bool {
+					return unicode.IsLower(r) || unicode.IsDigit(r) || r == ':'
+				},
+			},
+		).
+		// The value of the "author" header may be at most 10 bytes long.
+		AddHeaderConstraint(
+			"author",
+			Constraint{MaxLength: 10},
+		)
+
+	doc := &Document{
+		ID:      "person:1",
+		Keys:    SharedKeys("hobby:Cycling"),
+		Headers: Headers{"author": "Alice Jones"},
+		Content: StringContent(""),
+	}
+
+	// Every violation is reported, not just the first.
+	if err, ok := s.Validate(doc).(*ValidationError); ok {
+		for _, v := range err.Violations {
+			fmt.Println(v)
+		}
+	}
+
+	// Output:
+	// key 'hobby:Cycling' contains a disallowed character ('C')
+	// header 'author' is longer than 10 bytes
+	// content must not be empty
+}
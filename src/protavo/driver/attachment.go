@@ -0,0 +1,86 @@
+package driver
+
+import (
+	"context"
+	"io"
+)
+
+// Attachment describes a binary attachment of a document.
+type Attachment struct {
+	// Name is the name of the attachment, which is unique within the document.
+	Name string
+
+	// Size is the size of the attachment's content, in bytes.
+	Size int64
+}
+
+// PutAttachment is a request to store a binary attachment of a document.
+type PutAttachment struct {
+	operation
+
+	DocumentID string
+	Name       string
+	Content    io.Reader
+}
+
+// ExecuteInWriteTx executes this operation within the context of tx.
+func (o *PutAttachment) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
+	tx.PutAttachment(ctx, o)
+}
+
+// GetAttachment is a request to read a binary attachment of a document.
+type GetAttachment struct {
+	operation
+
+	DocumentID string
+	Name       string
+	Content    io.Writer
+
+	// Found is set to true once the operation is executed if the attachment
+	// exists.
+	Found bool
+}
+
+// ExecuteInReadTx executes this operation within the context of tx.
+func (o *GetAttachment) ExecuteInReadTx(ctx context.Context, tx ReadTx) {
+	tx.GetAttachment(ctx, o)
+}
+
+// ExecuteInWriteTx executes this operation within the context of tx.
+func (o *GetAttachment) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
+	o.ExecuteInReadTx(ctx, tx)
+}
+
+// ListAttachments is a request to list the binary attachments of a document.
+type ListAttachments struct {
+	operation
+
+	DocumentID string
+
+	// Attachments is populated with the document's attachments, ordered by
+	// name, once the operation is executed.
+	Attachments []Attachment
+}
+
+// ExecuteInReadTx executes this operation within the context of tx.
+func (o *ListAttachments) ExecuteInReadTx(ctx context.Context, tx ReadTx) {
+	tx.ListAttachments(ctx, o)
+}
+
+// ExecuteInWriteTx executes this operation within the context of tx.
+func (o *ListAttachments) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
+	o.ExecuteInReadTx(ctx, tx)
+}
+
+// DeleteAttachment is a request to delete a binary attachment of a document.
+type DeleteAttachment struct {
+	operation
+
+	DocumentID string
+	Name       string
+}
+
+// ExecuteInWriteTx executes this operation within the context of tx.
+func (o *DeleteAttachment) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
+	tx.DeleteAttachment(ctx, o)
+}
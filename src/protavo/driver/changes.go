@@ -0,0 +1,64 @@
+package driver
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+)
+
+// Change describes the most recent modification of a document.
+type Change struct {
+	// Sequence is the namespace-wide sequence number of the write that made the
+	// change. Sequence numbers increase monotonically with each write.
+	Sequence uint64
+
+	// DocumentID is the ID of the document that was changed.
+	DocumentID string
+
+	// Document is the document as of the change. It is nil if the document was
+	// deleted.
+	Document *document.Document
+}
+
+// IsDeleted returns true if the change is the deletion of the document.
+func (c *Change) IsDeleted() bool {
+	return c.Document == nil
+}
+
+// ChangeFunc is a function that is invoked for each change found in a
+// changes-since operation.
+//
+// The operation is ended if it returns false or a non-nil error.
+type ChangeFunc func(*Change) (bool, error)
+
+// ChangesSince is a request to retrieve the documents that have changed since
+// a specific sequence number.
+type ChangesSince struct {
+	operation
+
+	Since uint64
+	Each  ChangeFunc
+}
+
+// ExecuteInReadTx executes this operation within the context of tx.
+func (o *ChangesSince) ExecuteInReadTx(ctx context.Context, tx ReadTx) {
+	tx.ChangesSince(ctx, o)
+}
+
+// ExecuteInWriteTx executes this operation within the context of tx.
+func (o *ChangesSince) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
+	o.ExecuteInReadTx(ctx, tx)
+}
+
+// ApplyChange is a request to apply a change that was obtained from another
+// database, such that the document's ID, revision and timestamps are preserved.
+type ApplyChange struct {
+	operation
+
+	Change *Change
+}
+
+// ExecuteInWriteTx executes this operation within the context of tx.
+func (o *ApplyChange) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
+	tx.ApplyChange(ctx, o)
+}
@@ -2,6 +2,7 @@ package driver
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmalloc/protavo/src/protavo/document"
 	"github.com/jmalloc/protavo/src/protavo/filter"
@@ -50,3 +51,33 @@ type DeleteNamespace struct {
 func (o *DeleteNamespace) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
 	tx.DeleteNamespace(ctx, o)
 }
+
+// Restore is a request to restore a soft-deleted document.
+type Restore struct {
+	operation
+
+	DocumentID string
+
+	// Found is set to true once the operation is executed if there was a
+	// soft-deleted document with the given ID.
+	Found bool
+}
+
+// ExecuteInWriteTx executes this operation within the context of tx.
+func (o *Restore) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
+	tx.Restore(ctx, o)
+}
+
+// PurgeDeleted is a request to permanently remove the soft-deleted documents
+// that were deleted before a specific time.
+type PurgeDeleted struct {
+	operation
+
+	Each   DeleteWhereFunc
+	Before time.Time
+}
+
+// ExecuteInWriteTx executes this operation within the context of tx.
+func (o *PurgeDeleted) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
+	tx.PurgeDeleted(ctx, o)
+}
@@ -21,6 +21,12 @@ type NoOpCloser struct {
 	Driver
 }
 
+// Write atomically executes a set of read/write operations using the
+// underlying driver.
+func (c NoOpCloser) Write(ctx context.Context, ns string, ops []Operation) error {
+	return Write(ctx, c.Driver, ns, ops)
+}
+
 // Close is a no-op that always returns nil.
 func (NoOpCloser) Close() error {
 	return nil
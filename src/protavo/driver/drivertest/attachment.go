@@ -0,0 +1,286 @@
+package drivertest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+// describeAttachments defines the standard test suite for the attachment
+// operations.
+func describeAttachments(
+	before func() (*protavo.DB, error),
+	after func(),
+) {
+	ctx := context.Background()
+
+	g.Describe("Attachments", func() {
+		var (
+			db    *protavo.DB
+			doc   *document.Document
+			large []byte
+		)
+
+		g.BeforeEach(func() {
+			var err error
+			db, err = before()
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			doc = &document.Document{
+				ID:      "doc-1",
+				Content: document.StringContent("content-1"),
+			}
+
+			err = db.Save(ctx, doc)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			// large enough to span several chunks in drivers that split
+			// attachments into chunks
+			large = make([]byte, 300*1024)
+			for i := range large {
+				large[i] = byte(i % 251)
+			}
+		})
+
+		g.AfterEach(func() {
+			_ = db.Close()
+
+			if after != nil {
+				after()
+			}
+		})
+
+		// get returns the content of the given attachment of doc-1.
+		get := func(name string) ([]byte, bool) {
+			var buf bytes.Buffer
+
+			ok, err := db.GetAttachment(ctx, "doc-1", name, &buf)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			return buf.Bytes(), ok
+		}
+
+		g.Describe("PutAttachment", func() {
+			g.It("stores the attachment", func() {
+				err := db.PutAttachment(ctx, "doc-1", "large.bin", bytes.NewReader(large))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				content, ok := get("large.bin")
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(content).To(m.Equal(large))
+			})
+
+			g.It("stores empty attachments", func() {
+				err := db.PutAttachment(ctx, "doc-1", "empty", bytes.NewReader(nil))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				content, ok := get("empty")
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(content).To(m.BeEmpty())
+			})
+
+			g.It("replaces an existing attachment with the same name", func() {
+				err := db.PutAttachment(ctx, "doc-1", "file", bytes.NewReader(large))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = db.PutAttachment(ctx, "doc-1", "file", bytes.NewReader([]byte("<small>")))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				content, ok := get("file")
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(string(content)).To(m.Equal("<small>"))
+			})
+
+			g.It("does not affect the document", func() {
+				err := db.PutAttachment(ctx, "doc-1", "file", bytes.NewReader(large))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				d, ok, err := db.Load(ctx, "doc-1")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(d.Equal(doc)).To(m.BeTrue())
+			})
+
+			g.It("returns an error if the document does not exist", func() {
+				err := db.PutAttachment(ctx, "doc-2", "file", bytes.NewReader(large))
+				m.Expect(err).To(m.Equal(
+					&protavo.NotFoundError{
+						DocumentID: "doc-2",
+						Operation:  "attach 'file' to",
+					},
+				))
+			})
+
+			g.It("returns an error if the namespace does not exist", func() {
+				err := db.Namespace("missing").PutAttachment(ctx, "doc-1", "file", bytes.NewReader(large))
+				m.Expect(protavo.IsNamespaceNotFoundError(err)).To(m.BeTrue())
+			})
+
+			g.It("returns an error if the attachment name is empty", func() {
+				err := db.PutAttachment(ctx, "doc-1", "", bytes.NewReader(large))
+				m.Expect(err).Should(m.HaveOccurred())
+			})
+
+			g.It("returns an error if the content can not be read", func() {
+				err := db.PutAttachment(ctx, "doc-1", "file", errorReader{})
+				m.Expect(err).To(m.MatchError("<read error>"))
+
+				_, ok := get("file")
+				m.Expect(ok).To(m.BeFalse())
+			})
+
+			g.It("is undone when the enclosing attempt fails", func() {
+				err := db.Write(
+					ctx,
+					protavo.Attempt(
+						func(context.Context, driver.WriteTx, error) error {
+							return nil
+						},
+						protavo.PutAttachment("doc-1", "file", bytes.NewReader(large)),
+						protavo.Save(
+							&document.Document{
+								ID:      "doc-1",
+								Content: document.StringContent("content-1"),
+							},
+						), // revision 0 does not match
+					),
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				_, ok := get("file")
+				m.Expect(ok).To(m.BeFalse())
+			})
+		})
+
+		g.Describe("GetAttachment", func() {
+			g.It("returns false if the attachment does not exist", func() {
+				_, ok := get("file")
+				m.Expect(ok).To(m.BeFalse())
+			})
+
+			g.It("returns false if the document does not exist", func() {
+				var buf bytes.Buffer
+
+				ok, err := db.GetAttachment(ctx, "doc-2", "file", &buf)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeFalse())
+			})
+		})
+
+		g.Describe("ListAttachments", func() {
+			g.It("returns the attachments ordered by name", func() {
+				err := db.Write(
+					ctx,
+					protavo.PutAttachment("doc-1", "b", bytes.NewReader(large)),
+					protavo.PutAttachment("doc-1", "a", bytes.NewReader([]byte("<a>"))),
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				attachments, err := db.ListAttachments(ctx, "doc-1")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(attachments).To(m.Equal(
+					[]driver.Attachment{
+						{Name: "a", Size: 3},
+						{Name: "b", Size: int64(len(large))},
+					},
+				))
+			})
+
+			g.It("returns an empty slice if the document has no attachments", func() {
+				attachments, err := db.ListAttachments(ctx, "doc-1")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(attachments).To(m.BeEmpty())
+			})
+		})
+
+		g.Describe("DeleteAttachment", func() {
+			g.It("deletes the attachment", func() {
+				err := db.Write(
+					ctx,
+					protavo.PutAttachment("doc-1", "a", bytes.NewReader(large)),
+					protavo.PutAttachment("doc-1", "b", bytes.NewReader(large)),
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = db.DeleteAttachment(ctx, "doc-1", "a")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				attachments, err := db.ListAttachments(ctx, "doc-1")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(attachments).To(m.HaveLen(1))
+				m.Expect(attachments[0].Name).To(m.Equal("b"))
+			})
+
+			g.It("does not return an error if the attachment does not exist", func() {
+				err := db.DeleteAttachment(ctx, "doc-1", "file")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = db.DeleteAttachment(ctx, "doc-2", "file")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+		})
+
+		g.Context("when the document is deleted", func() {
+			g.BeforeEach(func() {
+				err := db.PutAttachment(ctx, "doc-1", "file", bytes.NewReader(large))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+
+			// expectNoAttachments recreates doc-1 and verifies that the
+			// attachments of the original document were deleted.
+			expectNoAttachments := func() {
+				// the ID of a soft-deleted document can not be reused until
+				// it is purged
+				_, err := db.PurgeDeleted(ctx, 0)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = db.Save(
+					ctx,
+					&document.Document{
+						ID:      "doc-1",
+						Content: document.StringContent("content-1"),
+					},
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				attachments, err := db.ListAttachments(ctx, "doc-1")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(attachments).To(m.BeEmpty())
+			}
+
+			g.It("deletes the attachments using Delete()", func() {
+				err := db.Delete(ctx, doc)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				expectNoAttachments()
+			})
+
+			g.It("deletes the attachments using DeleteWhere()", func() {
+				_, err := db.DeleteWhere(ctx, protavo.IsOneOf("doc-1"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				expectNoAttachments()
+			})
+
+			g.It("deletes the attachments using DeleteNamespace()", func() {
+				err := db.DeleteNamespace(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				expectNoAttachments()
+			})
+		})
+	})
+}
+
+// errorReader is an io.Reader that always fails.
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) {
+	return 0, errors.New("<read error>")
+}
@@ -0,0 +1,355 @@
+package drivertest
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+// describeChangesSince defines the standard test suite for the
+// protavo.ChangesSince() operation.
+func describeChangesSince(
+	before func() (*protavo.DB, error),
+	after func(),
+) {
+	ctx := context.Background()
+
+	g.Describe("ChangesSince", func() {
+		var db *protavo.DB
+
+		g.BeforeEach(func() {
+			var err error
+			db, err = before()
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			err = db.Save(
+				ctx,
+				&document.Document{
+					ID:      "doc-1",
+					Content: document.StringContent("content-1"),
+				},
+				&document.Document{
+					ID:      "doc-2",
+					Content: document.StringContent("content-2"),
+				},
+				&document.Document{
+					ID:      "doc-3",
+					Content: document.StringContent("content-3"),
+				},
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+		})
+
+		g.AfterEach(func() {
+			_ = db.Close()
+
+			if after != nil {
+				after()
+			}
+		})
+
+		// changesSince returns the changes since seq.
+		changesSince := func(seq uint64) []*driver.Change {
+			var changes []*driver.Change
+
+			err := db.ChangesSince(
+				ctx,
+				seq,
+				func(c *driver.Change) (bool, error) {
+					changes = append(changes, c)
+					return true, nil
+				},
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			return changes
+		}
+
+		// ids returns the document IDs of the given changes.
+		ids := func(changes []*driver.Change) []string {
+			var ids []string
+
+			for _, c := range changes {
+				ids = append(ids, c.DocumentID)
+			}
+
+			return ids
+		}
+
+		g.It("returns every document when the sequence number is zero", func() {
+			changes := changesSince(0)
+			m.Expect(ids(changes)).To(m.Equal([]string{"doc-1", "doc-2", "doc-3"}))
+
+			for _, c := range changes {
+				m.Expect(c.IsDeleted()).To(m.BeFalse())
+				m.Expect(c.Document.ID).To(m.Equal(c.DocumentID))
+			}
+		})
+
+		g.It("returns sequence numbers that increase with each change", func() {
+			changes := changesSince(0)
+			m.Expect(changes).To(m.HaveLen(3))
+			m.Expect(changes[0].Sequence).To(m.BeNumerically(">", 0))
+			m.Expect(changes[1].Sequence).To(m.BeNumerically(">", changes[0].Sequence))
+			m.Expect(changes[2].Sequence).To(m.BeNumerically(">", changes[1].Sequence))
+		})
+
+		g.It("only returns changes after the given sequence number", func() {
+			changes := changesSince(0)
+
+			m.Expect(ids(changesSince(changes[0].Sequence))).To(m.Equal([]string{"doc-2", "doc-3"}))
+			m.Expect(changesSince(changes[2].Sequence)).To(m.BeEmpty())
+		})
+
+		g.It("returns only the most recent change to each document", func() {
+			doc, ok, err := db.Load(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+
+			doc.Content = document.StringContent("content-1-updated")
+			err = db.Save(ctx, doc)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			changes := changesSince(0)
+			m.Expect(ids(changes)).To(m.Equal([]string{"doc-2", "doc-3", "doc-1"}))
+			m.Expect(changes[2].Document.Revision).To(m.Equal(doc.Revision))
+		})
+
+		g.It("returns deletions", func() {
+			doc, ok, err := db.Load(ctx, "doc-2")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+
+			err = db.Delete(ctx, doc)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			changes := changesSince(0)
+			m.Expect(ids(changes)).To(m.Equal([]string{"doc-1", "doc-3", "doc-2"}))
+			m.Expect(changes[2].IsDeleted()).To(m.BeTrue())
+		})
+
+		g.It("returns deletions made by delete-where operations", func() {
+			_, err := db.DeleteWhere(ctx, protavo.IsOneOf("doc-1", "doc-3"))
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			// the order in which the documents are deleted is unspecified
+			changes := changesSince(0)
+			m.Expect(changes).To(m.HaveLen(3))
+			m.Expect(changes[0].DocumentID).To(m.Equal("doc-2"))
+			m.Expect(ids(changes[1:])).To(m.ConsistOf("doc-1", "doc-3"))
+			m.Expect(changes[1].IsDeleted()).To(m.BeTrue())
+			m.Expect(changes[2].IsDeleted()).To(m.BeTrue())
+		})
+
+		g.It("replaces the deletion of a document that is re-created", func() {
+			_, err := db.DeleteByID(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			// the ID of a soft-deleted document can not be reused until it is
+			// purged
+			_, err = db.PurgeDeleted(ctx, 0)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			err = db.Save(ctx, &document.Document{
+				ID:      "doc-1",
+				Content: document.StringContent("content-1"),
+			})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			changes := changesSince(0)
+			m.Expect(ids(changes)).To(m.Equal([]string{"doc-2", "doc-3", "doc-1"}))
+			m.Expect(changes[2].IsDeleted()).To(m.BeFalse())
+		})
+
+		g.It("stops iterating if the function returns false", func() {
+			var count int
+
+			err := db.ChangesSince(
+				ctx,
+				0,
+				func(*driver.Change) (bool, error) {
+					count++
+					return false, nil
+				},
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(count).To(m.Equal(1))
+		})
+
+		g.It("returns the error from the function", func() {
+			expected := errors.New("<error>")
+
+			err := db.ChangesSince(
+				ctx,
+				0,
+				func(*driver.Change) (bool, error) {
+					return false, expected
+				},
+			)
+			m.Expect(err).To(m.Equal(expected))
+		})
+
+		g.When("the namespace is deleted", func() {
+			var highWaterMark uint64
+
+			g.BeforeEach(func() {
+				changes := changesSince(0)
+				highWaterMark = changes[len(changes)-1].Sequence
+
+				err := db.Write(ctx, protavo.DeleteNamespace())
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = db.Save(ctx, &document.Document{
+					ID:      "doc-4",
+					Content: document.StringContent("content-4"),
+				})
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+
+			g.It("continues the sequence from above the previous high-water mark", func() {
+				changes := changesSince(0)
+				m.Expect(ids(changes)).To(m.Equal([]string{"doc-4"}))
+				m.Expect(changes[0].Sequence).To(m.BeNumerically(">", highWaterMark))
+			})
+
+			g.It("returns an error if the sequence number precedes the deletion", func() {
+				err := db.ChangesSince(
+					ctx,
+					highWaterMark,
+					func(*driver.Change) (bool, error) {
+						g.Fail("unexpected change")
+						return false, nil
+					},
+				)
+				m.Expect(protavo.IsStaleSequenceError(err)).To(m.BeTrue())
+			})
+
+			g.It("returns changes since a sequence number that follows the deletion", func() {
+				changes := changesSince(0)
+
+				m.Expect(changesSince(changes[0].Sequence)).To(m.BeEmpty())
+			})
+		})
+
+		g.It("returns an error if the sequence number is greater than that of the most recent write", func() {
+			changes := changesSince(0)
+
+			err := db.ChangesSince(
+				ctx,
+				changes[2].Sequence+1,
+				func(*driver.Change) (bool, error) {
+					return true, nil
+				},
+			)
+			m.Expect(protavo.IsStaleSequenceError(err)).To(m.BeTrue())
+		})
+
+		g.Describe("ApplyChange", func() {
+			g.It("preserves the revision and timestamps of the document", func() {
+				createdAt := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+				updatedAt := time.Date(2002, 3, 4, 5, 6, 7, 0, time.UTC)
+
+				err := db.Write(
+					ctx,
+					protavo.ApplyChange(&driver.Change{
+						Sequence:   100,
+						DocumentID: "doc-4",
+						Document: &document.Document{
+							ID:        "doc-4",
+							Keys:      document.UniqueKeys("key-4"),
+							Headers:   document.Headers{"h": "v"},
+							Content:   document.StringContent("content-4"),
+							Revision:  7,
+							CreatedAt: createdAt,
+							UpdatedAt: updatedAt,
+						},
+					}),
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				doc, ok, err := db.LoadByUniqueKey(ctx, "key-4")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(doc.ID).To(m.Equal("doc-4"))
+				m.Expect(doc.Revision).To(m.BeEquivalentTo(7))
+				m.Expect(doc.CreatedAt.Equal(createdAt)).To(m.BeTrue())
+				m.Expect(doc.UpdatedAt.Equal(updatedAt)).To(m.BeTrue())
+				m.Expect(doc.Headers).To(m.Equal(document.Headers{"h": "v"}))
+				m.Expect(doc.Content).To(m.Equal(document.StringContent("content-4")))
+			})
+
+			g.It("replaces an existing document regardless of its revision", func() {
+				change := &driver.Change{
+					Sequence:   100,
+					DocumentID: "doc-1",
+					Document: &document.Document{
+						ID:        "doc-1",
+						Content:   document.StringContent("replaced"),
+						Revision:  5,
+						CreatedAt: time.Now(),
+						UpdatedAt: time.Now(),
+					},
+				}
+
+				err := db.Write(ctx, protavo.ApplyChange(change))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				// applying the change again has no further effect
+				err = db.Write(ctx, protavo.ApplyChange(change))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				doc, ok, err := db.Load(ctx, "doc-1")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(doc.Revision).To(m.BeEquivalentTo(5))
+				m.Expect(doc.Content).To(m.Equal(document.StringContent("replaced")))
+			})
+
+			g.It("deletes the document if the change is a deletion", func() {
+				change := &driver.Change{
+					Sequence:   100,
+					DocumentID: "doc-1",
+				}
+
+				err := db.Write(ctx, protavo.ApplyChange(change))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = db.Write(ctx, protavo.ApplyChange(change))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				_, ok, err := db.Load(ctx, "doc-1")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeFalse())
+			})
+
+			g.It("records the change in the database's own sequence", func() {
+				before := changesSince(0)
+
+				err := db.Write(
+					ctx,
+					protavo.ApplyChange(&driver.Change{
+						Sequence:   1,
+						DocumentID: "doc-1",
+						Document: &document.Document{
+							ID:        "doc-1",
+							Content:   document.StringContent("replaced"),
+							Revision:  5,
+							CreatedAt: time.Now(),
+							UpdatedAt: time.Now(),
+						},
+					}),
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				changes := changesSince(before[2].Sequence)
+				m.Expect(ids(changes)).To(m.Equal([]string{"doc-1"}))
+			})
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package drivertest
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+// describeClosedTx defines the standard test suite for the use of transactions
+// that have already been committed or closed.
+func describeClosedTx(
+	before func() (*protavo.DB, error),
+	after func(),
+) {
+	ctx := context.Background()
+
+	g.Describe("Closed transactions", func() {
+		var (
+			db  *protavo.DB
+			doc *document.Document
+		)
+
+		g.BeforeEach(func() {
+			var err error
+			db, err = before()
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			doc = &document.Document{
+				ID:      "doc-1",
+				Content: document.StringContent("content-1"),
+			}
+		})
+
+		g.AfterEach(func() {
+			_ = db.Close()
+
+			if after != nil {
+				after()
+			}
+		})
+
+		g.It("returns ErrTxClosed from operations performed after a commit", func() {
+			tx, err := db.BeginWrite(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer tx.Close()
+
+			err = tx.Commit()
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			op := protavo.Save(doc)
+			op.ExecuteInWriteTx(ctx, tx)
+			m.Expect(op.Err()).To(m.Equal(protavo.ErrTxClosed))
+
+			_, err = tx.Savepoint(ctx)
+			m.Expect(err).To(m.Equal(protavo.ErrTxClosed))
+		})
+
+		g.It("returns ErrTxClosed from operations performed after a write transaction is closed", func() {
+			tx, err := db.BeginWrite(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			err = tx.Close()
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			op := protavo.Save(doc)
+			op.ExecuteInWriteTx(ctx, tx)
+			m.Expect(op.Err()).To(m.Equal(protavo.ErrTxClosed))
+
+			err = tx.Commit()
+			m.Expect(err).To(m.Equal(protavo.ErrTxClosed))
+		})
+
+		g.It("returns ErrTxClosed from operations performed after a read transaction is closed", func() {
+			tx, err := db.BeginRead(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			err = tx.Close()
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			op := protavo.FetchAll(nil)
+			op.ExecuteInReadTx(ctx, tx)
+			m.Expect(op.Err()).To(m.Equal(protavo.ErrTxClosed))
+		})
+	})
+}
@@ -0,0 +1,140 @@
+package drivertest
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+// describeContext defines the standard test suite for context cancellation.
+func describeContext(
+	before func() (*protavo.DB, error),
+	after func(),
+) {
+	g.Describe("Context cancellation", func() {
+		var (
+			db     *protavo.DB
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+
+		g.BeforeEach(func() {
+			var err error
+			db, err = before()
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			err = db.Save(
+				context.Background(),
+				&document.Document{
+					ID:      "doc-1",
+					Keys:    document.SharedKeys("foo"),
+					Content: document.StringContent("content-1"),
+				},
+				&document.Document{
+					ID:      "doc-2",
+					Keys:    document.SharedKeys("foo"),
+					Content: document.StringContent("content-2"),
+				},
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			ctx, cancel = context.WithCancel(context.Background())
+		})
+
+		g.AfterEach(func() {
+			cancel()
+			_ = db.Close()
+
+			if after != nil {
+				after()
+			}
+		})
+
+		g.It("does not start a read transaction if the context is already canceled", func() {
+			cancel()
+
+			_, err := db.BeginRead(ctx)
+			m.Expect(err).To(m.Equal(context.Canceled))
+		})
+
+		g.It("does not start a write transaction if the context is already canceled", func() {
+			cancel()
+
+			_, err := db.BeginWrite(ctx)
+			m.Expect(err).To(m.Equal(context.Canceled))
+		})
+
+		g.It("stops fetching if the context is canceled", func() {
+			count := 0
+
+			err := db.Read(
+				ctx,
+				protavo.FetchAll(
+					func(*document.Document) (bool, error) {
+						count++
+						cancel()
+						return true, nil
+					},
+				),
+			)
+			m.Expect(err).To(m.Equal(context.Canceled))
+			m.Expect(count).To(m.Equal(1))
+		})
+
+		g.It("does not fetch if the context is canceled", func() {
+			tx, err := db.BeginRead(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer tx.Close()
+
+			cancel()
+
+			op := protavo.FetchWhere(
+				nil, // should never be invoked
+				protavo.HasKeys("foo"),
+			)
+			op.ExecuteInReadTx(ctx, tx)
+			m.Expect(op.Err()).To(m.Equal(context.Canceled))
+		})
+
+		g.It("does not save if the context is canceled", func() {
+			tx, err := db.BeginWrite(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer tx.Close()
+
+			cancel()
+
+			op := protavo.Save(&document.Document{
+				ID:      "doc-3",
+				Content: document.StringContent("content-3"),
+			})
+			op.ExecuteInWriteTx(ctx, tx)
+			m.Expect(op.Err()).To(m.Equal(context.Canceled))
+		})
+
+		g.It("stops deleting if the context is canceled", func() {
+			var ids []string
+
+			err := db.Write(
+				ctx,
+				protavo.DeleteWhere(
+					func(id string) error {
+						ids = append(ids, id)
+						cancel()
+						return nil
+					},
+					protavo.HasKeys("foo"),
+				),
+			)
+			m.Expect(err).To(m.Equal(context.Canceled))
+			m.Expect(ids).To(m.HaveLen(1))
+
+			// the transaction is rolled back, so the deleted document is retained
+			docs, err := db.LoadAll(context.Background())
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(docs).To(m.HaveLen(2))
+		})
+	})
+}
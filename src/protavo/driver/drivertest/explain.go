@@ -0,0 +1,111 @@
+package drivertest
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/filter"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+// describeExplain defines the standard test suite for the protavo.Explain()
+// operation.
+func describeExplain(
+	before func() (*protavo.DB, error),
+	after func(),
+) {
+	ctx := context.Background()
+
+	g.Describe("Explain", func() {
+		var db *protavo.DB
+
+		g.BeforeEach(func() {
+			var err error
+			db, err = before()
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+		})
+
+		g.AfterEach(func() {
+			_ = db.Close()
+
+			if after != nil {
+				after()
+			}
+		})
+
+		g.When("there are no documents in the database", func() {
+			g.It("does not return an error", func() {
+				op := protavo.Explain(
+					protavo.IsOneOf("doc-1"),
+				)
+
+				err := db.Read(ctx, op)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(op.Plan).NotTo(m.BeNil())
+			})
+		})
+
+		g.When("there are documents in the database", func() {
+			g.BeforeEach(func() {
+				err := db.Save(
+					ctx,
+					&document.Document{
+						ID:      "doc-1",
+						Content: document.StringContent("content-1"),
+						Keys:    document.SharedKeys("foo"),
+					},
+					&document.Document{
+						ID:      "doc-2",
+						Content: document.StringContent("content-2"),
+						Keys:    document.SharedKeys("foo", "bar"),
+					},
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+
+			g.It("returns the optimized filter", func() {
+				plan, err := db.Explain(
+					ctx,
+					protavo.IsOneOf("doc-1", "doc-2"),
+					protavo.IsOneOf("doc-2", "doc-3"),
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(plan.Filter.Conditions).To(m.ConsistOf(
+					&filter.IsOneOf{
+						Values: filter.NewSet("doc-2"),
+					},
+				))
+			})
+
+			g.It("does not require a full scan if the filter can not match any documents", func() {
+				plan, err := db.Explain(
+					ctx,
+					protavo.IsOneOf("doc-1"),
+					protavo.IsOneOf("doc-2"),
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(plan.Filter.Conditions).To(m.BeEmpty())
+				m.Expect(plan.IsFullScan).To(m.BeFalse())
+			})
+
+			g.It("can be combined with other operations", func() {
+				op := protavo.Explain(
+					protavo.HasKeys("foo"),
+				)
+
+				err := db.Write(
+					ctx,
+					protavo.Save(&document.Document{
+						ID:      "doc-3",
+						Content: document.StringContent("content-3"),
+					}),
+					op,
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(op.Plan).NotTo(m.BeNil())
+			})
+		})
+	})
+}
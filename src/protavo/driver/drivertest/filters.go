@@ -2,7 +2,9 @@ package drivertest
 
 import (
 	"context"
+	"time"
 
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jmalloc/protavo/src/protavo"
 	"github.com/jmalloc/protavo/src/protavo/document"
 	"github.com/jmalloc/protavo/src/protavo/filter"
@@ -11,6 +13,19 @@ import (
 	m "github.com/onsi/gomega"
 )
 
+// TextIndex is the full-text index configuration that drivers must use for the
+// full-text search tests to pass.
+var TextIndex = (&document.TextIndex{}).
+	AddFields(&document.StringContentType{}, "value").
+	AddHeaders("tags")
+
+var (
+	// past and future are times that are before and after the time at which any
+	// of the documents in the filter tests are saved.
+	past   = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	future = time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
 // describeFilters defines the standard test suite for Protavo filters.
 func describeFilters(
 	before func() (*protavo.DB, error),
@@ -34,7 +49,11 @@ func describeFilters(
 						"uniq-1": document.UniqueKey,
 						"shar-a": document.SharedKey,
 					},
-					Content: document.StringContent(""),
+					Headers: document.Headers{
+						"tags":  "urgent",
+						"other": "secret",
+					},
+					Content: document.StringContent("The quick brown fox"),
 				},
 				&document.Document{
 					ID: "doc-2",
@@ -43,7 +62,10 @@ func describeFilters(
 						"shar-a": document.SharedKey,
 						"shar-b": document.SharedKey,
 					},
-					Content: document.StringContent(""),
+					Headers: document.Headers{
+						"other": "public",
+					},
+					Content: document.StringContent("the lazy dog"),
 				},
 				&document.Document{
 					ID: "doc-3",
@@ -53,7 +75,10 @@ func describeFilters(
 						"shar-b": document.SharedKey,
 						"shar-c": document.SharedKey,
 					},
-					Content: document.StringContent(""),
+					Headers: document.Headers{
+						"tags": "later",
+					},
+					Content: document.StringContent("quick dog"),
 				},
 				&document.Document{
 					ID: "doc-4",
@@ -64,7 +89,7 @@ func describeFilters(
 						"shar-c": document.SharedKey,
 						"shar-d": document.SharedKey,
 					},
-					Content: document.StringContent(""),
+					Content: document.StringContent("brown dog"),
 				},
 				&document.Document{
 					ID: "doc-5",
@@ -76,7 +101,14 @@ func describeFilters(
 						"shar-d": document.SharedKey,
 						"shar-e": document.SharedKey,
 					},
-					Content: document.StringContent(""),
+					Headers: document.Headers{
+						"tags": "urgent",
+					},
+					Content: document.StringContent("Quick, brown dog!"),
+				},
+				&document.Document{
+					ID:      "doc-6",
+					Content: &wrappers.StringValue{Value: "quick fox"},
 				},
 			)
 
@@ -113,6 +145,196 @@ func describeFilters(
 				},
 				[]string{"doc-3", "doc-4", "doc-5"},
 			),
+			table.Entry(
+				"HasKeyWithPrefix",
+				[]filter.Condition{
+					protavo.HasKeyWithPrefix("shar-d"),
+				},
+				[]string{"doc-4", "doc-5"},
+			),
+			table.Entry(
+				"HasKeyWithPrefix (shared prefix)",
+				[]filter.Condition{
+					protavo.HasKeyWithPrefix("uniq-"),
+				},
+				[]string{"doc-1", "doc-2", "doc-3", "doc-4", "doc-5"},
+			),
+			table.Entry(
+				"HasKeyWithPrefix (multiple prefixes)",
+				[]filter.Condition{
+					protavo.HasKeyWithPrefix("shar-"),
+					protavo.HasKeyWithPrefix("uniq-2"),
+				},
+				[]string{"doc-2"},
+			),
+			table.Entry(
+				"HasKeyWithPrefix (non-existent prefix)",
+				[]filter.Condition{
+					protavo.HasKeyWithPrefix("non-existent"),
+				},
+				[]string{},
+			),
+			table.Entry(
+				"HasKeyWithPrefix and HasKeys",
+				[]filter.Condition{
+					protavo.HasKeyWithPrefix("shar-"),
+					protavo.HasKeys("shar-c"),
+				},
+				[]string{"doc-3", "doc-4", "doc-5"},
+			),
+			table.Entry(
+				"MatchesText",
+				[]filter.Condition{
+					protavo.MatchesText("QUICK dog"),
+				},
+				[]string{"doc-3", "doc-5"},
+			),
+			table.Entry(
+				"MatchesText (registered headers)",
+				[]filter.Condition{
+					protavo.MatchesText("urgent"),
+				},
+				[]string{"doc-1", "doc-5"},
+			),
+			table.Entry(
+				"MatchesText (unregistered headers)",
+				[]filter.Condition{
+					protavo.MatchesText("secret"),
+				},
+				[]string{},
+			),
+			table.Entry(
+				"HasHeader",
+				[]filter.Condition{
+					protavo.HasHeader("tags"),
+				},
+				[]string{"doc-1", "doc-3", "doc-5"},
+			),
+			table.Entry(
+				"HasHeader (multiple headers)",
+				[]filter.Condition{
+					protavo.HasHeader("tags"),
+					protavo.HasHeader("other"),
+				},
+				[]string{"doc-1"},
+			),
+			table.Entry(
+				"HeaderEquals",
+				[]filter.Condition{
+					protavo.HeaderEquals("tags", "urgent"),
+				},
+				[]string{"doc-1", "doc-5"},
+			),
+			table.Entry(
+				"HeaderEquals (conflicting values)",
+				[]filter.Condition{
+					protavo.HeaderEquals("tags", "urgent"),
+					protavo.HeaderEquals("tags", "later"),
+				},
+				[]string{},
+			),
+			table.Entry(
+				"HeaderIn",
+				[]filter.Condition{
+					protavo.HeaderIn("other", "secret", "public", "non-existent"),
+				},
+				[]string{"doc-1", "doc-2"},
+			),
+			table.Entry(
+				"HeaderIn (intersecting values)",
+				[]filter.Condition{
+					protavo.HeaderIn("tags", "urgent", "later"),
+					protavo.HeaderEquals("tags", "later"),
+					protavo.HasHeader("tags"),
+				},
+				[]string{"doc-3"},
+			),
+			table.Entry(
+				"CreatedBetween",
+				[]filter.Condition{
+					protavo.CreatedBetween(past, future),
+				},
+				[]string{"doc-1", "doc-2", "doc-3", "doc-4", "doc-5", "doc-6"},
+			),
+			table.Entry(
+				"CreatedBetween (unbounded)",
+				[]filter.Condition{
+					protavo.CreatedBetween(time.Time{}, time.Time{}),
+				},
+				[]string{"doc-1", "doc-2", "doc-3", "doc-4", "doc-5", "doc-6"},
+			),
+			table.Entry(
+				"CreatedBetween (in the past)",
+				[]filter.Condition{
+					protavo.CreatedBetween(time.Time{}, past),
+				},
+				[]string{},
+			),
+			table.Entry(
+				"UpdatedBetween",
+				[]filter.Condition{
+					protavo.UpdatedBetween(past, time.Time{}),
+				},
+				[]string{"doc-1", "doc-2", "doc-3", "doc-4", "doc-5", "doc-6"},
+			),
+			table.Entry(
+				"UpdatedBetween (in the future)",
+				[]filter.Condition{
+					protavo.UpdatedBetween(future, time.Time{}),
+				},
+				[]string{},
+			),
+			table.Entry(
+				"UpdatedBetween (intersecting ranges)",
+				[]filter.Condition{
+					protavo.UpdatedBetween(past, time.Time{}),
+					protavo.UpdatedBetween(time.Time{}, future),
+				},
+				[]string{"doc-1", "doc-2", "doc-3", "doc-4", "doc-5", "doc-6"},
+			),
+			table.Entry(
+				"UpdatedBetween (disjoint ranges)",
+				[]filter.Condition{
+					protavo.UpdatedBetween(time.Time{}, past),
+					protavo.UpdatedBetween(past, time.Time{}),
+				},
+				[]string{},
+			),
+			table.Entry(
+				"IsContentType",
+				[]filter.Condition{
+					protavo.IsContentType(&document.StringContentType{}),
+				},
+				[]string{"doc-1", "doc-2", "doc-3", "doc-4", "doc-5"},
+			),
+			table.Entry(
+				"IsContentType (multiple types)",
+				[]filter.Condition{
+					protavo.IsContentType(
+						&document.StringContentType{},
+						&wrappers.StringValue{},
+					),
+				},
+				[]string{"doc-1", "doc-2", "doc-3", "doc-4", "doc-5", "doc-6"},
+			),
+			table.Entry(
+				"IsContentType (disjoint types)",
+				[]filter.Condition{
+					protavo.IsContentType(&document.StringContentType{}),
+					protavo.IsContentType(&wrappers.StringValue{}),
+				},
+				[]string{},
+			),
+			table.Entry(
+				"IsContentTypeURL",
+				[]filter.Condition{
+					protavo.IsContentTypeURL(
+						"type.googleapis.com/google.protobuf.StringValue",
+						"type.googleapis.com/non-existent",
+					),
+				},
+				[]string{"doc-6"},
+			),
 			table.Entry(
 				"Everything",
 				[]filter.Condition{
@@ -184,8 +406,132 @@ func describeFilters(
 				},
 				[]string{"doc-2", "doc-3"},
 			),
+
+			// MatchesText first ...
+			table.Entry(
+				"MatchesText, then IsOneOf",
+				[]filter.Condition{
+					protavo.MatchesText("lazy"),
+					protavo.IsOneOf("doc-1", "doc-2", "doc-3"),
+				},
+				[]string{"doc-2"},
+			),
+			table.Entry(
+				"MatchesText, then HasKeys",
+				[]filter.Condition{
+					protavo.MatchesText("lazy"),
+					protavo.HasKeys("shar-a"),
+				},
+				[]string{"doc-2"},
+			),
+
+			// header conditions first ...
+			table.Entry(
+				"HeaderEquals, then HasKeys",
+				[]filter.Condition{
+					protavo.HeaderEquals("tags", "urgent"),
+					protavo.HasKeys("shar-a", "shar-b"),
+				},
+				[]string{"doc-5"},
+			),
+			table.Entry(
+				"HeaderIn, then MatchesText",
+				[]filter.Condition{
+					protavo.HeaderIn("other", "secret", "public"),
+					protavo.MatchesText("dog"),
+				},
+				[]string{"doc-2"},
+			),
+
+			// time ranges first ...
+			table.Entry(
+				"CreatedBetween, then HasKeys",
+				[]filter.Condition{
+					protavo.CreatedBetween(past, future),
+					protavo.HasKeys("shar-d"),
+				},
+				[]string{"doc-4", "doc-5"},
+			),
+
+			// IsContentType first ...
+			table.Entry(
+				"IsContentType, then UpdatedBetween",
+				[]filter.Condition{
+					protavo.IsContentType(&wrappers.StringValue{}),
+					protavo.UpdatedBetween(past, future),
+				},
+				[]string{"doc-6"},
+			),
+
+			// ... and last
+			table.Entry(
+				"IsOneOf, then MatchesText",
+				[]filter.Condition{
+					protavo.IsOneOf("doc-1"),
+					protavo.MatchesText("brown"),
+				},
+				[]string{"doc-1"},
+			),
+			table.Entry(
+				"HasKeys, then MatchesText",
+				[]filter.Condition{
+					protavo.HasKeys("shar-e"),
+					protavo.MatchesText("dog"),
+				},
+				[]string{"doc-5"},
+			),
+			table.Entry(
+				"IsOneOf, then HasHeader",
+				[]filter.Condition{
+					protavo.IsOneOf("doc-1", "doc-2"),
+					protavo.HasHeader("other"),
+				},
+				[]string{"doc-1", "doc-2"},
+			),
+			table.Entry(
+				"IsOneOf, then UpdatedBetween",
+				[]filter.Condition{
+					protavo.IsOneOf("doc-2"),
+					protavo.UpdatedBetween(past, future),
+				},
+				[]string{"doc-2"},
+			),
+			table.Entry(
+				"IsOneOf, then IsContentType",
+				[]filter.Condition{
+					protavo.IsOneOf("doc-1", "doc-6"),
+					protavo.IsContentType(&document.StringContentType{}),
+				},
+				[]string{"doc-1"},
+			),
 		}
 
+		g.It("can find documents that were modified since a checkpoint", func() {
+			checkpoint := time.Now()
+
+			doc, ok, err := db.Load(ctx, "doc-3")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+
+			err = db.Save(ctx, doc)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			docs, err := db.LoadManyWhere(
+				ctx,
+				protavo.UpdatedBetween(checkpoint, time.Time{}),
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(docs).To(m.HaveLen(1))
+			m.Expect(docs[0].ID).To(m.Equal("doc-3"))
+
+			docs, err = db.LoadManyWhere(
+				ctx,
+				protavo.CreatedBetween(checkpoint, time.Time{}),
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(docs).To(m.BeEmpty())
+		})
+
 		table.DescribeTable(
 			"FetchWhere",
 			func(
@@ -0,0 +1,115 @@
+package drivertest
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+// describeIterate defines the standard test suite for the DB.IterateAll() and
+// DB.IterateWhere() methods.
+func describeIterate(
+	before func() (*protavo.DB, error),
+	after func(),
+) {
+	ctx := context.Background()
+
+	g.Describe("Iterator", func() {
+		var db *protavo.DB
+
+		// collect returns the IDs of the documents returned by it, and closes
+		// it.
+		collect := func(it *protavo.Iterator) []string {
+			var ids []string
+
+			for it.Next() {
+				ids = append(ids, it.Document().ID)
+			}
+
+			m.Expect(it.Close()).ShouldNot(m.HaveOccurred())
+
+			return ids
+		}
+
+		g.BeforeEach(func() {
+			var err error
+			db, err = before()
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+		})
+
+		g.AfterEach(func() {
+			_ = db.Close()
+
+			if after != nil {
+				after()
+			}
+		})
+
+		g.When("there are no documents in the database", func() {
+			g.It("does not return any documents", func() {
+				it, err := db.IterateAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(collect(it)).To(m.BeEmpty())
+			})
+		})
+
+		g.When("there are documents in the database", func() {
+			g.BeforeEach(func() {
+				err := db.Save(
+					ctx,
+					&document.Document{
+						ID:      "doc-1",
+						Content: document.StringContent("content-1"),
+						Keys:    document.SharedKeys("foo"),
+					},
+					&document.Document{
+						ID:      "doc-2",
+						Content: document.StringContent("content-2"),
+						Keys:    document.SharedKeys("foo", "bar"),
+					},
+					&document.Document{
+						ID:      "doc-3",
+						Content: document.StringContent("content-3"),
+						Keys:    document.UniqueKeys("uniq"),
+					},
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+
+			g.It("returns every document", func() {
+				it, err := db.IterateAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(collect(it)).To(m.ConsistOf("doc-1", "doc-2", "doc-3"))
+			})
+
+			g.It("returns the documents that match the filter", func() {
+				it, err := db.IterateWhere(ctx, protavo.HasKeys("foo"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(collect(it)).To(m.ConsistOf("doc-1", "doc-2"))
+
+				it, err = db.IterateWhere(ctx, protavo.IsOneOf("doc-1", "doc-4"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(collect(it)).To(m.ConsistOf("doc-1"))
+
+				it, err = db.IterateWhere(ctx, protavo.HasUniqueKeyIn("uniq"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(collect(it)).To(m.ConsistOf("doc-3"))
+			})
+
+			g.It("allows further writes once closed before it is exhausted", func() {
+				it, err := db.IterateAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				m.Expect(it.Next()).To(m.BeTrue())
+				m.Expect(it.Close()).ShouldNot(m.HaveOccurred())
+				m.Expect(it.Next()).To(m.BeFalse())
+
+				_, err = db.DeleteByID(ctx, "doc-1")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+		})
+	})
+}
@@ -0,0 +1,107 @@
+package drivertest
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+// describeListKeys defines the standard test suite for the list-keys
+// operation.
+func describeListKeys(
+	before func() (*protavo.DB, error),
+	after func(),
+) {
+	ctx := context.Background()
+
+	g.Describe("ListKeys", func() {
+		var db *protavo.DB
+
+		g.BeforeEach(func() {
+			var err error
+			db, err = before()
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			err = db.Save(
+				ctx,
+				&document.Document{
+					ID: "doc-1",
+					Keys: document.Keys{
+						"user:1":  document.UniqueKey,
+						"group:a": document.SharedKey,
+					},
+					Content: document.StringContent("content-1"),
+				},
+				&document.Document{
+					ID: "doc-2",
+					Keys: document.Keys{
+						"user:2":  document.UniqueKey,
+						"group:a": document.SharedKey,
+						"group:b": document.SharedKey,
+					},
+					Content: document.StringContent("content-2"),
+				},
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+		})
+
+		g.AfterEach(func() {
+			_ = db.Close()
+
+			if after != nil {
+				after()
+			}
+		})
+
+		g.It("returns the keys with the prefix ordered by name", func() {
+			keys, err := db.ListKeys(ctx, "group:", 0)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(keys).To(m.Equal(
+				[]driver.Key{
+					{Name: "group:a", Type: document.SharedKey, Count: 2},
+					{Name: "group:b", Type: document.SharedKey, Count: 1},
+				},
+			))
+		})
+
+		g.It("returns all keys if the prefix is empty", func() {
+			keys, err := db.ListKeys(ctx, "", 0)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(keys).To(m.HaveLen(4))
+		})
+
+		g.It("returns only keys of the given type", func() {
+			keys, err := db.ListKeys(ctx, "", document.UniqueKey)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(keys).To(m.Equal(
+				[]driver.Key{
+					{Name: "user:1", Type: document.UniqueKey, Count: 1},
+					{Name: "user:2", Type: document.UniqueKey, Count: 1},
+				},
+			))
+		})
+
+		g.It("does not return keys that have been removed from every document", func() {
+			_, err := db.DeleteByID(ctx, "doc-2")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			keys, err := db.ListKeys(ctx, "group:", 0)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(keys).To(m.Equal(
+				[]driver.Key{
+					{Name: "group:a", Type: document.SharedKey, Count: 1},
+				},
+			))
+		})
+
+		g.It("returns an empty slice if no keys have the prefix", func() {
+			keys, err := db.ListKeys(ctx, "other:", 0)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(keys).To(m.BeEmpty())
+		})
+	})
+}
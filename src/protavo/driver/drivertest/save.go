@@ -6,6 +6,7 @@ import (
 
 	"github.com/jmalloc/protavo/src/protavo"
 	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
 	g "github.com/onsi/ginkgo"
 	m "github.com/onsi/gomega"
 )
@@ -158,6 +159,101 @@ func describeSave(
 			})
 		})
 
+		g.When("the document has derived keys", func() {
+			// saveWithDerivedKeys saves doc with the given derived keys, as
+			// the DB does when its schema has key extractors.
+			saveWithDerivedKeys := func(doc *document.Document, keys document.Keys) error {
+				return db.Write(ctx, &driver.Save{
+					Document:    doc,
+					DerivedKeys: keys,
+				})
+			}
+
+			g.It("indexes the derived keys separately from the document's keys", func() {
+				err := saveWithDerivedKeys(doc2, document.UniqueKeys("derived"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(doc2.Keys).To(m.BeEmpty())
+				m.Expect(doc2.DerivedKeys).To(m.Equal(document.UniqueKeys("derived")))
+
+				doc, ok, err := db.LoadByUniqueKey(ctx, "derived")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(doc.Keys).To(m.BeEmpty())
+				m.Expect(doc.DerivedKeys).To(m.Equal(document.UniqueKeys("derived")))
+			})
+
+			g.It("replaces the derived keys each time the document is saved", func() {
+				err := saveWithDerivedKeys(doc2, document.UniqueKeys("old"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = saveWithDerivedKeys(doc2, document.UniqueKeys("new"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				_, ok, err := db.LoadByUniqueKey(ctx, "old")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeFalse())
+
+				_, ok, err = db.LoadByUniqueKey(ctx, "new")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeTrue())
+
+				// the old key is available to other documents
+				err = saveWithDerivedKeys(doc1, document.UniqueKeys("old"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+
+			g.It("prefers the document's own key type over a derived key", func() {
+				err := saveWithDerivedKeys(doc1, document.SharedKeys("uniq", "derived"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				doc, ok, err := db.Load(ctx, "doc-1")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(doc.Keys["uniq"]).To(m.Equal(document.UniqueKey))
+				m.Expect(doc.DerivedKeys).To(m.Equal(document.SharedKeys("derived")))
+			})
+
+			g.It("returns an error if a derived unique key conflicts with another document", func() {
+				err := db.Save(ctx, doc1)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = saveWithDerivedKeys(doc2, document.UniqueKeys("uniq"))
+				m.Expect(protavo.IsDuplicateKeyError(err)).To(m.BeTrue())
+			})
+		})
+
+		g.It("returns a validation error if the document is not valid", func() {
+			doc1.ID = ""
+			doc1.Content = nil
+
+			err := db.Save(ctx, doc1)
+			m.Expect(err).To(m.Equal(
+				&document.ValidationError{
+					Violations: []string{
+						"document ID must not be empty",
+						"document content must not be nil",
+					},
+				},
+			))
+		})
+
+		g.It("returns a validation error if a document saved within a transaction is not valid", func() {
+			tx, err := db.BeginWrite(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer tx.Close()
+
+			doc1.Content = nil
+
+			op := &driver.Save{Document: doc1}
+			op.ExecuteInWriteTx(ctx, tx)
+			m.Expect(op.Err()).To(m.Equal(
+				&document.ValidationError{
+					DocumentID: "doc-1",
+					Violations: []string{"document content must not be nil"},
+				},
+			))
+		})
+
 		g.It("aborts the save if a unique key conflicts with another document", func() {
 			op := protavo.Save(doc1)
 
@@ -0,0 +1,362 @@
+package drivertest
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+// describeSavepoint defines the standard test suite for savepoints and the
+// protavo.Attempt() operation.
+func describeSavepoint(
+	before func() (*protavo.DB, error),
+	after func(),
+) {
+	ctx := context.Background()
+
+	g.Describe("Savepoint", func() {
+		var (
+			db   *protavo.DB
+			doc1 *document.Document
+		)
+
+		g.BeforeEach(func() {
+			var err error
+			db, err = before()
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			doc1 = &document.Document{
+				ID:      "doc-1",
+				Keys:    document.UniqueKeys("uniq"),
+				Content: document.StringContent("content-1"),
+			}
+
+			err = db.Save(ctx, doc1)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+		})
+
+		g.AfterEach(func() {
+			_ = db.Close()
+
+			if after != nil {
+				after()
+			}
+		})
+
+		// write executes ops within tx, failing the test if any of them fail.
+		write := func(tx driver.WriteTx, ops ...driver.Operation) {
+			for _, op := range ops {
+				op.ExecuteInWriteTx(ctx, tx)
+				m.Expect(op.Err()).ShouldNot(m.HaveOccurred())
+			}
+		}
+
+		g.Describe("RollbackTo", func() {
+			var tx driver.WriteTx
+
+			g.BeforeEach(func() {
+				var err error
+				tx, err = db.BeginWrite(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+
+			g.AfterEach(func() {
+				_ = tx.Close()
+			})
+
+			// rollback creates a savepoint, executes ops, then rolls back to the
+			// savepoint and commits the transaction.
+			rollback := func(ops ...driver.Operation) {
+				sp, err := tx.Savepoint(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				write(tx, ops...)
+
+				err = tx.RollbackTo(ctx, sp)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = tx.Commit()
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			}
+
+			g.It("undoes the creation of a document", func() {
+				rollback(
+					protavo.Save(&document.Document{
+						ID:      "doc-2",
+						Keys:    document.UniqueKeys("uniq-2"),
+						Content: document.StringContent("content-2"),
+					}),
+				)
+
+				docs, err := db.LoadAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(docs).To(m.HaveLen(1))
+				m.Expect(docs[0].ID).To(m.Equal("doc-1"))
+
+				_, ok, err := db.LoadByUniqueKey(ctx, "uniq-2")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeFalse())
+			})
+
+			g.It("undoes changes to a document", func() {
+				rollback(
+					protavo.Save(&document.Document{
+						ID:       "doc-1",
+						Revision: doc1.Revision,
+						Keys:     document.UniqueKeys("uniq-2"),
+						Content:  document.StringContent("content-2"),
+					}),
+				)
+
+				doc, ok, err := db.LoadByUniqueKey(ctx, "uniq")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(doc.Equal(doc1)).To(m.BeTrue())
+			})
+
+			g.It("undoes the deletion of a document", func() {
+				rollback(
+					protavo.Delete(doc1),
+				)
+
+				doc, ok, err := db.LoadByUniqueKey(ctx, "uniq")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(doc.Equal(doc1)).To(m.BeTrue())
+			})
+
+			g.It("undoes the deletion of the namespace", func() {
+				rollback(
+					protavo.DeleteNamespace(),
+				)
+
+				doc, ok, err := db.LoadByUniqueKey(ctx, "uniq")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(doc.Equal(doc1)).To(m.BeTrue())
+			})
+
+			g.It("retains changes made before the savepoint", func() {
+				doc2 := &document.Document{
+					ID:      "doc-2",
+					Content: document.StringContent("content-2"),
+				}
+
+				write(tx, protavo.Save(doc2))
+
+				rollback(
+					protavo.Delete(doc1),
+					protavo.Delete(doc2),
+				)
+
+				docs, err := db.LoadAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(docs).To(m.HaveLen(2))
+			})
+
+			g.It("discards nested savepoints", func() {
+				outer, err := tx.Savepoint(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				write(tx, protavo.Delete(doc1))
+
+				inner, err := tx.Savepoint(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = tx.RollbackTo(ctx, outer)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = tx.RollbackTo(ctx, inner)
+				m.Expect(err).Should(m.HaveOccurred())
+			})
+
+			g.It("allows the same savepoint to be rolled back to more than once", func() {
+				sp, err := tx.Savepoint(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				write(tx, protavo.Delete(doc1))
+
+				err = tx.RollbackTo(ctx, sp)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				rollback(
+					protavo.DeleteNamespace(),
+				)
+
+				docs, err := db.LoadAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(docs).To(m.HaveLen(1))
+			})
+		})
+
+		g.Describe("Release", func() {
+			var tx driver.WriteTx
+
+			g.BeforeEach(func() {
+				var err error
+				tx, err = db.BeginWrite(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+
+			g.AfterEach(func() {
+				_ = tx.Close()
+			})
+
+			g.It("keeps the changes made since the savepoint", func() {
+				sp, err := tx.Savepoint(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				write(tx, protavo.Delete(doc1))
+
+				err = tx.Release(ctx, sp)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = tx.Commit()
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				docs, err := db.LoadAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(docs).To(m.BeEmpty())
+			})
+
+			g.It("discards the savepoint", func() {
+				sp, err := tx.Savepoint(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = tx.Release(ctx, sp)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = tx.RollbackTo(ctx, sp)
+				m.Expect(err).Should(m.HaveOccurred())
+			})
+
+			g.It("allows an enclosing savepoint to be rolled back to", func() {
+				outer, err := tx.Savepoint(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				inner, err := tx.Savepoint(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				write(tx, protavo.Delete(doc1))
+
+				err = tx.Release(ctx, inner)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = tx.RollbackTo(ctx, outer)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = tx.Commit()
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				docs, err := db.LoadAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(docs).To(m.HaveLen(1))
+			})
+		})
+
+		g.Describe("Attempt", func() {
+			conflicting := func() *document.Document {
+				return &document.Document{
+					ID:      "doc-2",
+					Keys:    document.UniqueKeys("uniq"), // doc1 already has this key
+					Content: document.StringContent("content-2"),
+				}
+			}
+
+			g.It("undoes the changes made by the attempt if an operation fails", func() {
+				err := db.Write(
+					ctx,
+					protavo.Attempt(
+						nil,
+						protavo.Save(&document.Document{
+							ID:      "doc-3",
+							Content: document.StringContent("content-3"),
+						}),
+						protavo.Save(conflicting()),
+					),
+				)
+				m.Expect(protavo.IsDuplicateKeyError(err)).To(m.BeTrue())
+
+				docs, err := db.LoadAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(docs).To(m.HaveLen(1))
+			})
+
+			g.It("allows alternative work to be performed in the same transaction", func() {
+				err := db.Write(
+					ctx,
+					protavo.Attempt(
+						func(ctx context.Context, tx driver.WriteTx, err error) error {
+							if !protavo.IsDuplicateKeyError(err) {
+								return err
+							}
+
+							doc := conflicting()
+							doc.Keys = document.UniqueKeys("uniq-2")
+
+							op := protavo.Save(doc)
+							op.ExecuteInWriteTx(ctx, tx)
+							return op.Err()
+						},
+						protavo.Save(conflicting()),
+					),
+					protavo.Save(&document.Document{
+						ID:      "doc-3",
+						Content: document.StringContent("content-3"),
+					}),
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				doc, ok, err := db.LoadByUniqueKey(ctx, "uniq-2")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeTrue())
+				m.Expect(doc.ID).To(m.Equal("doc-2"))
+
+				docs, err := db.LoadAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(docs).To(m.HaveLen(3))
+			})
+
+			g.It("fails with the error returned by the error handler", func() {
+				expected := errors.New("<error>")
+
+				err := db.Write(
+					ctx,
+					protavo.Attempt(
+						func(context.Context, driver.WriteTx, error) error {
+							return expected
+						},
+						protavo.Save(conflicting()),
+					),
+				)
+				m.Expect(err).To(m.Equal(expected))
+			})
+
+			g.It("does not call the error handler if all operations succeed", func() {
+				called := false
+
+				err := db.Write(
+					ctx,
+					protavo.Attempt(
+						func(context.Context, driver.WriteTx, error) error {
+							called = true
+							return nil
+						},
+						protavo.Delete(doc1),
+					),
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(called).To(m.BeFalse())
+
+				docs, err := db.LoadAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(docs).To(m.BeEmpty())
+			})
+		})
+	})
+}
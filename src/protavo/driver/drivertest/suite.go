@@ -7,6 +7,9 @@ import (
 
 // Describe defines the standard test suite for an implementation of
 // protavo.Driver.
+//
+// The driver returned by before must be configured to use TextIndex, if it
+// supports full-text search.
 func Describe(
 	name string,
 	before func() (*protavo.DB, error),
@@ -47,12 +50,20 @@ func describe(
 	g.Describe(name, func() {
 		describeFetchAll(before, after)
 		describeFetchWhere(before, after)
+		describeIterate(before, after)
+		describeExplain(before, after)
 		describeSave(before, after)
 		describeForceSave(before, after)
 		describeDelete(before, after)
 		describeDeleteWhere(before, after)
 		describeDeleteNamespace(before, after)
+		describeSavepoint(before, after)
+		describeAttachments(before, after)
+		describeChangesSince(before, after)
+		describeListKeys(before, after)
+		describeClosedTx(before, after)
 
 		describeFilters(before, after)
+		describeContext(before, after)
 	})
 }
@@ -0,0 +1,44 @@
+package driver
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo/filter"
+)
+
+// QueryPlan describes how a driver locates the documents that match a filter.
+type QueryPlan struct {
+	// Filter is the optimized form of the filter that the plan applies to.
+	Filter *filter.Filter
+
+	// Strategy is a driver-specific name for the approach used to locate the
+	// matching documents.
+	Strategy string
+
+	// Cost is the driver's estimate of the number of records that need to be
+	// examined in order to execute the plan.
+	Cost int
+
+	// IsFullScan is true if every document in the namespace must be examined
+	// in order to execute the plan.
+	IsFullScan bool
+}
+
+// Explain is a request to describe the query plan used to find the documents
+// that match a filter.
+type Explain struct {
+	operation
+
+	Filter *filter.Filter
+	Plan   *QueryPlan
+}
+
+// ExecuteInReadTx executes this operation within the context of tx.
+func (o *Explain) ExecuteInReadTx(ctx context.Context, tx ReadTx) {
+	tx.Explain(ctx, o)
+}
+
+// ExecuteInWriteTx executes this operation within the context of tx.
+func (o *Explain) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
+	o.ExecuteInReadTx(ctx, tx)
+}
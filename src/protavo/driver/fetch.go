@@ -30,3 +30,24 @@ func (o *Fetch) ExecuteInReadTx(ctx context.Context, tx ReadTx) {
 func (o *Fetch) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
 	o.ExecuteInReadTx(ctx, tx)
 }
+
+// Cursor iterates over the documents that match a filter, fetching them one at
+// a time as they are requested.
+type Cursor interface {
+	// Next returns the next document.
+	//
+	// ok is false if there are no more documents. The cursor can not be used
+	// once the transaction it was obtained from has been closed.
+	Next(ctx context.Context) (doc *document.Document, ok bool, err error)
+}
+
+// CursorTx is a ReadTx that can fetch documents using a Cursor.
+//
+// It is implemented by drivers that are able to suspend a fetch between
+// documents without the use of a separate goroutine. It is optional.
+type CursorTx interface {
+	ReadTx
+
+	// Cursor returns a cursor over the documents that match f.
+	Cursor(ctx context.Context, f *filter.Filter) (Cursor, error)
+}
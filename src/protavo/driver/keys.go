@@ -0,0 +1,45 @@
+package driver
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+)
+
+// Key describes an indexing key, and the documents that have it.
+type Key struct {
+	// Name is the key itself.
+	Name string
+
+	// Type is the type of the key, which is the same for every document that
+	// has it.
+	Type document.KeyType
+
+	// Count is the number of documents that have the key.
+	Count int
+}
+
+// ListKeys is a request to list the indexing keys within a namespace.
+type ListKeys struct {
+	operation
+
+	// Prefix restricts the result to those keys that begin with this string.
+	Prefix string
+
+	// Type, if non-zero, restricts the result to keys of this type.
+	Type document.KeyType
+
+	// Keys is populated with the matching keys, ordered by name, once the
+	// operation is executed.
+	Keys []Key
+}
+
+// ExecuteInReadTx executes this operation within the context of tx.
+func (o *ListKeys) ExecuteInReadTx(ctx context.Context, tx ReadTx) {
+	tx.ListKeys(ctx, o)
+}
+
+// ExecuteInWriteTx executes this operation within the context of tx.
+func (o *ListKeys) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
+	o.ExecuteInReadTx(ctx, tx)
+}
@@ -0,0 +1,395 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo/driver"
+)
+
+// Driver is a driver.Driver that invokes a chain of interceptors for each
+// transaction and operation performed by another driver.
+type Driver struct {
+	next         driver.Driver
+	interceptors []Interceptor
+}
+
+// Wrap returns a driver that invokes the given interceptors for each
+// transaction and operation performed by d.
+//
+// The interceptors are invoked in order, such that the first interceptor is the
+// outermost.
+func Wrap(d driver.Driver, interceptors ...Interceptor) *Driver {
+	return &Driver{d, interceptors}
+}
+
+// BeginRead starts a new read-only transaction.
+func (d *Driver) BeginRead(ctx context.Context, ns string) (driver.ReadTx, error) {
+	var tx driver.ReadTx
+
+	err := d.call(
+		ctx,
+		&Call{Namespace: ns, Method: "BeginRead"},
+		func(ctx context.Context) (err error) {
+			tx, err = d.next.BeginRead(ctx, ns)
+			return err
+		},
+	)
+	if err != nil {
+		if tx != nil {
+			tx.Close()
+		}
+
+		return nil, err
+	}
+
+	return &readTx{d, ns, tx}, nil
+}
+
+// BeginWrite starts a new read/write transaction.
+func (d *Driver) BeginWrite(ctx context.Context, ns string) (driver.WriteTx, error) {
+	var tx driver.WriteTx
+
+	err := d.call(
+		ctx,
+		&Call{Namespace: ns, Method: "BeginWrite"},
+		func(ctx context.Context) (err error) {
+			tx, err = d.next.BeginWrite(ctx, ns)
+			return err
+		},
+	)
+	if err != nil {
+		if tx != nil {
+			tx.Close()
+		}
+
+		return nil, err
+	}
+
+	return &writeTx{readTx{d, ns, tx}, tx, ctx}, nil
+}
+
+// Write atomically executes a set of read/write operations.
+//
+// If the underlying driver implements driver.Writer, the call is intercepted
+// as a single "Write" call, and each of the operations is intercepted
+// individually. The transaction used by the underlying driver is not visible to
+// the interceptors, so there are no "BeginWrite" or "Commit" calls.
+//
+// Otherwise, the operations are executed within a transaction started by
+// BeginWrite().
+func (d *Driver) Write(ctx context.Context, ns string, ops []driver.Operation) error {
+	w, ok := d.next.(driver.Writer)
+	if !ok {
+		return driver.ExecuteWrite(ctx, d, ns, ops)
+	}
+
+	wrapped := make([]driver.Operation, len(ops))
+	for i, op := range ops {
+		wrapped[i] = &operation{op, d, ns}
+	}
+
+	return d.call(
+		ctx,
+		&Call{Namespace: ns, Method: "Write"},
+		func(ctx context.Context) error {
+			return w.Write(ctx, ns, wrapped)
+		},
+	)
+}
+
+// Close closes the underlying driver.
+func (d *Driver) Close() error {
+	return d.next.Close()
+}
+
+// call invokes the interceptors for c, followed by h.
+func (d *Driver) call(ctx context.Context, c *Call, h Handler) error {
+	return chain(d.interceptors, c, h)(ctx)
+}
+
+// callOperation invokes the interceptors for a call that executes op, followed
+// by h.
+//
+// If an interceptor fails the call without calling h, op is marked as executed
+// with the interceptor's error.
+func (d *Driver) callOperation(
+	ctx context.Context,
+	ns string,
+	method string,
+	op driver.Operation,
+	h Handler,
+) {
+	called := false
+
+	err := d.call(
+		ctx,
+		&Call{Namespace: ns, Method: method, Operation: op},
+		func(ctx context.Context) error {
+			called = true
+			return h(ctx)
+		},
+	)
+
+	if !called {
+		op.MarkExecuted(err)
+	}
+}
+
+// readTx is a driver.ReadTx that invokes interceptors for each operation.
+type readTx struct {
+	d    *Driver
+	ns   string
+	next driver.ReadTx
+}
+
+func (tx *readTx) Fetch(ctx context.Context, op *driver.Fetch) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"Fetch",
+		op,
+		func(ctx context.Context) error {
+			tx.next.Fetch(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *readTx) Explain(ctx context.Context, op *driver.Explain) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"Explain",
+		op,
+		func(ctx context.Context) error {
+			tx.next.Explain(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *readTx) GetAttachment(ctx context.Context, op *driver.GetAttachment) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"GetAttachment",
+		op,
+		func(ctx context.Context) error {
+			tx.next.GetAttachment(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *readTx) ListAttachments(ctx context.Context, op *driver.ListAttachments) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"ListAttachments",
+		op,
+		func(ctx context.Context) error {
+			tx.next.ListAttachments(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *readTx) ListKeys(ctx context.Context, op *driver.ListKeys) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"ListKeys",
+		op,
+		func(ctx context.Context) error {
+			tx.next.ListKeys(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *readTx) ChangesSince(ctx context.Context, op *driver.ChangesSince) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"ChangesSince",
+		op,
+		func(ctx context.Context) error {
+			tx.next.ChangesSince(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *readTx) Close() error {
+	return tx.next.Close()
+}
+
+// writeTx is a driver.WriteTx that invokes interceptors for each operation.
+type writeTx struct {
+	readTx
+	next driver.WriteTx
+
+	// ctx is the context in which the transaction was started. It is passed
+	// to the interceptors for the "Commit" call, as Commit() does not accept
+	// a context of its own.
+	ctx context.Context
+}
+
+func (tx *writeTx) Save(ctx context.Context, op *driver.Save) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"Save",
+		op,
+		func(ctx context.Context) error {
+			tx.next.Save(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *writeTx) Delete(ctx context.Context, op *driver.Delete) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"Delete",
+		op,
+		func(ctx context.Context) error {
+			tx.next.Delete(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *writeTx) DeleteWhere(ctx context.Context, op *driver.DeleteWhere) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"DeleteWhere",
+		op,
+		func(ctx context.Context) error {
+			tx.next.DeleteWhere(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *writeTx) DeleteNamespace(ctx context.Context, op *driver.DeleteNamespace) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"DeleteNamespace",
+		op,
+		func(ctx context.Context) error {
+			tx.next.DeleteNamespace(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *writeTx) Restore(ctx context.Context, op *driver.Restore) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"Restore",
+		op,
+		func(ctx context.Context) error {
+			tx.next.Restore(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *writeTx) PurgeDeleted(ctx context.Context, op *driver.PurgeDeleted) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"PurgeDeleted",
+		op,
+		func(ctx context.Context) error {
+			tx.next.PurgeDeleted(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *writeTx) PutAttachment(ctx context.Context, op *driver.PutAttachment) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"PutAttachment",
+		op,
+		func(ctx context.Context) error {
+			tx.next.PutAttachment(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *writeTx) DeleteAttachment(ctx context.Context, op *driver.DeleteAttachment) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"DeleteAttachment",
+		op,
+		func(ctx context.Context) error {
+			tx.next.DeleteAttachment(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *writeTx) ApplyChange(ctx context.Context, op *driver.ApplyChange) {
+	tx.d.callOperation(
+		ctx,
+		tx.ns,
+		"ApplyChange",
+		op,
+		func(ctx context.Context) error {
+			tx.next.ApplyChange(ctx, op)
+			return op.Err()
+		},
+	)
+}
+
+func (tx *writeTx) Savepoint(ctx context.Context) (driver.Savepoint, error) {
+	return tx.next.Savepoint(ctx)
+}
+
+func (tx *writeTx) RollbackTo(ctx context.Context, sp driver.Savepoint) error {
+	return tx.next.RollbackTo(ctx, sp)
+}
+
+func (tx *writeTx) Release(ctx context.Context, sp driver.Savepoint) error {
+	return tx.next.Release(ctx, sp)
+}
+
+func (tx *writeTx) Commit() error {
+	return tx.d.call(
+		tx.ctx,
+		&Call{Namespace: tx.ns, Method: "Commit"},
+		func(context.Context) error {
+			return tx.next.Commit()
+		},
+	)
+}
+
+// operation is a driver.Operation that executes another operation within a
+// transaction that invokes interceptors.
+//
+// It is used to intercept operations that are passed to the underlying
+// driver's Write() method, which executes them within transactions that are
+// not visible to the interceptors.
+type operation struct {
+	driver.Operation
+
+	d  *Driver
+	ns string
+}
+
+func (o *operation) ExecuteInWriteTx(ctx context.Context, tx driver.WriteTx) {
+	o.Operation.ExecuteInWriteTx(
+		ctx,
+		&writeTx{readTx{o.d, o.ns, tx}, tx, ctx},
+	)
+}
@@ -0,0 +1,294 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver/drivertest"
+	. "github.com/jmalloc/protavo/src/protavo/driver/middleware"
+	"github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+func init() {
+	for _, batch := range []bool{false, true} {
+		var dir string
+		batch := batch
+
+		name := "middleware.Driver"
+		if batch {
+			name += " (batched writes)"
+		}
+
+		drivertest.Describe(
+			name,
+			func() (*protavo.DB, error) {
+				var (
+					d   *protavobolt.ExclusiveDriver
+					err error
+				)
+
+				dir, d, err = openBolt(batch)
+				if err != nil {
+					return nil, err
+				}
+
+				return protavo.NewDB(
+					Wrap(d, Logging(log.New(ioutil.Discard, "", 0), 0)),
+				)
+			},
+			func() {
+				_ = os.RemoveAll(dir)
+			},
+		)
+	}
+}
+
+var _ = g.Describe("Driver", func() {
+	var (
+		ctx   = context.Background()
+		dir   string
+		inner *protavobolt.ExclusiveDriver
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		dir, inner, err = openBolt(false)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.AfterEach(func() {
+		_ = inner.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	// record returns an interceptor that appends the string representation of
+	// each call to calls, prefixed with the given name.
+	record := func(name string, calls *[]string) Interceptor {
+		return func(ctx context.Context, c *Call, next Handler) error {
+			*calls = append(*calls, name+": "+c.String())
+			return next(ctx)
+		}
+	}
+
+	g.It("invokes the interceptors in order for each call", func() {
+		var calls []string
+
+		db, err := protavo.NewDB(
+			Wrap(
+				inner,
+				record("a", &calls),
+				record("b", &calls),
+			),
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = db.Namespace("ns").Save(
+			ctx,
+			&document.Document{
+				ID:      "doc-1",
+				Content: document.StringContent("content-1"),
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		m.Expect(calls).To(m.Equal([]string{
+			"a: Write in 'ns' namespace",
+			"b: Write in 'ns' namespace",
+			"a: Save 'doc-1' in 'ns' namespace",
+			"b: Save 'doc-1' in 'ns' namespace",
+		}))
+	})
+
+	g.It("intercepts the start and commit of explicit transactions", func() {
+		var calls []string
+
+		db, err := protavo.NewDB(
+			Wrap(inner, record("a", &calls)),
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		tx, err := db.BeginWrite(ctx)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer tx.Close()
+
+		op := protavo.Save(&document.Document{
+			ID:      "doc-1",
+			Content: document.StringContent("content-1"),
+		})
+		op.ExecuteInWriteTx(ctx, tx)
+		m.Expect(op.Err()).ShouldNot(m.HaveOccurred())
+
+		err = tx.Commit()
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		m.Expect(calls).To(m.Equal([]string{
+			"a: BeginWrite",
+			"a: Save 'doc-1'",
+			"a: Commit",
+		}))
+	})
+
+	g.It("passes the context from BeginWrite to the interceptors for the commit", func() {
+		type key struct{}
+		var value interface{}
+
+		db, err := protavo.NewDB(
+			Wrap(
+				inner,
+				func(ctx context.Context, c *Call, next Handler) error {
+					if c.Method == "Commit" {
+						value = ctx.Value(key{})
+					}
+
+					return next(ctx)
+				},
+			),
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		tx, err := db.BeginWrite(
+			context.WithValue(ctx, key{}, "<value>"),
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer tx.Close()
+
+		err = tx.Commit()
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(value).To(m.Equal("<value>"))
+	})
+
+	g.It("fails the operation if an interceptor does not call the next handler", func() {
+		expected := errors.New("<error>")
+
+		db, err := protavo.NewDB(
+			Wrap(
+				inner,
+				func(ctx context.Context, c *Call, next Handler) error {
+					if c.Method == "Save" {
+						return expected
+					}
+
+					return next(ctx)
+				},
+			),
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = db.Save(
+			ctx,
+			&document.Document{
+				ID:      "doc-1",
+				Content: document.StringContent("content-1"),
+			},
+		)
+		m.Expect(err).To(m.Equal(expected))
+
+		docs, err := db.LoadAll(ctx)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(docs).To(m.BeEmpty())
+	})
+
+	g.Describe("Logging", func() {
+		g.It("logs failed calls", func() {
+			var buf bytes.Buffer
+
+			db, err := protavo.NewDB(
+				Wrap(inner, Logging(log.New(&buf, "", 0), time.Hour)),
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			_, err = db.DeleteByID(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(buf.String()).To(m.BeEmpty())
+
+			err = db.Save(
+				ctx,
+				&document.Document{
+					ID:       "doc-1",
+					Revision: 1,
+					Content:  document.StringContent("content-1"),
+				},
+			)
+			m.Expect(err).Should(m.HaveOccurred())
+			m.Expect(buf.String()).To(m.HavePrefix("Save 'doc-1' failed after "))
+		})
+
+		g.It("logs successful calls that exceed the threshold", func() {
+			var buf bytes.Buffer
+
+			db, err := protavo.NewDB(
+				Wrap(inner, Logging(log.New(&buf, "", 0), 0)),
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			_, err = db.LoadAll(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(buf.String()).To(m.MatchRegexp(
+				`^BeginRead completed in .+\nFetch completed in .+\n$`,
+			))
+		})
+	})
+
+	g.Describe("Metrics", func() {
+		g.It("records the result of each call", func() {
+			stats := &Stats{}
+
+			db, err := protavo.NewDB(
+				Wrap(inner, Metrics(stats)),
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			doc := &document.Document{
+				ID:      "doc-1",
+				Content: document.StringContent("content-1"),
+			}
+
+			err = db.Save(ctx, doc)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			doc.Revision = 0
+			err = db.Save(ctx, doc)
+			m.Expect(err).Should(m.HaveOccurred())
+
+			s := stats.Snapshot()
+			m.Expect(s["Write"].Calls).To(m.BeEquivalentTo(2))
+			m.Expect(s["Write"].Errors).To(m.BeEquivalentTo(1))
+			m.Expect(s["Save"].Calls).To(m.BeEquivalentTo(2))
+			m.Expect(s["Save"].Errors).To(m.BeEquivalentTo(1))
+			m.Expect(s["Save"].MaxDuration).To(m.BeNumerically(">", 0))
+		})
+	})
+})
+
+// openBolt returns a BoltDB driver that uses a temporary file.
+//
+// It returns the temporary directory that contains the file, which must be
+// removed after the driver is closed.
+func openBolt(batch bool) (string, *protavobolt.ExclusiveDriver, error) {
+	dir, err := ioutil.TempDir("", "protavo-middleware-")
+	if err != nil {
+		return "", nil, err
+	}
+
+	db, err := bolt.Open(path.Join(dir, "bolt.db"), 0600, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return dir, &protavobolt.ExclusiveDriver{
+		DB:          db,
+		BatchWrites: batch,
+		TextIndex:   drivertest.TextIndex,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmalloc/protavo/src/protavo/driver"
+)
+
+// Call describes a single call to a driver or one of its transactions.
+type Call struct {
+	// Namespace is the namespace that the call applies to.
+	Namespace string
+
+	// Method is the name of the method being called. It is one of "BeginRead",
+	// "BeginWrite", "Write", "Commit", or the name of the transaction method
+	// that executes Operation, such as "Fetch" or "Save".
+	Method string
+
+	// Operation is the operation being executed. It is nil if the call does not
+	// execute an operation, such as when starting or committing a transaction.
+	Operation driver.Operation
+}
+
+// String returns a human-readable description of the call.
+func (c *Call) String() string {
+	s := c.Method
+
+	switch op := c.Operation.(type) {
+	case *driver.Save:
+		s += " '" + op.Document.ID + "'"
+	case *driver.Delete:
+		s += " '" + op.Document.ID + "'"
+	case *driver.Restore:
+		s += " '" + op.DocumentID + "'"
+	case *driver.PutAttachment:
+		s += " '" + op.Name + "' of '" + op.DocumentID + "'"
+	case *driver.GetAttachment:
+		s += " '" + op.Name + "' of '" + op.DocumentID + "'"
+	case *driver.ListAttachments:
+		s += " of '" + op.DocumentID + "'"
+	case *driver.DeleteAttachment:
+		s += " '" + op.Name + "' of '" + op.DocumentID + "'"
+	case *driver.ListKeys:
+		s += fmt.Sprintf(" with prefix '%s'", op.Prefix)
+	case *driver.ChangesSince:
+		s += fmt.Sprintf(" %d", op.Since)
+	case *driver.ApplyChange:
+		s += fmt.Sprintf(" %d to '%s'", op.Change.Sequence, op.Change.DocumentID)
+	}
+
+	if c.Namespace != "" {
+		s += fmt.Sprintf(" in '%s' namespace", c.Namespace)
+	}
+
+	return s
+}
+
+// Handler is a function that performs a call.
+type Handler func(ctx context.Context) error
+
+// Interceptor is a function that is invoked for each call.
+//
+// It must call next to perform the call, and return the error that next
+// returns, if any. It may replace ctx, such as to add a tracing span, or fail
+// the call without calling next.
+type Interceptor func(ctx context.Context, c *Call, next Handler) error
+
+// Chain returns an interceptor that invokes each of the given interceptors in
+// order, such that the first interceptor is the outermost.
+func Chain(interceptors ...Interceptor) Interceptor {
+	return func(ctx context.Context, c *Call, next Handler) error {
+		return chain(interceptors, c, next)(ctx)
+	}
+}
+
+// chain returns a handler that invokes interceptors, followed by h.
+func chain(interceptors []Interceptor, c *Call, h Handler) Handler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		fn, next := interceptors[i], h
+
+		h = func(ctx context.Context) error {
+			return fn(ctx, c, next)
+		}
+	}
+
+	return h
+}
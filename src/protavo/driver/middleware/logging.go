@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"time"
+)
+
+// Logger is the interface used by the logging interceptor to write log
+// messages. It is implemented by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Logging returns an interceptor that logs calls to l.
+//
+// Calls that fail are always logged. Calls that succeed are only logged if they
+// take at least threshold to complete. A threshold of zero logs every call,
+// while a positive threshold can be used to report slow queries.
+func Logging(l Logger, threshold time.Duration) Interceptor {
+	return func(ctx context.Context, c *Call, next Handler) error {
+		start := time.Now()
+		err := next(ctx)
+		elapsed := time.Since(start)
+
+		if err != nil {
+			l.Printf("%s failed after %s: %s", c, elapsed, err)
+		} else if elapsed >= threshold {
+			l.Printf("%s completed in %s", c, elapsed)
+		}
+
+		return err
+	}
+}
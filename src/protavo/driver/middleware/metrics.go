@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Recorder is an interface for recording metrics about driver calls, such as
+// by forwarding them to a metrics system.
+type Recorder interface {
+	// Record records the result of a call that took d to complete. err is the
+	// error returned by the call, if any.
+	Record(c *Call, d time.Duration, err error)
+}
+
+// Metrics returns an interceptor that records the duration and result of each
+// call using r.
+func Metrics(r Recorder) Interceptor {
+	return func(ctx context.Context, c *Call, next Handler) error {
+		start := time.Now()
+		err := next(ctx)
+		r.Record(c, time.Since(start), err)
+
+		return err
+	}
+}
+
+// Stats is a Recorder that aggregates metrics about the calls to each method
+// in memory.
+type Stats struct {
+	m       sync.Mutex
+	methods map[string]MethodStats
+}
+
+// MethodStats holds the aggregated metrics for calls to a single method.
+type MethodStats struct {
+	// Calls is the total number of calls.
+	Calls uint64
+
+	// Errors is the number of calls that failed.
+	Errors uint64
+
+	// TotalDuration is the sum of the durations of all calls.
+	TotalDuration time.Duration
+
+	// MaxDuration is the duration of the slowest call.
+	MaxDuration time.Duration
+}
+
+// Record records the result of a call that took d to complete.
+func (s *Stats) Record(c *Call, d time.Duration, err error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.methods == nil {
+		s.methods = map[string]MethodStats{}
+	}
+
+	ms := s.methods[c.Method]
+	ms.Calls++
+	ms.TotalDuration += d
+
+	if err != nil {
+		ms.Errors++
+	}
+
+	if d > ms.MaxDuration {
+		ms.MaxDuration = d
+	}
+
+	s.methods[c.Method] = ms
+}
+
+// Snapshot returns the metrics recorded so far, keyed by method name.
+func (s *Stats) Snapshot() map[string]MethodStats {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	snapshot := make(map[string]MethodStats, len(s.methods))
+	for m, ms := range s.methods {
+		snapshot[m] = ms
+	}
+
+	return snapshot
+}
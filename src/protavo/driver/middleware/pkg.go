@@ -0,0 +1,4 @@
+// Package middleware provides a driver.Driver decorator that invokes a chain of
+// interceptors for each transaction and operation, along with built-in
+// interceptors for logging and metrics.
+package middleware
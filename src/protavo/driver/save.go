@@ -12,9 +12,18 @@ type Save struct {
 
 	Document *document.Document
 	Force    bool
+
+	// DerivedKeys is the set of keys derived from the document's content. They
+	// are indexed along with the document's keys, and replace any keys that
+	// were derived when the document was last saved. They are set by the DB
+	// from its schema.
+	DerivedKeys document.Keys
 }
 
 // ExecuteInWriteTx executes this operation within the context of tx.
+//
+// The document is not validated. The DB validates each document before it is
+// saved.
 func (o *Save) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
 	tx.Save(ctx, o)
 }
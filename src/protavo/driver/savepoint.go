@@ -0,0 +1,61 @@
+package driver
+
+import (
+	"context"
+)
+
+// Savepoint identifies a point within a write transaction to which changes can
+// be rolled back. Its value is only meaningful to the transaction that created
+// it.
+type Savepoint uint64
+
+// AttemptFunc is a function that is invoked when one of the operations in an
+// attempt fails.
+//
+// It is called after the changes made by the attempt have been rolled back, and
+// may perform alternative work within tx. The attempt fails with the returned
+// error, if it is non-nil.
+type AttemptFunc func(ctx context.Context, tx WriteTx, err error) error
+
+// Attempt is a request to execute a sequence of operations, undoing their
+// changes if any of them fail, without aborting the transaction.
+type Attempt struct {
+	operation
+
+	Operations []Operation
+	OnError    AttemptFunc
+}
+
+// ExecuteInWriteTx executes this operation within the context of tx.
+func (o *Attempt) ExecuteInWriteTx(ctx context.Context, tx WriteTx) {
+	sp, err := tx.Savepoint(ctx)
+	if err != nil {
+		o.MarkExecuted(err)
+		return
+	}
+
+	for _, op := range o.Operations {
+		op.ExecuteInWriteTx(ctx, tx)
+
+		err := op.Err()
+		if err == nil {
+			continue
+		}
+
+		if rerr := tx.RollbackTo(ctx, sp); rerr != nil {
+			o.MarkExecuted(rerr)
+			return
+		}
+
+		if o.OnError != nil {
+			err = o.OnError(ctx, tx, err)
+		}
+
+		o.MarkExecuted(err)
+		return
+	}
+
+	o.MarkExecuted(
+		tx.Release(ctx, sp),
+	)
+}
@@ -7,6 +7,11 @@ import (
 // ReadTx is a transaction that can not modify the database.
 type ReadTx interface {
 	Fetch(ctx context.Context, op *Fetch)
+	Explain(ctx context.Context, op *Explain)
+	GetAttachment(ctx context.Context, op *GetAttachment)
+	ListAttachments(ctx context.Context, op *ListAttachments)
+	ChangesSince(ctx context.Context, op *ChangesSince)
+	ListKeys(ctx context.Context, op *ListKeys)
 
 	Close() error
 }
@@ -19,6 +24,25 @@ type WriteTx interface {
 	Delete(ctx context.Context, op *Delete)
 	DeleteWhere(ctx context.Context, op *DeleteWhere)
 	DeleteNamespace(ctx context.Context, op *DeleteNamespace)
+	Restore(ctx context.Context, op *Restore)
+	PurgeDeleted(ctx context.Context, op *PurgeDeleted)
+	PutAttachment(ctx context.Context, op *PutAttachment)
+	DeleteAttachment(ctx context.Context, op *DeleteAttachment)
+	ApplyChange(ctx context.Context, op *ApplyChange)
+
+	// Savepoint records the current state of the transaction, such that the
+	// changes made after this point can be undone by passing the returned
+	// savepoint to RollbackTo().
+	Savepoint(ctx context.Context) (Savepoint, error)
+
+	// RollbackTo undoes the changes made since sp was created, without ending
+	// the transaction. Savepoints created after sp are discarded.
+	RollbackTo(ctx context.Context, sp Savepoint) error
+
+	// Release discards sp, and any savepoints created after it, keeping the
+	// changes made since sp was created. The driver may then discard the
+	// information it retained in order to roll back to sp.
+	Release(ctx context.Context, sp Savepoint) error
 
 	Commit() error
 }
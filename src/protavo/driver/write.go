@@ -0,0 +1,56 @@
+package driver
+
+import "context"
+
+// Writer is an optional interface implemented by drivers that provide their
+// own mechanism for executing a set of write operations, such as coalescing
+// concurrent writes into a single transaction.
+type Writer interface {
+	// Write atomically executes a set of read/write operations.
+	Write(ctx context.Context, ns string, ops []Operation) error
+}
+
+// Write atomically executes a set of read/write operations using d.
+//
+// If d implements Writer, its Write() method is used, otherwise the operations
+// are executed using ExecuteWrite().
+func Write(
+	ctx context.Context,
+	d Driver,
+	ns string,
+	ops []Operation,
+) error {
+	if w, ok := d.(Writer); ok {
+		return w.Write(ctx, ns, ops)
+	}
+
+	return ExecuteWrite(ctx, d, ns, ops)
+}
+
+// ExecuteWrite atomically executes a set of read/write operations within a
+// new write transaction.
+//
+// The operations are executed in order. The transaction is only committed if
+// all of the operations succeed.
+func ExecuteWrite(
+	ctx context.Context,
+	d Driver,
+	ns string,
+	ops []Operation,
+) error {
+	tx, err := d.BeginWrite(ctx, ns)
+	if err != nil {
+		return err
+	}
+	defer tx.Close()
+
+	for _, op := range ops {
+		op.ExecuteInWriteTx(ctx, tx)
+
+		if err := op.Err(); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -1,6 +1,11 @@
 package protavo
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+)
 
 // OptimisticLockError is an error that occurs when an attempt to modify a
 // document fails because the incorrect document revision was provided with the
@@ -34,8 +39,10 @@ func (e *OptimisticLockError) Error() string {
 // IsOptimisticLockError returns true if err represents an optimistic lock
 // failure.
 func IsOptimisticLockError(err error) bool {
-	_, ok := err.(*OptimisticLockError)
-	return ok
+	return matches(err, func(err error) bool {
+		_, ok := err.(*OptimisticLockError)
+		return ok
+	})
 }
 
 // DuplicateKeyError is an error that occurs when an attempt is made to save a
@@ -57,6 +64,198 @@ func (e *DuplicateKeyError) Error() string {
 
 // IsDuplicateKeyError returns true if err represents a duplicate key error.
 func IsDuplicateKeyError(err error) bool {
-	_, ok := err.(*DuplicateKeyError)
-	return ok
+	return matches(err, func(err error) bool {
+		_, ok := err.(*DuplicateKeyError)
+		return ok
+	})
+}
+
+// NotFoundError is an error that occurs when an operation requires a document
+// that does not exist.
+type NotFoundError struct {
+	DocumentID string
+	Operation  string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf(
+		"cannot %s '%s', the document does not exist",
+		e.Operation,
+		e.DocumentID,
+	)
+}
+
+// IsNotFoundError returns true if err represents a missing document.
+func IsNotFoundError(err error) bool {
+	return matches(err, func(err error) bool {
+		_, ok := err.(*NotFoundError)
+		return ok
+	})
+}
+
+// NamespaceNotFoundError is an error that occurs when an operation requires a
+// namespace that does not exist.
+type NamespaceNotFoundError struct {
+	Namespace string
+	Operation string
+}
+
+func (e *NamespaceNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"cannot %s, the '%s' namespace does not exist",
+		e.Operation,
+		e.Namespace,
+	)
+}
+
+// IsNamespaceNotFoundError returns true if err represents a missing namespace.
+func IsNamespaceNotFoundError(err error) bool {
+	return matches(err, func(err error) bool {
+		_, ok := err.(*NamespaceNotFoundError)
+		return ok
+	})
+}
+
+// DataIntegrityError is an error that occurs when a driver finds that its
+// persisted data is missing or inconsistent.
+//
+// Bucket and DocumentID are empty if the problem is not specific to a
+// particular bucket or document. The meaning of Bucket is driver-specific.
+type DataIntegrityError struct {
+	Namespace   string
+	Bucket      string
+	DocumentID  string
+	Description string
+}
+
+func (e *DataIntegrityError) Error() string {
+	if e.Namespace == "" {
+		return "data integrity error: " + e.Description
+	}
+
+	return fmt.Sprintf(
+		"data integrity error within '%s' namespace: %s",
+		e.Namespace,
+		e.Description,
+	)
+}
+
+// IsDataIntegrityError returns true if err represents a data integrity error.
+func IsDataIntegrityError(err error) bool {
+	return matches(err, func(err error) bool {
+		_, ok := err.(*DataIntegrityError)
+		return ok
+	})
+}
+
+// InvalidDocumentError is an error that occurs when an attempt is made to save
+// a document that can not be stored.
+type InvalidDocumentError struct {
+	DocumentID string
+	Reason     string
+}
+
+func (e *InvalidDocumentError) Error() string {
+	if e.DocumentID == "" {
+		return "cannot save document, " + e.Reason
+	}
+
+	return fmt.Sprintf(
+		"cannot save '%s', %s",
+		e.DocumentID,
+		e.Reason,
+	)
+}
+
+// IsInvalidDocumentError returns true if err represents an invalid document,
+// including a document that failed validation.
+func IsInvalidDocumentError(err error) bool {
+	return matches(err, func(err error) bool {
+		switch err.(type) {
+		case *InvalidDocumentError, *document.ValidationError:
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+// StaleSequenceError is an error that occurs when the changes since a
+// sequence number are requested, but the namespace's history no longer begins
+// at or before that sequence number.
+//
+// This occurs when the namespace has been deleted since the write with the
+// given sequence number, or when the given sequence number is greater than
+// that of the namespace's most recent write. The consumer of the changes must
+// discard the documents it has obtained from the namespace and request the
+// changes since sequence number 0.
+type StaleSequenceError struct {
+	Namespace  string
+	GivenSeq   uint64
+	CurrentSeq uint64
+	ResetSeq   uint64
+}
+
+func (e *StaleSequenceError) Error() string {
+	if e.GivenSeq < e.ResetSeq {
+		return fmt.Sprintf(
+			"cannot fetch changes since sequence number %d, the '%s' namespace was deleted at sequence number %d",
+			e.GivenSeq,
+			e.Namespace,
+			e.ResetSeq,
+		)
+	}
+
+	return fmt.Sprintf(
+		"cannot fetch changes since sequence number %d, the most recent write to the '%s' namespace has sequence number %d",
+		e.GivenSeq,
+		e.Namespace,
+		e.CurrentSeq,
+	)
+}
+
+// IsStaleSequenceError returns true if err indicates that the changes since a
+// sequence number are no longer available.
+func IsStaleSequenceError(err error) bool {
+	return matches(err, func(err error) bool {
+		_, ok := err.(*StaleSequenceError)
+		return ok
+	})
+}
+
+// ErrTxClosed is returned when an operation is performed within a transaction
+// that has already been committed or closed.
+var ErrTxClosed = errors.New("the transaction has already been committed or closed")
+
+// IsTxClosedError returns true if err indicates that a transaction has already
+// been committed or closed.
+func IsTxClosedError(err error) bool {
+	return matches(err, func(err error) bool {
+		return err == ErrTxClosed
+	})
+}
+
+// matches returns true if fn returns true for err, or for any error that it
+// wraps.
+//
+// Errors are unwrapped using their Unwrap() method, as per the errors package
+// in Go 1.13 and later. All of the errors in this package can be used with
+// errors.Is() and errors.As().
+func matches(err error, fn func(error) bool) bool {
+	for err != nil {
+		if fn(err) {
+			return true
+		}
+
+		w, ok := err.(interface {
+			Unwrap() error
+		})
+		if !ok {
+			return false
+		}
+
+		err = w.Unwrap()
+	}
+
+	return false
 }
@@ -0,0 +1,50 @@
+//go:build go1.13
+// +build go1.13
+
+package protavo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavobolt"
+)
+
+func ExampleOptimisticLockError() {
+	// First, initialize a database. We'll use the BoltDB driver for examples.
+	db, err := protavobolt.OpenTemp(0600, nil)
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	// Next, attempt to save a new document with a non-zero revision.
+	err = db.Save(
+		context.Background(),
+		&document.Document{
+			ID:       "person:1",
+			Revision: 3,
+			Content:  document.StringContent("Alice"),
+		},
+	)
+
+	// The errors returned by drivers can be wrapped, and still inspected using
+	// errors.As() or errors.Is().
+	err = fmt.Errorf("could not save alice: %w", err)
+
+	var e *OptimisticLockError
+	if errors.As(err, &e) {
+		fmt.Printf("%s has revision %d\n", e.DocumentID, e.ActualRev)
+	}
+
+	fmt.Println(IsOptimisticLockError(err))
+	fmt.Println(errors.Is(err, ErrTxClosed))
+
+	// Output:
+	// person:1 has revision 0
+	// true
+	// false
+}
@@ -0,0 +1,36 @@
+package protavo_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavobolt"
+)
+
+func ExampleIsNotFoundError() {
+	// First, initialize a database. We'll use the BoltDB driver for examples.
+	db, err := protavobolt.OpenTemp(0600, nil)
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	// Attachments can only be added to documents that exist, so attempting to
+	// attach a file to a document that has never been saved fails.
+	err = db.Namespace("people").PutAttachment(
+		context.Background(),
+		"person:1",
+		"avatar.png",
+		strings.NewReader("<image data>"),
+	)
+
+	// The namespace has never been used either, so the error is more specific.
+	fmt.Println(IsNotFoundError(err))
+	fmt.Println(IsNamespaceNotFoundError(err))
+
+	// Output:
+	// false
+	// true
+}
@@ -0,0 +1,80 @@
+package protavo_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	. "github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavobolt"
+)
+
+func ExampleDB_LoadByUniqueKey_keyExtractor() {
+	// First, define a schema with a key extractor that derives a unique key
+	// from each email address that is stored as document content.
+	schema := (&document.Schema{}).AddKeyExtractor(
+		&wrappers.StringValue{},
+		func(m proto.Message) document.Keys {
+			return document.UniqueKeys(
+				"email:" + m.(*wrappers.StringValue).Value,
+			)
+		},
+	)
+
+	// Next, initialize a database that uses the schema. We'll use the BoltDB
+	// driver for examples.
+	db, err := protavobolt.OpenTemp(
+		0600,
+		nil,
+		protavobolt.WithDBOptions(WithSchema(schema)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	// The document is saved without any keys being set explicitly.
+	doc := &document.Document{
+		ID:      "email:1",
+		Content: &wrappers.StringValue{Value: "alice@example.org"},
+	}
+
+	if err := db.Save(context.Background(), doc); err != nil {
+		panic(err)
+	}
+
+	// But it can still be found by the derived key.
+	found, ok, err := db.LoadByUniqueKey(
+		context.Background(),
+		"email:alice@example.org",
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(ok, found.ID)
+
+	// The derived keys are recomputed each time the document is saved, so
+	// once the content changes, the old key no longer matches.
+	doc.Content = &wrappers.StringValue{Value: "bob@example.org"}
+
+	if err := db.Save(context.Background(), doc); err != nil {
+		panic(err)
+	}
+
+	_, ok, err = db.LoadByUniqueKey(
+		context.Background(),
+		"email:alice@example.org",
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(ok, len(doc.Keys), len(doc.DerivedKeys))
+
+	// Output:
+	// true email:1
+	// false 0 1
+}
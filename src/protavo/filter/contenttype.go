@@ -0,0 +1,25 @@
+package filter
+
+import "github.com/jmalloc/protavo/src/protavo/document"
+
+// IsContentType is a condition that matches documents with content of any of
+// a given set of types. Types are identified by their type URL, as returned by
+// document.TypeURL().
+type IsContentType struct {
+	TypeURLs Set
+}
+
+// IsSatisfiedBy returns true if doc meets this condition.
+func (c *IsContentType) IsSatisfiedBy(doc *document.Document) bool {
+	if doc.Content == nil {
+		return false
+	}
+
+	_, ok := c.TypeURLs[document.TypeURL(doc.Content)]
+	return ok
+}
+
+// Accept calls v.IsContentType(c).
+func (c *IsContentType) Accept(v Visitor) (bool, error) {
+	return v.IsContentType(c)
+}
@@ -0,0 +1,61 @@
+package filter
+
+import "github.com/jmalloc/protavo/src/protavo/document"
+
+// HasHeader is a condition that matches documents that have a specific
+// header, regardless of its value.
+type HasHeader struct {
+	Name string
+}
+
+// IsSatisfiedBy returns true if doc meets this condition.
+func (c *HasHeader) IsSatisfiedBy(doc *document.Document) bool {
+	_, ok := doc.Headers[c.Name]
+	return ok
+}
+
+// Accept calls v.HasHeader(c).
+func (c *HasHeader) Accept(v Visitor) (bool, error) {
+	return v.HasHeader(c)
+}
+
+// HeaderEquals is a condition that matches documents that have a specific
+// header with a specific value.
+type HeaderEquals struct {
+	Name  string
+	Value string
+}
+
+// IsSatisfiedBy returns true if doc meets this condition.
+func (c *HeaderEquals) IsSatisfiedBy(doc *document.Document) bool {
+	v, ok := doc.Headers[c.Name]
+	return ok && v == c.Value
+}
+
+// Accept calls v.HeaderEquals(c).
+func (c *HeaderEquals) Accept(v Visitor) (bool, error) {
+	return v.HeaderEquals(c)
+}
+
+// HeaderIn is a condition that matches documents that have a specific header
+// with a value in a given set.
+type HeaderIn struct {
+	Name   string
+	Values Set
+}
+
+// IsSatisfiedBy returns true if doc meets this condition.
+func (c *HeaderIn) IsSatisfiedBy(doc *document.Document) bool {
+	v, ok := doc.Headers[c.Name]
+	if !ok {
+		return false
+	}
+
+	_, ok = c.Values[v]
+	return ok
+}
+
+// Accept calls v.HeaderIn(c).
+func (c *HeaderIn) Accept(v Visitor) (bool, error) {
+	return v.HeaderIn(c)
+}
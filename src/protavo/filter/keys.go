@@ -1,6 +1,10 @@
 package filter
 
-import "github.com/jmalloc/protavo/src/protavo/document"
+import (
+	"strings"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+)
 
 // HasKeys is a condition that matches documents that have all of a given
 // set of keys.
@@ -10,8 +14,10 @@ type HasKeys struct {
 
 // IsSatisfiedBy returns true if doc meets this condition.
 func (c *HasKeys) IsSatisfiedBy(doc *document.Document) bool {
+	keys := doc.IndexedKeys()
+
 	for k := range c.Values {
-		if _, ok := doc.Keys[k]; !ok {
+		if _, ok := keys[k]; !ok {
 			return false
 		}
 	}
@@ -32,16 +38,18 @@ type HasUniqueKeyIn struct {
 
 // IsSatisfiedBy returns true if doc meets this condition.
 func (c *HasUniqueKeyIn) IsSatisfiedBy(doc *document.Document) bool {
+	keys := doc.IndexedKeys()
+
 	// iterate the smaller of the two sets
-	if len(c.Values) <= len(doc.Keys) {
+	if len(c.Values) <= len(keys) {
 		for k := range c.Values {
-			t, ok := doc.Keys[k]
+			t, ok := keys[k]
 			if ok && t == document.UniqueKey {
 				return true
 			}
 		}
 	} else {
-		for k, t := range doc.Keys {
+		for k, t := range keys {
 			if t == document.UniqueKey {
 				if _, ok := c.Values[k]; ok {
 					return true
@@ -57,3 +65,25 @@ func (c *HasUniqueKeyIn) IsSatisfiedBy(doc *document.Document) bool {
 func (c *HasUniqueKeyIn) Accept(v Visitor) (bool, error) {
 	return v.HasUniqueKeyIn(c)
 }
+
+// HasKeyWithPrefix is a condition that matches documents that have at least
+// one key that begins with a given prefix.
+type HasKeyWithPrefix struct {
+	Prefix string
+}
+
+// IsSatisfiedBy returns true if doc meets this condition.
+func (c *HasKeyWithPrefix) IsSatisfiedBy(doc *document.Document) bool {
+	for k := range doc.IndexedKeys() {
+		if strings.HasPrefix(k, c.Prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Accept calls v.HasKeyWithPrefix(c).
+func (c *HasKeyWithPrefix) Accept(v Visitor) (bool, error) {
+	return v.HasKeyWithPrefix(c)
+}
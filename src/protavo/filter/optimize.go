@@ -1,5 +1,10 @@
 package filter
 
+import (
+	"sort"
+	"strings"
+)
+
 // Optimize performs basic optimization of the given filter.
 func Optimize(f *Filter) *Filter {
 	o := &optimizer{}
@@ -28,6 +33,26 @@ func Optimize(f *Filter) *Filter {
 		conds = append(conds, o.hasKeys)
 	}
 
+	conds = append(conds, o.keyPrefixConditions()...)
+
+	if o.matchesText != nil {
+		conds = append(conds, o.matchesText)
+	}
+
+	conds = append(conds, o.headerConditions()...)
+
+	if o.createdBetween != nil {
+		conds = append(conds, o.createdBetween)
+	}
+
+	if o.updatedBetween != nil {
+		conds = append(conds, o.updatedBetween)
+	}
+
+	if o.isContentType != nil {
+		conds = append(conds, o.isContentType)
+	}
+
 	// TODO(jmalloc): we could scan o.hasKeys to look for any keys that are also in
 	// o.hasUniqueKeyIn and remove it from the set.
 
@@ -46,6 +71,15 @@ type optimizer struct {
 	hasUniqueKeyInCount int
 	hasKeys             *HasKeys
 	hasKeysCount        int
+	hasKeyWithPrefix    map[string]*HasKeyWithPrefix
+	matchesText         *MatchesText
+	matchesTextCount    int
+	hasHeader           map[string]*HasHeader
+	headerIn            map[string]*HeaderIn
+	createdBetween      *CreatedBetween
+	updatedBetween      *UpdatedBetween
+	isContentType       *IsContentType
+	isContentTypeCount  int
 }
 
 func (o *optimizer) IsOneOf(c *IsOneOf) (bool, error) {
@@ -129,3 +163,214 @@ func (o *optimizer) HasKeys(c *HasKeys) (bool, error) {
 
 	return true, nil
 }
+
+func (o *optimizer) HasKeyWithPrefix(c *HasKeyWithPrefix) (bool, error) {
+	if o.hasKeyWithPrefix == nil {
+		o.hasKeyWithPrefix = map[string]*HasKeyWithPrefix{}
+	}
+
+	o.hasKeyWithPrefix[c.Prefix] = c
+
+	return true, nil
+}
+
+func (o *optimizer) MatchesText(c *MatchesText) (bool, error) {
+	if len(c.Terms) == 0 {
+		return true, nil
+	}
+
+	o.matchesTextCount++
+
+	// if this is the first condition of this type we've seen, use it as is.
+	if o.matchesTextCount == 1 {
+		o.matchesText = c
+		return true, nil
+	}
+
+	// if this is the second condition we've seen, perform a copy-on-write
+	if o.matchesTextCount == 2 {
+		o.matchesText = &MatchesText{
+			Terms: o.matchesText.Terms.Copy(),
+		}
+	}
+
+	// compute the union of the terms from the existing condition and this one.
+	o.matchesText.Terms.UnionInPlace(c.Terms)
+
+	return true, nil
+}
+
+func (o *optimizer) HasHeader(c *HasHeader) (bool, error) {
+	if o.hasHeader == nil {
+		o.hasHeader = map[string]*HasHeader{}
+	}
+
+	o.hasHeader[c.Name] = c
+
+	return true, nil
+}
+
+func (o *optimizer) HeaderEquals(c *HeaderEquals) (bool, error) {
+	return o.HeaderIn(&HeaderIn{
+		Name:   c.Name,
+		Values: NewSet(c.Value),
+	})
+}
+
+func (o *optimizer) HeaderIn(c *HeaderIn) (bool, error) {
+	// if there are no values, there can be no matches
+	if len(c.Values) == 0 {
+		return false, nil
+	}
+
+	if o.headerIn == nil {
+		o.headerIn = map[string]*HeaderIn{}
+	}
+
+	x, ok := o.headerIn[c.Name]
+
+	// if this is the first condition for this header we've seen, store a copy so
+	// that the intersection with subsequent conditions can be computed in-place.
+	if !ok {
+		o.headerIn[c.Name] = &HeaderIn{
+			Name:   c.Name,
+			Values: c.Values.Copy(),
+		}
+
+		return true, nil
+	}
+
+	// compute the intersection of the values from the existing condition and this
+	// new one. bail early if the intersection is empty.
+	x.Values.IntersectInPlace(c.Values)
+
+	return len(x.Values) > 0, nil
+}
+
+func (o *optimizer) CreatedBetween(c *CreatedBetween) (bool, error) {
+	// if this is the first condition of this type we've seen, use it as is, but
+	// bail early if the range itself is empty.
+	if o.createdBetween == nil {
+		o.createdBetween = c
+		return !isEmptyRange(c.After, c.Before), nil
+	}
+
+	// otherwise, compute the intersection of the existing range and this new
+	// one. bail early if the intersection is empty.
+	after, before, ok := intersectRange(
+		o.createdBetween.After, o.createdBetween.Before,
+		c.After, c.Before,
+	)
+
+	o.createdBetween = &CreatedBetween{after, before}
+
+	return ok, nil
+}
+
+func (o *optimizer) UpdatedBetween(c *UpdatedBetween) (bool, error) {
+	// if this is the first condition of this type we've seen, use it as is, but
+	// bail early if the range itself is empty.
+	if o.updatedBetween == nil {
+		o.updatedBetween = c
+		return !isEmptyRange(c.After, c.Before), nil
+	}
+
+	// otherwise, compute the intersection of the existing range and this new
+	// one. bail early if the intersection is empty.
+	after, before, ok := intersectRange(
+		o.updatedBetween.After, o.updatedBetween.Before,
+		c.After, c.Before,
+	)
+
+	o.updatedBetween = &UpdatedBetween{after, before}
+
+	return ok, nil
+}
+
+func (o *optimizer) IsContentType(c *IsContentType) (bool, error) {
+	// if there are no types, there can be no matches
+	if len(c.TypeURLs) == 0 {
+		return false, nil
+	}
+
+	o.isContentTypeCount++
+
+	// if this is the first condition of this type we've seen, use it as is.
+	if o.isContentTypeCount == 1 {
+		o.isContentType = c
+		return true, nil
+	}
+
+	// if this is the second condition we've seen, perform a copy-on-write
+	if o.isContentTypeCount == 2 {
+		o.isContentType = &IsContentType{
+			TypeURLs: o.isContentType.TypeURLs.Copy(),
+		}
+	}
+
+	// compute the intersection of the types from the existing condition and this
+	// new one. bail early if the intersection is empty.
+	o.isContentType.TypeURLs.IntersectInPlace(c.TypeURLs)
+
+	return len(o.isContentType.TypeURLs) > 0, nil
+}
+
+// keyPrefixConditions returns the 'HasKeyWithPrefix' conditions, sorted by
+// prefix.
+//
+// Any condition with a prefix that is itself a prefix of another condition's
+// prefix is omitted, as it is implied by the other condition.
+func (o *optimizer) keyPrefixConditions() []Condition {
+	prefixes := make([]string, 0, len(o.hasKeyWithPrefix))
+
+	for p := range o.hasKeyWithPrefix {
+		prefixes = append(prefixes, p)
+	}
+
+	sort.Strings(prefixes)
+
+	var conds []Condition
+
+	for i, p := range prefixes {
+		// once sorted, any prefix that implies this one immediately follows it
+		if i+1 < len(prefixes) && strings.HasPrefix(prefixes[i+1], p) {
+			continue
+		}
+
+		conds = append(conds, o.hasKeyWithPrefix[p])
+	}
+
+	return conds
+}
+
+// headerConditions returns the conditions on headers, sorted by header name.
+//
+// There is at most one condition per header. 'HasHeader' conditions are
+// omitted for any header that also has a 'HeaderIn' condition, as it is implied.
+func (o *optimizer) headerConditions() []Condition {
+	names := make([]string, 0, len(o.hasHeader)+len(o.headerIn))
+
+	for n := range o.headerIn {
+		names = append(names, n)
+	}
+
+	for n := range o.hasHeader {
+		if _, ok := o.headerIn[n]; !ok {
+			names = append(names, n)
+		}
+	}
+
+	sort.Strings(names)
+
+	conds := make([]Condition, len(names))
+
+	for i, n := range names {
+		if c, ok := o.headerIn[n]; ok {
+			conds[i] = c
+		} else {
+			conds[i] = o.hasHeader[n]
+		}
+	}
+
+	return conds
+}
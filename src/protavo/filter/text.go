@@ -0,0 +1,31 @@
+package filter
+
+import "github.com/jmalloc/protavo/src/protavo/document"
+
+// MatchesText is a condition that matches documents with searchable text that
+// contains all of a given set of terms.
+type MatchesText struct {
+	Terms Set
+}
+
+// IsSatisfiedBy returns true if doc meets this condition.
+//
+// The text index used by a driver is not known when the condition is evaluated
+// in memory, so the terms are taken from all of the document's text, as per
+// doc.AllTextTerms().
+func (c *MatchesText) IsSatisfiedBy(doc *document.Document) bool {
+	terms := NewSet(doc.AllTextTerms()...)
+
+	for t := range c.Terms {
+		if _, ok := terms[t]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Accept calls v.MatchesText(c).
+func (c *MatchesText) Accept(v Visitor) (bool, error) {
+	return v.MatchesText(c)
+}
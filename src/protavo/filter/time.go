@@ -0,0 +1,97 @@
+package filter
+
+import (
+	"time"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+)
+
+// CreatedBetween is a condition that matches documents that were created
+// within a time range.
+//
+// The range includes After but excludes Before. A zero time leaves that end of
+// the range unbounded.
+type CreatedBetween struct {
+	After  time.Time
+	Before time.Time
+}
+
+// Contains returns true if t is within the time range.
+func (c *CreatedBetween) Contains(t time.Time) bool {
+	return isInRange(t, c.After, c.Before)
+}
+
+// IsSatisfiedBy returns true if doc meets this condition.
+func (c *CreatedBetween) IsSatisfiedBy(doc *document.Document) bool {
+	return c.Contains(doc.CreatedAt)
+}
+
+// Accept calls v.CreatedBetween(c).
+func (c *CreatedBetween) Accept(v Visitor) (bool, error) {
+	return v.CreatedBetween(c)
+}
+
+// UpdatedBetween is a condition that matches documents that were last updated
+// within a time range.
+//
+// The range includes After but excludes Before. A zero time leaves that end of
+// the range unbounded.
+type UpdatedBetween struct {
+	After  time.Time
+	Before time.Time
+}
+
+// Contains returns true if t is within the time range.
+func (c *UpdatedBetween) Contains(t time.Time) bool {
+	return isInRange(t, c.After, c.Before)
+}
+
+// IsSatisfiedBy returns true if doc meets this condition.
+func (c *UpdatedBetween) IsSatisfiedBy(doc *document.Document) bool {
+	return c.Contains(doc.UpdatedAt)
+}
+
+// Accept calls v.UpdatedBetween(c).
+func (c *UpdatedBetween) Accept(v Visitor) (bool, error) {
+	return v.UpdatedBetween(c)
+}
+
+// isInRange returns true if t is within the range that includes after but
+// excludes before, either of which may be zero to leave the range unbounded.
+func isInRange(t, after, before time.Time) bool {
+	if !after.IsZero() && t.Before(after) {
+		return false
+	}
+
+	if !before.IsZero() && !t.Before(before) {
+		return false
+	}
+
+	return true
+}
+
+// intersectRange returns the intersection of two time ranges.
+//
+// It returns false if the intersection is empty.
+func intersectRange(
+	after1, before1 time.Time,
+	after2, before2 time.Time,
+) (after, before time.Time, ok bool) {
+	after = after1
+	if after.IsZero() || after2.After(after) {
+		after = after2
+	}
+
+	before = before1
+	if before.IsZero() || (!before2.IsZero() && before2.Before(before)) {
+		before = before2
+	}
+
+	return after, before, !isEmptyRange(after, before)
+}
+
+// isEmptyRange returns true if the range that includes after but excludes
+// before can not contain any times.
+func isEmptyRange(after, before time.Time) bool {
+	return !after.IsZero() && !before.IsZero() && !after.Before(before)
+}
@@ -5,4 +5,12 @@ type Visitor interface {
 	IsOneOf(*IsOneOf) (bool, error)
 	HasUniqueKeyIn(*HasUniqueKeyIn) (bool, error)
 	HasKeys(*HasKeys) (bool, error)
+	HasKeyWithPrefix(*HasKeyWithPrefix) (bool, error)
+	MatchesText(*MatchesText) (bool, error)
+	HasHeader(*HasHeader) (bool, error)
+	HeaderEquals(*HeaderEquals) (bool, error)
+	HeaderIn(*HeaderIn) (bool, error)
+	CreatedBetween(*CreatedBetween) (bool, error)
+	UpdatedBetween(*UpdatedBetween) (bool, error)
+	IsContentType(*IsContentType) (bool, error)
 }
@@ -1,6 +1,12 @@
 package protavo
 
-import "github.com/jmalloc/protavo/src/protavo/filter"
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/filter"
+)
 
 // IsOneOf matches all of the documents with the given IDs.
 func IsOneOf(ids ...string) filter.Condition {
@@ -28,6 +34,95 @@ func HasKeys(keys ...string) filter.Condition {
 	}
 }
 
+// HasKeyWithPrefix matches documents that have at least one key that begins
+// with the given prefix, regardless of key type.
+func HasKeyWithPrefix(prefix string) filter.Condition {
+	return &filter.HasKeyWithPrefix{
+		Prefix: prefix,
+	}
+}
+
+// MatchesText matches documents with searchable text that contains all of the
+// terms in the given query.
+//
+// The query is split into terms using document.Tokenize(). Which of a
+// document's content fields and headers are searchable is determined by the
+// driver's configuration, such as protavobolt.ExclusiveDriver.TextIndex.
+func MatchesText(query string) filter.Condition {
+	return &filter.MatchesText{
+		Terms: filter.NewSet(document.Tokenize(query)...),
+	}
+}
+
+// HasHeader matches documents that have the given header, regardless of its
+// value.
+func HasHeader(name string) filter.Condition {
+	return &filter.HasHeader{
+		Name: name,
+	}
+}
+
+// HeaderEquals matches documents that have the given header with the given
+// value.
+func HeaderEquals(name, value string) filter.Condition {
+	return &filter.HeaderEquals{
+		Name:  name,
+		Value: value,
+	}
+}
+
+// HeaderIn matches documents that have the given header with any of the given
+// values.
+func HeaderIn(name string, values ...string) filter.Condition {
+	return &filter.HeaderIn{
+		Name:   name,
+		Values: filter.NewSet(values...),
+	}
+}
+
+// CreatedBetween matches documents that were created at or after the time
+// after, and before the time before.
+//
+// Either time may be zero, in which case that end of the range is unbounded.
+func CreatedBetween(after, before time.Time) filter.Condition {
+	return &filter.CreatedBetween{
+		After:  after,
+		Before: before,
+	}
+}
+
+// UpdatedBetween matches documents that were last updated at or after the time
+// after, and before the time before.
+//
+// Either time may be zero, in which case that end of the range is unbounded.
+// For example, UpdatedBetween(checkpoint, time.Time{}) matches the documents
+// that have been modified since checkpoint.
+func UpdatedBetween(after, before time.Time) filter.Condition {
+	return &filter.UpdatedBetween{
+		After:  after,
+		Before: before,
+	}
+}
+
+// IsContentType matches documents with content of the same type as any of the
+// given messages.
+func IsContentType(messages ...proto.Message) filter.Condition {
+	urls := make([]string, len(messages))
+	for i, m := range messages {
+		urls[i] = document.TypeURL(m)
+	}
+
+	return IsContentTypeURL(urls...)
+}
+
+// IsContentTypeURL matches documents with content of any of the types
+// identified by the given type URLs.
+func IsContentTypeURL(urls ...string) filter.Condition {
+	return &filter.IsContentType{
+		TypeURLs: filter.NewSet(urls...),
+	}
+}
+
 // TODO(jmalloc): implement HasKeyIn() and HasSharedKeyIn()
 // TODO(jmalloc): find some way to implement a logical OR of key sets, something
 // conceptually like HasKeys(set1, set2, ...)
@@ -0,0 +1,459 @@
+package protavo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+)
+
+// SaveHook is a function that is invoked when a document is saved.
+//
+// It is invoked within the write transaction. It may perform other operations
+// within tx, such as saving related documents, and these are committed
+// atomically with the save. Operations performed within tx do not invoke any
+// hooks.
+//
+// If a before-save hook returns an error, the document is not saved and the
+// save fails with that error. A before-save hook may modify doc, such as to
+// add headers, before it is passed to the driver.
+type SaveHook func(ctx context.Context, tx driver.WriteTx, doc *document.Document) error
+
+// DeleteHook is a function that is invoked when a document is deleted.
+//
+// It is invoked within the write transaction, and may perform other operations
+// within tx, in the same way as a SaveHook. If a before-delete hook returns an
+// error, the document is not deleted and the delete fails with that error.
+type DeleteHook func(ctx context.Context, tx driver.WriteTx, id string) error
+
+// CommitHook is a function that is invoked once a write transaction has been
+// committed.
+//
+// saved contains the documents that were saved by the transaction, and deleted
+// contains the IDs of the documents that were deleted. The changes have
+// already been committed, so a CommitHook can not fail.
+type CommitHook func(ctx context.Context, saved []*document.Document, deleted []string)
+
+// BeforeSave registers a hook that is invoked before each document is saved.
+//
+// The hook applies to documents saved using db, and using any DB obtained from
+// db.Namespace(). Hooks are invoked for the Save() and ForceSave() operations,
+// including those executed within an Attempt() or a transaction started with
+// db.BeginWrite().
+//
+// Hooks are not invoked for documents that are restored by Restore(), nor for
+// changes applied by ApplyChange(), such as those written by a replication
+// follower, as these reinstate documents that were previously saved.
+func (db *DB) BeforeSave(fn SaveHook) {
+	db.hooks.register(db.ns, func(h *hooks) {
+		h.beforeSave = append(h.beforeSave, fn)
+	})
+}
+
+// AfterSave registers a hook that is invoked after each document is saved,
+// before the transaction is committed.
+//
+// The hook applies to the same operations as those registered with
+// BeforeSave(). If the hook returns an error, the save fails with that error.
+func (db *DB) AfterSave(fn SaveHook) {
+	db.hooks.register(db.ns, func(h *hooks) {
+		h.afterSave = append(h.afterSave, fn)
+	})
+}
+
+// BeforeDelete registers a hook that is invoked before each document is
+// deleted.
+//
+// The hook applies to documents deleted using db, and using any DB obtained
+// from db.Namespace(). Hooks are invoked for the Delete(), ForceDelete() and
+// DeleteWhere() operations. Hooks are not invoked for documents that are
+// removed by DeleteNamespace(), PurgeDeleted() or ApplyChange().
+//
+// For DeleteWhere(), the hook is invoked as the driver reports each document
+// that it deletes. The document has already been removed within the
+// transaction by the time the hook is invoked, though an error from the hook
+// still aborts the operation.
+func (db *DB) BeforeDelete(fn DeleteHook) {
+	db.hooks.register(db.ns, func(h *hooks) {
+		h.beforeDelete = append(h.beforeDelete, fn)
+	})
+}
+
+// AfterDelete registers a hook that is invoked after each document is deleted,
+// before the transaction is committed.
+//
+// The hook applies to the same operations as those registered with
+// BeforeDelete(). If the hook returns an error, the delete fails with that
+// error.
+func (db *DB) AfterDelete(fn DeleteHook) {
+	db.hooks.register(db.ns, func(h *hooks) {
+		h.afterDelete = append(h.afterDelete, fn)
+	})
+}
+
+// AfterCommit registers a hook that is invoked after each write transaction
+// that saves or deletes documents using db, or any DB obtained from
+// db.Namespace(), is committed.
+//
+// The hook is only invoked if the driver's commit succeeds. Changes that were
+// undone by an Attempt() that failed are not included, nor are changes made by
+// the operations that do not invoke the save and delete hooks, such as
+// Restore() and ApplyChange().
+func (db *DB) AfterCommit(fn CommitHook) {
+	db.hooks.register(db.ns, func(h *hooks) {
+		h.afterCommit = append(h.afterCommit, fn)
+	})
+}
+
+// hooks is a set of hooks.
+type hooks struct {
+	beforeSave   []SaveHook
+	afterSave    []SaveHook
+	beforeDelete []DeleteHook
+	afterDelete  []DeleteHook
+	afterCommit  []CommitHook
+}
+
+// hookRegistry is the set of hooks registered on a DB and each of its
+// namespaces. It is shared by every DB obtained from the same call to NewDB().
+type hookRegistry struct {
+	m          sync.RWMutex
+	namespaces map[string]*hooks
+}
+
+// register calls fn to add hooks to the namespace ns.
+func (r *hookRegistry) register(ns string, fn func(*hooks)) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	h, ok := r.namespaces[ns]
+	if !ok {
+		if r.namespaces == nil {
+			r.namespaces = map[string]*hooks{}
+		}
+
+		h = &hooks{}
+		r.namespaces[ns] = h
+	}
+
+	fn(h)
+}
+
+// resolve returns the hooks that apply to a write within the namespace ns,
+// including those registered on its parent namespaces, which are invoked
+// first.
+//
+// It returns nil if there are no hooks.
+func (r *hookRegistry) resolve(ns string) *hooks {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	var res *hooks
+
+	for _, n := range namespaceAncestry(ns) {
+		h, ok := r.namespaces[n]
+		if !ok {
+			continue
+		}
+
+		if res == nil {
+			res = &hooks{}
+		}
+
+		res.beforeSave = append(res.beforeSave, h.beforeSave...)
+		res.afterSave = append(res.afterSave, h.afterSave...)
+		res.beforeDelete = append(res.beforeDelete, h.beforeDelete...)
+		res.afterDelete = append(res.afterDelete, h.afterDelete...)
+		res.afterCommit = append(res.afterCommit, h.afterCommit...)
+	}
+
+	return res
+}
+
+// namespaceAncestry returns the names of ns and each of its parent namespaces,
+// starting with the root namespace.
+func namespaceAncestry(ns string) []string {
+	names := []string{""}
+
+	if ns == "" {
+		return names
+	}
+
+	for i, r := range ns {
+		if r == '.' {
+			names = append(names, ns[:i])
+		}
+	}
+
+	return append(names, ns)
+}
+
+// write atomically executes a set of read/write operations using d, invoking
+// the hooks in h.
+func (h *hooks) write(
+	ctx context.Context,
+	d driver.Driver,
+	ns string,
+	ops []driver.Operation,
+) error {
+	w, ok := d.(driver.Writer)
+	if !ok {
+		return driver.ExecuteWrite(ctx, &hookDriver{d, h}, ns, ops)
+	}
+
+	// the driver manages its own transaction, so the operations are wrapped
+	// instead, and share a single record of the changes
+	c := &changes{}
+
+	wrapped := make([]driver.Operation, len(ops))
+	for i, op := range ops {
+		wrapped[i] = &hookOperation{op, h, c}
+	}
+
+	if err := w.Write(ctx, ns, wrapped); err != nil {
+		return err
+	}
+
+	h.commit(ctx, c)
+
+	return nil
+}
+
+// commit invokes the after-commit hooks.
+func (h *hooks) commit(ctx context.Context, c *changes) {
+	if len(c.saved) == 0 && len(c.deleted) == 0 {
+		return
+	}
+
+	for _, fn := range h.afterCommit {
+		fn(ctx, c.saved, c.deleted)
+	}
+}
+
+// changes is a record of the documents saved and deleted within a transaction.
+type changes struct {
+	saved   []*document.Document
+	deleted []string
+
+	// marks maps each savepoint to the number of changes that had been made
+	// when it was created.
+	marks map[driver.Savepoint][2]int
+}
+
+// hookDriver is a driver.Driver that invokes hooks within its write
+// transactions.
+type hookDriver struct {
+	driver.Driver
+
+	hooks *hooks
+}
+
+// BeginWrite starts a new read/write transaction.
+func (d *hookDriver) BeginWrite(ctx context.Context, ns string) (driver.WriteTx, error) {
+	tx, err := d.Driver.BeginWrite(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hookTx{tx, ctx, d.hooks, &changes{}}, nil
+}
+
+// hookOperation is an operation that invokes hooks when it is executed within
+// a transaction that is managed by a driver.Writer.
+type hookOperation struct {
+	driver.Operation
+
+	hooks   *hooks
+	changes *changes
+}
+
+func (o *hookOperation) ExecuteInWriteTx(ctx context.Context, tx driver.WriteTx) {
+	o.Operation.ExecuteInWriteTx(
+		ctx,
+		&hookTx{tx, ctx, o.hooks, o.changes},
+	)
+}
+
+// hookTx is a driver.WriteTx that invokes hooks for each document that is
+// saved or deleted.
+//
+// Restore() and ApplyChange() are passed through to the underlying transaction
+// without invoking any hooks.
+type hookTx struct {
+	driver.WriteTx
+
+	ctx     context.Context
+	hooks   *hooks
+	changes *changes
+}
+
+func (tx *hookTx) Save(ctx context.Context, op *driver.Save) {
+	op.MarkExecuted(
+		tx.save(ctx, op),
+	)
+}
+
+func (tx *hookTx) Delete(ctx context.Context, op *driver.Delete) {
+	op.MarkExecuted(
+		tx.delete(ctx, op),
+	)
+}
+
+func (tx *hookTx) DeleteWhere(ctx context.Context, op *driver.DeleteWhere) {
+	op.MarkExecuted(
+		tx.deleteWhere(ctx, op),
+	)
+}
+
+func (tx *hookTx) Savepoint(ctx context.Context) (driver.Savepoint, error) {
+	sp, err := tx.WriteTx.Savepoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	c := tx.changes
+	if c.marks == nil {
+		c.marks = map[driver.Savepoint][2]int{}
+	}
+
+	c.marks[sp] = [2]int{len(c.saved), len(c.deleted)}
+
+	return sp, nil
+}
+
+func (tx *hookTx) RollbackTo(ctx context.Context, sp driver.Savepoint) error {
+	if err := tx.WriteTx.RollbackTo(ctx, sp); err != nil {
+		return err
+	}
+
+	c := tx.changes
+	if m, ok := c.marks[sp]; ok {
+		c.saved = c.saved[:m[0]]
+		c.deleted = c.deleted[:m[1]]
+	}
+
+	return nil
+}
+
+func (tx *hookTx) Release(ctx context.Context, sp driver.Savepoint) error {
+	if err := tx.WriteTx.Release(ctx, sp); err != nil {
+		return err
+	}
+
+	// the changes made since sp are kept, so only the mark is discarded
+	delete(tx.changes.marks, sp)
+
+	return nil
+}
+
+func (tx *hookTx) Commit() error {
+	if err := tx.WriteTx.Commit(); err != nil {
+		return err
+	}
+
+	tx.hooks.commit(tx.ctx, tx.changes)
+
+	return nil
+}
+
+// save saves a document, invoking the save hooks.
+//
+// The driver executes a copy of op, as op can only be marked as executed once
+// the after-save hooks have been invoked.
+func (tx *hookTx) save(ctx context.Context, op *driver.Save) error {
+	for _, fn := range tx.hooks.beforeSave {
+		if err := fn(ctx, tx.WriteTx, op.Document); err != nil {
+			return err
+		}
+	}
+
+	next := &driver.Save{
+		Document:    op.Document,
+		Force:       op.Force,
+		DerivedKeys: op.DerivedKeys,
+	}
+
+	next.ExecuteInWriteTx(ctx, tx.WriteTx)
+	if err := next.Err(); err != nil {
+		return err
+	}
+
+	for _, fn := range tx.hooks.afterSave {
+		if err := fn(ctx, tx.WriteTx, op.Document); err != nil {
+			return err
+		}
+	}
+
+	tx.changes.saved = append(tx.changes.saved, op.Document)
+
+	return nil
+}
+
+// delete deletes a document, invoking the delete hooks.
+func (tx *hookTx) delete(ctx context.Context, op *driver.Delete) error {
+	id := op.Document.ID
+
+	for _, fn := range tx.hooks.beforeDelete {
+		if err := fn(ctx, tx.WriteTx, id); err != nil {
+			return err
+		}
+	}
+
+	next := &driver.Delete{
+		Document: op.Document,
+	}
+
+	tx.WriteTx.Delete(ctx, next)
+	if err := next.Err(); err != nil {
+		return err
+	}
+
+	for _, fn := range tx.hooks.afterDelete {
+		if err := fn(ctx, tx.WriteTx, id); err != nil {
+			return err
+		}
+	}
+
+	tx.changes.deleted = append(tx.changes.deleted, id)
+
+	return nil
+}
+
+// deleteWhere deletes the documents that match a filter, invoking the delete
+// hooks.
+//
+// The hooks are invoked as the driver reports each deleted document, so the
+// hooks see exactly the set of documents that is deleted.
+func (tx *hookTx) deleteWhere(ctx context.Context, op *driver.DeleteWhere) error {
+	next := &driver.DeleteWhere{
+		Filter: op.Filter,
+		Each: func(id string) error {
+			for _, fn := range tx.hooks.beforeDelete {
+				if err := fn(ctx, tx.WriteTx, id); err != nil {
+					return err
+				}
+			}
+
+			if op.Each != nil {
+				if err := op.Each(id); err != nil {
+					return err
+				}
+			}
+
+			for _, fn := range tx.hooks.afterDelete {
+				if err := fn(ctx, tx.WriteTx, id); err != nil {
+					return err
+				}
+			}
+
+			tx.changes.deleted = append(tx.changes.deleted, id)
+
+			return nil
+		},
+	}
+
+	tx.WriteTx.DeleteWhere(ctx, next)
+
+	return next.Err()
+}
@@ -0,0 +1,461 @@
+package protavo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	. "github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavo/driver/middleware"
+	"github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+func ExampleDB_BeforeSave() {
+	// First, initialize a database. We'll use the BoltDB driver for examples.
+	db, err := protavobolt.OpenTemp(0600, nil)
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	// Next, register a hook that stamps every document with a header, and
+	// refuses to save any document that does not represent a person.
+	db.BeforeSave(
+		func(ctx context.Context, tx driver.WriteTx, doc *document.Document) error {
+			if !strings.HasPrefix(doc.ID, "person:") {
+				return fmt.Errorf("refusing to save '%s'", doc.ID)
+			}
+
+			if doc.Headers == nil {
+				doc.Headers = document.Headers{}
+			}
+
+			doc.Headers["saved-by"] = "example"
+
+			return nil
+		},
+	)
+
+	// The hook also applies to the namespaces within the database.
+	people := db.Namespace("people")
+
+	doc := &document.Document{
+		ID:      "person:1",
+		Content: document.StringContent("Alice"),
+	}
+
+	if err := people.Save(context.Background(), doc); err != nil {
+		panic(err)
+	}
+
+	fmt.Println(doc.Headers["saved-by"])
+
+	// A document rejected by the hook is not saved.
+	err = people.Save(
+		context.Background(),
+		&document.Document{
+			ID:      "robot:1",
+			Content: document.StringContent("R2-D2"),
+		},
+	)
+
+	fmt.Println(err)
+
+	// Output:
+	// example
+	// refusing to save 'robot:1'
+}
+
+func ExampleDB_AfterCommit() {
+	// First, initialize a database. We'll use the BoltDB driver for examples.
+	db, err := protavobolt.OpenTemp(0600, nil)
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	// Next, register a hook that writes a related document atomically with
+	// each deletion, and another that reports the changes once they have been
+	// committed.
+	db.AfterDelete(
+		func(ctx context.Context, tx driver.WriteTx, id string) error {
+			op := Save(&document.Document{
+				ID:      "tombstone:" + id,
+				Content: document.StringContent(id),
+			})
+
+			op.ExecuteInWriteTx(ctx, tx)
+
+			return op.Err()
+		},
+	)
+
+	db.AfterCommit(
+		func(ctx context.Context, saved []*document.Document, deleted []string) {
+			for _, doc := range saved {
+				fmt.Println("saved", doc.ID)
+			}
+
+			for _, id := range deleted {
+				fmt.Println("deleted", id)
+			}
+		},
+	)
+
+	alice := &document.Document{
+		ID:      "person:1",
+		Content: document.StringContent("Alice"),
+	}
+
+	if err := db.Save(context.Background(), alice); err != nil {
+		panic(err)
+	}
+
+	// Changes that are undone by a failed attempt are not reported.
+	if err := db.Write(
+		context.Background(),
+		Attempt(
+			func(context.Context, driver.WriteTx, error) error {
+				return nil
+			},
+			Save(&document.Document{
+				ID:      "person:2",
+				Content: document.StringContent("Bob"),
+			}),
+			Save(&document.Document{
+				ID:      "person:3",
+				Content: document.StringContent("Carol"),
+				// a new document must not have a revision, so this save fails
+				Revision: 1,
+			}),
+		),
+		Delete(alice),
+	); err != nil {
+		panic(err)
+	}
+
+	// The related document written by the hook was saved along with the
+	// deletion.
+	_, ok, err := db.Load(context.Background(), "tombstone:person:1")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(ok)
+
+	// Output:
+	// saved person:1
+	// deleted person:1
+	// true
+}
+
+var _ = g.Describe("hooks", func() {
+	var (
+		ctx       = context.Background()
+		dir       string
+		errVetoed = errors.New("<vetoed>")
+	)
+
+	// newDoc returns a new document with the given ID.
+	newDoc := func(id string) *document.Document {
+		return &document.Document{
+			ID:      id,
+			Content: document.StringContent("<content>"),
+		}
+	}
+
+	g.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "protavo-")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	for _, batch := range []bool{false, true} {
+		batch := batch // capture loop variable
+
+		desc := "without batched writes"
+		if batch {
+			desc = "with batched writes"
+		}
+
+		g.Context(desc, func() {
+			var (
+				db *DB
+
+				mutex   sync.Mutex
+				saved   []string
+				deleted []string
+				commits int
+			)
+
+			g.BeforeEach(func() {
+				var err error
+				db, err = openBolt(dir, batch)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				saved = nil
+				deleted = nil
+				commits = 0
+
+				db.BeforeSave(
+					func(_ context.Context, _ driver.WriteTx, doc *document.Document) error {
+						if doc.ID == "veto" {
+							return errVetoed
+						}
+
+						return nil
+					},
+				)
+
+				db.AfterCommit(
+					func(_ context.Context, s []*document.Document, d []string) {
+						mutex.Lock()
+						defer mutex.Unlock()
+
+						for _, doc := range s {
+							saved = append(saved, doc.ID)
+						}
+
+						deleted = append(deleted, d...)
+						commits++
+					},
+				)
+			})
+
+			g.AfterEach(func() {
+				_ = db.Close()
+			})
+
+			g.It("aborts the transaction if a before-save hook returns an error", func() {
+				err := db.Save(ctx, newDoc("doc-1"), newDoc("veto"))
+				m.Expect(err).To(m.Equal(errVetoed))
+
+				_, ok, err := db.Load(ctx, "doc-1")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeFalse())
+
+				m.Expect(commits).To(m.Equal(0))
+			})
+
+			g.It("does not report changes that are undone by a failed Attempt()", func() {
+				err := db.Write(
+					ctx,
+					Save(newDoc("doc-1")),
+					Attempt(
+						func(context.Context, driver.WriteTx, error) error {
+							return nil
+						},
+						Save(newDoc("doc-2")),
+						Save(newDoc("veto")),
+					),
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				m.Expect(saved).To(m.Equal([]string{"doc-1"}))
+			})
+
+			g.It("does not report changes that are undone by RollbackTo()", func() {
+				tx, err := db.BeginWrite(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				defer tx.Close()
+
+				op := Save(newDoc("doc-1"))
+				op.ExecuteInWriteTx(ctx, tx)
+				m.Expect(op.Err()).ShouldNot(m.HaveOccurred())
+
+				sp, err := tx.Savepoint(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				op = Save(newDoc("doc-2"))
+				op.ExecuteInWriteTx(ctx, tx)
+				m.Expect(op.Err()).ShouldNot(m.HaveOccurred())
+
+				err = tx.RollbackTo(ctx, sp)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = tx.Commit()
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				m.Expect(saved).To(m.Equal([]string{"doc-1"}))
+			})
+
+			g.It("invokes the delete hooks for each document deleted by DeleteWhere()", func() {
+				doc1 := newDoc("doc-1")
+				doc1.Keys = document.SharedKeys("match")
+				doc2 := newDoc("doc-2")
+				doc2.Keys = document.SharedKeys("match")
+
+				err := db.Save(ctx, doc1, doc2, newDoc("doc-3"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				var before, after []string
+
+				db.BeforeDelete(
+					func(_ context.Context, _ driver.WriteTx, id string) error {
+						before = append(before, id)
+						return nil
+					},
+				)
+
+				db.AfterDelete(
+					func(_ context.Context, _ driver.WriteTx, id string) error {
+						after = append(after, id)
+						return nil
+					},
+				)
+
+				_, err = db.DeleteWhere(ctx, HasKeys("match"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				m.Expect(before).To(m.ConsistOf("doc-1", "doc-2"))
+				m.Expect(after).To(m.ConsistOf("doc-1", "doc-2"))
+				m.Expect(deleted).To(m.ConsistOf("doc-1", "doc-2"))
+			})
+
+			g.It("aborts DeleteWhere() if a before-delete hook returns an error", func() {
+				doc1 := newDoc("doc-1")
+				doc1.Keys = document.SharedKeys("match")
+				doc2 := newDoc("doc-2")
+				doc2.Keys = document.SharedKeys("match")
+
+				err := db.Save(ctx, doc1, doc2)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				db.BeforeDelete(
+					func(_ context.Context, _ driver.WriteTx, id string) error {
+						return errors.New("<error>")
+					},
+				)
+
+				_, err = db.DeleteWhere(ctx, HasKeys("match"))
+				m.Expect(err).To(m.MatchError("<error>"))
+
+				docs, err := db.LoadManyWhere(ctx, HasKeys("match"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(docs).To(m.HaveLen(2))
+			})
+
+			g.It("reports the changes made by each of several concurrent writes", func() {
+				var wg sync.WaitGroup
+
+				for i := 0; i < 10; i++ {
+					wg.Add(1)
+
+					go func(i int) {
+						defer g.GinkgoRecover()
+						defer wg.Done()
+
+						err := db.Save(ctx, newDoc(fmt.Sprintf("doc-%d", i)))
+						m.Expect(err).ShouldNot(m.HaveOccurred())
+					}(i)
+				}
+
+				wg.Wait()
+
+				m.Expect(saved).To(m.HaveLen(10))
+				m.Expect(commits).To(m.Equal(10))
+			})
+
+			g.It("applies hooks registered on a namespace to every DB for that namespace", func() {
+				db.Namespace("ns").BeforeSave(
+					func(_ context.Context, _ driver.WriteTx, doc *document.Document) error {
+						return errVetoed
+					},
+				)
+
+				err := db.Namespace("ns").Save(ctx, newDoc("doc-1"))
+				m.Expect(err).To(m.Equal(errVetoed))
+
+				err = db.Namespace("ns").Namespace("sub").Save(ctx, newDoc("doc-1"))
+				m.Expect(err).To(m.Equal(errVetoed))
+
+				err = db.Namespace("other").Save(ctx, newDoc("doc-1"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = db.Save(ctx, newDoc("doc-1"))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+		})
+	}
+
+	g.When("the commit fails", func() {
+		var (
+			db      *DB
+			commits int
+		)
+
+		g.BeforeEach(func() {
+			d, err := newBoltDriver(dir, false)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			db, err = NewDB(
+				middleware.Wrap(
+					d,
+					func(ctx context.Context, c *middleware.Call, next middleware.Handler) error {
+						switch c.Method {
+						case "Commit":
+							return errors.New("<commit failed>")
+						case "Write":
+							// the driver manages its own transaction, so the
+							// failure is reported once it has finished
+							if err := next(ctx); err != nil {
+								return err
+							}
+
+							return errors.New("<commit failed>")
+						}
+
+						return next(ctx)
+					},
+				),
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			commits = 0
+
+			db.AfterCommit(
+				func(context.Context, []*document.Document, []string) {
+					commits++
+				},
+			)
+		})
+
+		g.AfterEach(func() {
+			_ = db.Close()
+		})
+
+		g.It("does not invoke the after-commit hooks", func() {
+			err := db.Save(ctx, newDoc("doc-1"))
+			m.Expect(err).To(m.MatchError("<commit failed>"))
+
+			m.Expect(commits).To(m.Equal(0))
+		})
+
+		g.It("does not invoke the after-commit hooks for a transaction started by BeginWrite()", func() {
+			tx, err := db.BeginWrite(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer tx.Close()
+
+			op := Save(newDoc("doc-1"))
+			op.ExecuteInWriteTx(ctx, tx)
+			m.Expect(op.Err()).ShouldNot(m.HaveOccurred())
+
+			err = tx.Commit()
+			m.Expect(err).To(m.MatchError("<commit failed>"))
+
+			m.Expect(commits).To(m.Equal(0))
+		})
+	})
+})
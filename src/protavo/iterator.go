@@ -0,0 +1,257 @@
+package protavo
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavo/filter"
+)
+
+// Iterator is a pull-based alternative to the FetchAll() and FetchWhere()
+// operations.
+//
+// Documents are fetched lazily, one at a time, as Next() is called. The
+// iterator holds a read transaction open until it has been exhausted or closed.
+//
+// Close() must always be called, even if Next() has returned false. An
+// iterator that is abandoned before it is exhausted leaks its read
+// transaction.
+//
+// If the driver supports cursors (see driver.CursorTx), each document is read
+// from the cursor as Next() is called. Otherwise, the fetch is performed by a
+// separate goroutine, which is also leaked by an abandoned iterator until the
+// context passed when the iterator was created is canceled.
+//
+// An iterator is not safe for concurrent use.
+type Iterator struct {
+	ctx context.Context
+	tx  driver.ReadTx
+
+	// cursor is the cursor from which documents are read. It is nil if the
+	// driver does not support cursors, in which case the documents are
+	// supplied by a fetch operation in a separate goroutine.
+	cursor driver.Cursor
+
+	// docs carries documents from the fetch operation to the iterator, it is
+	// closed when the fetch operation completes.
+	docs chan *document.Document
+
+	// resume is used to instruct the fetch operation to continue to the next
+	// document (true), or to stop iterating (false).
+	resume chan bool
+
+	// done is closed when the fetch operation completes.
+	done chan struct{}
+
+	doc      *document.Document
+	waiting  bool // true if the fetch operation is waiting on resume
+	closed   bool
+	fetchErr error
+	err      error
+}
+
+// newIterator returns an iterator over the documents that match f, fetched
+// from within tx.
+//
+// tx is closed if the iterator can not be created.
+func newIterator(
+	ctx context.Context,
+	tx driver.ReadTx,
+	f *filter.Filter,
+) (*Iterator, error) {
+	it := &Iterator{
+		ctx: ctx,
+		tx:  tx,
+	}
+
+	if c, ok := tx.(driver.CursorTx); ok {
+		cur, err := c.Cursor(ctx, f)
+		if err != nil {
+			tx.Close()
+			return nil, err
+		}
+
+		it.cursor = cur
+
+		return it, nil
+	}
+
+	it.docs = make(chan *document.Document)
+	it.resume = make(chan bool)
+	it.done = make(chan struct{})
+
+	go it.fetch(ctx, f)
+
+	return it, nil
+}
+
+// Next advances the iterator to the next document.
+//
+// It returns false if there are no more documents, or an error occurs. The
+// iterator is closed automatically once Next() returns false.
+func (it *Iterator) Next() bool {
+	if it.closed {
+		return false
+	}
+
+	if it.cursor != nil {
+		return it.read()
+	}
+
+	if it.waiting {
+		it.waiting = false
+
+		select {
+		case it.resume <- true:
+		case <-it.done:
+		}
+	}
+
+	doc, ok := <-it.docs
+	if !ok {
+		it.doc = nil
+		it.err = it.fetchErr
+		it.release()
+		return false
+	}
+
+	it.doc = doc
+	it.waiting = true
+
+	return true
+}
+
+// Document returns the current document.
+//
+// It returns nil if Next() has not been called, or it has returned false.
+func (it *Iterator) Document() *document.Document {
+	return it.doc
+}
+
+// Err returns the error that caused iteration to stop, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Close stops iterating and ends the underlying read transaction.
+//
+// It returns the error that caused iteration to stop, if any, including an
+// error from the fetch operation that occurred after the last call to Next().
+// It is safe to call Close() multiple times.
+func (it *Iterator) Close() error {
+	if it.closed {
+		return it.err
+	}
+
+	if it.cursor == nil {
+		it.stop()
+	}
+
+	it.doc = nil
+	it.release()
+
+	return it.err
+}
+
+// All returns a function that yields each of the remaining documents.
+//
+// It is compatible with the range-over-func form of the for statement:
+//
+//	for doc := range it.All() {
+//		// ...
+//	}
+//
+// The iterator is closed when the loop ends. Err() should be checked once the
+// loop has completed.
+func (it *Iterator) All() func(yield func(*document.Document) bool) {
+	return func(yield func(*document.Document) bool) {
+		defer it.Close()
+
+		for it.Next() {
+			if !yield(it.doc) {
+				return
+			}
+		}
+	}
+}
+
+// read advances the iterator to the next document from its cursor.
+func (it *Iterator) read() bool {
+	doc, ok, err := it.cursor.Next(it.ctx)
+	if !ok || err != nil {
+		it.doc = nil
+		it.err = err
+		it.release()
+		return false
+	}
+
+	it.doc = doc
+
+	return true
+}
+
+// stop stops the fetch operation that supplies documents to the iterator, and
+// waits for it to complete.
+func (it *Iterator) stop() {
+	if it.waiting {
+		it.waiting = false
+
+		select {
+		case it.resume <- false:
+		case <-it.done:
+		}
+	}
+
+	// drain any document that was sent before the fetch operation saw the
+	// request to stop
+	for range it.docs {
+		select {
+		case it.resume <- false:
+		case <-it.done:
+		}
+	}
+
+	// the fetch operation has completed once it.docs is closed, so its error
+	// is safe to read
+	if it.err == nil {
+		it.err = it.fetchErr
+	}
+}
+
+// fetch executes the fetch operation that supplies documents to the iterator.
+// It is run in its own goroutine.
+func (it *Iterator) fetch(ctx context.Context, f *filter.Filter) {
+	defer close(it.done)
+	defer close(it.docs)
+
+	op := &driver.Fetch{
+		Filter: f,
+		Each: func(doc *document.Document) (bool, error) {
+			select {
+			case it.docs <- doc:
+			case <-ctx.Done():
+				return false, ctx.Err()
+			}
+
+			select {
+			case ok := <-it.resume:
+				return ok, nil
+			case <-ctx.Done():
+				return false, ctx.Err()
+			}
+		},
+	}
+
+	it.tx.Fetch(ctx, op)
+	it.fetchErr = op.Err()
+}
+
+// release ends the underlying transaction.
+func (it *Iterator) release() {
+	it.closed = true
+
+	if err := it.tx.Close(); it.err == nil {
+		it.err = err
+	}
+}
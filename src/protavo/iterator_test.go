@@ -0,0 +1,240 @@
+package protavo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavo/filter"
+	"github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+func ExampleDB_IterateWhere() {
+	// First, initialize a database. We'll use the BoltDB driver for examples.
+	db, err := protavobolt.OpenTemp(0600, nil)
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	// Next, save some documents so we have something to iterate over.
+	if err := db.Save(
+		context.Background(),
+		&document.Document{
+			ID:      "person:1",
+			Keys:    document.SharedKeys("hobby:cycling"),
+			Content: document.StringContent("Alice"),
+		},
+		&document.Document{
+			ID:      "person:2",
+			Keys:    document.SharedKeys("hobby:origami"),
+			Content: document.StringContent("Bob"),
+		},
+	); err != nil {
+		panic(err)
+	}
+
+	// Next, we use IterateWhere to find the people that enjoy cycling. The
+	// iterator must always be closed, even if it is not exhausted.
+	it, err := db.IterateWhere(
+		context.Background(),
+		HasKeys("hobby:cycling"),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer it.Close()
+
+	for it.Next() {
+		doc := it.Document()
+		fmt.Printf("%s enjoys cycling\n", document.GetStringContent(doc.Content))
+	}
+
+	if err := it.Err(); err != nil {
+		panic(err)
+	}
+
+	// Output: Alice enjoys cycling
+}
+
+func ExampleIterator_Close() {
+	// First, initialize a database. We'll use the BoltDB driver for examples.
+	db, err := protavobolt.OpenTemp(0600, nil)
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	// Next, save some documents so we have something to iterate over.
+	if err := db.Save(
+		context.Background(),
+		&document.Document{
+			ID:      "person:1",
+			Content: document.StringContent("Alice"),
+		},
+		&document.Document{
+			ID:      "person:2",
+			Content: document.StringContent("Bob"),
+		},
+	); err != nil {
+		panic(err)
+	}
+
+	// Next, we use IterateAll to find any single person. Closing the iterator
+	// early stops the fetch and ends the underlying read transaction.
+	it, err := db.IterateAll(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	if it.Next() {
+		fmt.Println("found a person")
+	}
+
+	if err := it.Close(); err != nil {
+		panic(err)
+	}
+
+	// Output: found a person
+}
+
+var _ = g.Describe("Iterator", func() {
+	var (
+		ctx = context.Background()
+		tx  *failingReadTx
+		db  *DB
+	)
+
+	g.BeforeEach(func() {
+		tx = &failingReadTx{
+			err: errors.New("<error>"),
+		}
+
+		var err error
+		db, err = NewDB(&readOnlyDriver{tx: tx})
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.Describe("Close", func() {
+		g.It("returns an error that occurs after the last call to Next()", func() {
+			it, err := db.IterateAll(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			m.Expect(it.Next()).To(m.BeTrue())
+
+			err = it.Close()
+			m.Expect(err).To(m.MatchError("<error>"))
+			m.Expect(it.Err()).To(m.MatchError("<error>"))
+			m.Expect(tx.closed).To(m.BeTrue())
+		})
+
+		g.It("returns the error again if called multiple times", func() {
+			it, err := db.IterateAll(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			m.Expect(it.Next()).To(m.BeTrue())
+			m.Expect(it.Next()).To(m.BeFalse())
+
+			m.Expect(it.Close()).To(m.MatchError("<error>"))
+			m.Expect(it.Close()).To(m.MatchError("<error>"))
+		})
+	})
+
+	g.When("the driver supports cursors", func() {
+		var cur *cursorReadTx
+
+		g.BeforeEach(func() {
+			cur = &cursorReadTx{failingReadTx: tx}
+
+			var err error
+			db, err = NewDB(&readOnlyDriver{tx: cur})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+		})
+
+		g.It("reads each document from the cursor as Next() is called", func() {
+			it, err := db.IterateAll(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(cur.reads).To(m.Equal(0))
+
+			m.Expect(it.Next()).To(m.BeTrue())
+			m.Expect(it.Document().ID).To(m.Equal("doc-1"))
+			m.Expect(cur.reads).To(m.Equal(1))
+
+			m.Expect(it.Close()).ShouldNot(m.HaveOccurred())
+			m.Expect(cur.reads).To(m.Equal(1))
+			m.Expect(tx.closed).To(m.BeTrue())
+		})
+
+		g.It("returns an error from the cursor", func() {
+			it, err := db.IterateAll(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			m.Expect(it.Next()).To(m.BeTrue())
+			m.Expect(it.Next()).To(m.BeFalse())
+			m.Expect(it.Err()).To(m.MatchError("<error>"))
+			m.Expect(tx.closed).To(m.BeTrue())
+		})
+	})
+})
+
+// readOnlyDriver is a driver.Driver that only supports read transactions, all
+// of which use the same transaction.
+type readOnlyDriver struct {
+	driver.Driver
+	tx driver.ReadTx
+}
+
+func (d *readOnlyDriver) BeginRead(context.Context, string) (driver.ReadTx, error) {
+	return d.tx, nil
+}
+
+// failingReadTx is a driver.ReadTx that fetches a single document, then fails
+// with err, regardless of whether the fetch is stopped early.
+type failingReadTx struct {
+	driver.ReadTx
+	err    error
+	closed bool
+}
+
+func (tx *failingReadTx) Fetch(ctx context.Context, op *driver.Fetch) {
+	_, _ = op.Each(&document.Document{
+		ID:      "doc-1",
+		Content: document.StringContent("<content>"),
+	})
+
+	op.MarkExecuted(tx.err)
+}
+
+func (tx *failingReadTx) Close() error {
+	tx.closed = true
+	return nil
+}
+
+// cursorReadTx is a driver.CursorTx whose cursor reads a single document, then
+// fails with err.
+type cursorReadTx struct {
+	*failingReadTx
+	reads int
+}
+
+func (tx *cursorReadTx) Cursor(context.Context, *filter.Filter) (driver.Cursor, error) {
+	return tx, nil
+}
+
+func (tx *cursorReadTx) Next(context.Context) (*document.Document, bool, error) {
+	tx.reads++
+
+	if tx.reads > 1 {
+		return nil, false, tx.err
+	}
+
+	return &document.Document{
+		ID:      "doc-1",
+		Content: document.StringContent("<content>"),
+	}, true, nil
+}
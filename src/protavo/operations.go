@@ -1,6 +1,9 @@
 package protavo
 
 import (
+	"io"
+	"time"
+
 	"github.com/jmalloc/protavo/src/protavo/document"
 	"github.com/jmalloc/protavo/src/protavo/driver"
 	"github.com/jmalloc/protavo/src/protavo/filter"
@@ -36,6 +39,64 @@ func FetchWhere(fn driver.FetchFunc, f ...filter.Condition) driver.ReadOnlyOpera
 	}
 }
 
+// Explain returns an operation that describes the query plan used to find the
+// documents that match the given filter conditions.
+//
+// Once executed, the plan is available via the Plan field of the returned
+// operation.
+//
+// The returned operation can be executed atomically with other operations using
+// DB.Read() or DB.Write(). DB.Explain() is a convenience method for performing
+// a single Explain operation.
+func Explain(f ...filter.Condition) *driver.Explain {
+	return &driver.Explain{
+		Filter: filter.New(f),
+	}
+}
+
+// ChangesSince returns an operation that calls fn once for each document that
+// has changed since the write with the sequence number seq, in the order of the
+// changes.
+//
+// Each write within a namespace is assigned a sequence number that is greater
+// than that of any previous write. Only the most recent change to each document
+// is reported. Pass the Sequence of the last change that was processed to
+// resume from that point, or 0 to obtain every document in the namespace.
+//
+// Sequence numbers continue to increase after the namespace is deleted. If the
+// namespace has been deleted since seq, the changes do not include the deletion
+// of each document, so the operation fails with a StaleSequenceError. The
+// consumer must then discard the documents it has obtained and start again
+// from 0.
+//
+// It stops iterating if fn returns false or a non-nil error.
+//
+// The returned operation can be executed atomically with other operations using
+// DB.Read() or DB.Write(). DB.ChangesSince() is a convenience method for
+// performing a single ChangesSince operation.
+func ChangesSince(seq uint64, fn driver.ChangeFunc) driver.ReadOnlyOperation {
+	return &driver.ChangesSince{
+		Since: seq,
+		Each:  fn,
+	}
+}
+
+// ApplyChange returns an operation that applies a change obtained from another
+// database, as returned by ChangesSince().
+//
+// Unlike Save() and Delete(), the document's revision is not checked, and its
+// ID, revision and timestamps are preserved exactly. Applying the same change
+// more than once has the same effect as applying it once. It is intended for
+// use by replication.
+//
+// The change is applied as-is. No hooks are invoked, and the document is not
+// validated against the DB's schema.
+func ApplyChange(c *driver.Change) driver.Operation {
+	return &driver.ApplyChange{
+		Change: c,
+	}
+}
+
 // Save returns an operation that creates or updates a document.
 //
 // The Revision field of the document must be equal to the revision of that
@@ -110,3 +171,137 @@ func DeleteWhere(
 func DeleteNamespace() driver.Operation {
 	return &driver.DeleteNamespace{}
 }
+
+// Restore returns an operation that restores the soft-deleted document with
+// the given ID, such that it is visible to subsequent reads.
+//
+// Documents are only soft-deleted if the driver is configured to do so. The
+// restored document is given a new revision. A DuplicateKeyError is returned if
+// another document has since been saved with one of its unique keys.
+//
+// Once executed, the Found field of the returned operation is true if there
+// was a soft-deleted document with the given ID. No hooks are invoked for the
+// restored document.
+//
+// The returned operation can be executed atomically with other operations using
+// DB.Write(). DB.Restore() is a convenience method for performing a single
+// Restore operation.
+func Restore(id string) *driver.Restore {
+	return &driver.Restore{
+		DocumentID: id,
+	}
+}
+
+// PurgeDeleted returns an operation that permanently removes the soft-deleted
+// documents that were deleted before the given time.
+//
+// If fn is non-nil, it is invoked for each of the purged documents.
+//
+// The returned operation can be executed atomically with other operations using
+// DB.Write(). DB.PurgeDeleted() is a convenience method for performing a single
+// PurgeDeleted operation.
+func PurgeDeleted(fn driver.DeleteWhereFunc, before time.Time) driver.Operation {
+	return &driver.PurgeDeleted{
+		Each:   fn,
+		Before: before,
+	}
+}
+
+// Attempt returns an operation that executes ops, undoing all of their changes
+// if any one of them fails, without aborting the enclosing transaction.
+//
+// If fn is non-nil, it is invoked with the error from the failed operation once
+// the changes have been undone. It may perform alternative work within the
+// transaction. The attempt fails with the error returned by fn. If fn is nil,
+// the attempt fails with the error from the failed operation.
+//
+// The returned operation can be executed atomically with other operations using
+// DB.Write().
+func Attempt(fn driver.AttemptFunc, ops ...driver.Operation) driver.Operation {
+	return &driver.Attempt{
+		Operations: ops,
+		OnError:    fn,
+	}
+}
+
+// PutAttachment returns an operation that stores a binary attachment of the
+// document with the given ID, replacing any existing attachment with the same
+// name. The document must exist.
+//
+// The attachment's content is read from r. Attachments are stored separately
+// from the document's content, so they are not loaded when the document is
+// fetched. They are deleted along with the document.
+//
+// The returned operation can be executed atomically with other operations using
+// DB.Write(). DB.PutAttachment() is a convenience method for performing a
+// single PutAttachment operation.
+func PutAttachment(id, name string, r io.Reader) driver.Operation {
+	return &driver.PutAttachment{
+		DocumentID: id,
+		Name:       name,
+		Content:    r,
+	}
+}
+
+// GetAttachment returns an operation that writes the content of a binary
+// attachment of the document with the given ID to w.
+//
+// Once executed, the Found field of the returned operation indicates whether
+// the attachment exists.
+//
+// The returned operation can be executed atomically with other operations using
+// DB.Read() or DB.Write(). DB.GetAttachment() is a convenience method for
+// performing a single GetAttachment operation.
+func GetAttachment(id, name string, w io.Writer) *driver.GetAttachment {
+	return &driver.GetAttachment{
+		DocumentID: id,
+		Name:       name,
+		Content:    w,
+	}
+}
+
+// ListAttachments returns an operation that lists the binary attachments of the
+// document with the given ID.
+//
+// Once executed, the attachments are available via the Attachments field of
+// the returned operation.
+//
+// The returned operation can be executed atomically with other operations using
+// DB.Read() or DB.Write(). DB.ListAttachments() is a convenience method for
+// performing a single ListAttachments operation.
+func ListAttachments(id string) *driver.ListAttachments {
+	return &driver.ListAttachments{
+		DocumentID: id,
+	}
+}
+
+// ListKeys returns an operation that lists the indexing keys that begin with
+// the given prefix. If t is non-zero, only keys of that type are listed.
+//
+// Once executed, the keys are available via the Keys field of the returned
+// operation.
+//
+// The returned operation can be executed atomically with other operations using
+// DB.Read() or DB.Write(). DB.ListKeys() is a convenience method for performing
+// a single ListKeys operation.
+func ListKeys(prefix string, t document.KeyType) *driver.ListKeys {
+	return &driver.ListKeys{
+		Prefix: prefix,
+		Type:   t,
+	}
+}
+
+// DeleteAttachment returns an operation that deletes a binary attachment of
+// the document with the given ID.
+//
+// It is not an error to delete a non-existent attachment.
+//
+// The returned operation can be executed atomically with other operations using
+// DB.Write(). DB.DeleteAttachment() is a convenience method for performing a
+// single DeleteAttachment operation.
+func DeleteAttachment(id, name string) driver.Operation {
+	return &driver.DeleteAttachment{
+		DocumentID: id,
+		Name:       name,
+	}
+}
@@ -0,0 +1,65 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+)
+
+// Checkpoint persists the sequence number of the last change that a follower
+// has applied, so that it can resume after a restart.
+type Checkpoint interface {
+	// Load returns the sequence number of the last change that was applied, or
+	// 0 if none have been applied.
+	Load(ctx context.Context) (uint64, error)
+
+	// Store persists the sequence number of the last change that was applied.
+	Store(ctx context.Context, seq uint64) error
+}
+
+// DocumentCheckpoint is a Checkpoint that is persisted as a document.
+//
+// The document should not be stored in the follower's namespace, otherwise it
+// is visible to readers of the replica. A sub-namespace of the follower
+// database is a good choice.
+type DocumentCheckpoint struct {
+	// DB is the database in which the checkpoint document is stored.
+	DB *protavo.DB
+
+	// DocumentID is the ID of the checkpoint document.
+	DocumentID string
+}
+
+// Load returns the sequence number of the last change that was applied, or 0
+// if none have been applied.
+func (c *DocumentCheckpoint) Load(ctx context.Context) (uint64, error) {
+	doc, ok, err := c.DB.Load(ctx, c.DocumentID)
+	if !ok || err != nil {
+		return 0, err
+	}
+
+	v, ok := doc.Content.(*wrappers.UInt64Value)
+	if !ok {
+		return 0, fmt.Errorf(
+			"checkpoint document '%s' has unexpected content of type %T",
+			c.DocumentID,
+			doc.Content,
+		)
+	}
+
+	return v.Value, nil
+}
+
+// Store persists the sequence number of the last change that was applied.
+func (c *DocumentCheckpoint) Store(ctx context.Context, seq uint64) error {
+	return c.DB.ForceSave(
+		ctx,
+		&document.Document{
+			ID:      c.DocumentID,
+			Content: &wrappers.UInt64Value{Value: seq},
+		},
+	)
+}
@@ -0,0 +1,333 @@
+package replication
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+)
+
+// DefaultPollInterval is the default interval at which a follower checks the
+// leader for new changes.
+const DefaultPollInterval = 1 * time.Second
+
+// DefaultBatchSize is the default maximum number of changes that a follower
+// applies in a single transaction.
+const DefaultBatchSize = 100
+
+// Follower replicates the documents in a leader database to a follower
+// database.
+//
+// Documents are written to the follower with the same IDs, revisions and
+// timestamps as on the leader. Attachments are not replicated. The follower
+// database should not be modified by anything other than the Follower.
+//
+// A Follower is not safe for concurrent use.
+type Follower struct {
+	// Leader is the source of the changes made to the leader database.
+	Leader Source
+
+	// DB is the follower database.
+	DB *protavo.DB
+
+	// Checkpoint persists the follower's progress. If it is nil, progress is
+	// only kept in memory, and each new follower starts by replicating every
+	// document on the leader.
+	Checkpoint Checkpoint
+
+	// PollInterval is the interval at which Run() checks the leader for new
+	// changes. If it is zero, DefaultPollInterval is used.
+	PollInterval time.Duration
+
+	// BatchSize is the maximum number of changes that are applied in a single
+	// transaction. If it is zero, DefaultBatchSize is used.
+	BatchSize int
+
+	seq    uint64
+	loaded bool
+}
+
+// Run applies the changes made to the leader until ctx is canceled or an
+// error occurs.
+func (f *Follower) Run(ctx context.Context) error {
+	d := f.PollInterval
+	if d == 0 {
+		d = DefaultPollInterval
+	}
+
+	for {
+		if _, err := f.Sync(ctx); err != nil {
+			// report cancelation consistently, regardless of how the
+			// transport surfaced it
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d):
+		}
+	}
+}
+
+// Sync applies the changes made to the leader since the last sync. It returns
+// the number of changes that were applied, which may be non-zero even if an
+// error occurs.
+//
+// The changes are applied in batches of at most BatchSize changes, and the
+// checkpoint is stored after each batch. Each batch is applied atomically, but
+// readers of the follower database may observe the state between two batches,
+// which need not match any state of the leader.
+//
+// If the leader's namespace has been deleted since the last sync, or the
+// leader's sequence numbers are lower than the checkpoint, every document in
+// the follower database is deleted and the leader's documents are replicated
+// again from the beginning.
+func (f *Follower) Sync(ctx context.Context) (int, error) {
+	if !f.loaded && f.Checkpoint != nil {
+		seq, err := f.Checkpoint.Load(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		f.seq = seq
+		f.loaded = true
+	}
+
+	n, err := f.sync(ctx)
+	if err != errSequenceReset && !protavo.IsStaleSequenceError(err) {
+		return n, err
+	}
+
+	if err := f.reset(ctx); err != nil {
+		return n, err
+	}
+
+	count, err := f.sync(ctx)
+	return n + count, err
+}
+
+// errSequenceReset indicates that the leader sent a change with a sequence
+// number that is not greater than that of the changes the follower has already
+// applied.
+var errSequenceReset = errors.New("the leader's sequence numbers have been reset")
+
+// sync applies the changes since f.seq in batches.
+func (f *Follower) sync(ctx context.Context) (int, error) {
+	size := f.BatchSize
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+
+	var (
+		count   int
+		last    = f.seq
+		changes []*driver.Change
+		pending []*driver.Change
+	)
+
+	if err := f.Leader.ChangesSince(
+		ctx,
+		f.seq,
+		func(c *driver.Change) (bool, error) {
+			if c.Sequence <= last {
+				return false, errSequenceReset
+			}
+
+			last = c.Sequence
+			changes = append(changes, c)
+
+			if len(changes) < size {
+				return true, nil
+			}
+
+			var err error
+			pending, err = f.apply(ctx, changes, pending, last, false)
+			if err != nil {
+				return false, err
+			}
+
+			count += len(changes)
+			changes = nil
+
+			return true, nil
+		},
+	); err != nil {
+		return count, err
+	}
+
+	if len(changes) == 0 && len(pending) == 0 {
+		return count, nil
+	}
+
+	if _, err := f.apply(ctx, changes, pending, last, true); err != nil {
+		return count, err
+	}
+
+	return count + len(changes), nil
+}
+
+// apply applies a batch of changes to the follower database and stores the
+// checkpoint.
+//
+// last is the sequence number of the last change received from the leader,
+// which is not necessarily in changes. pending is the changes from previous batches that have only been applied
+// without their unique keys, because the keys were still in use by other
+// documents. They are applied in full along with the batch. The changes that
+// still can not be applied in full are returned, unless final is true, in
+// which case the batch fails.
+//
+// The checkpoint never advances past a change that is yet to be applied in
+// full. Applying a change is idempotent, so if the checkpoint can not be
+// stored the changes are simply applied again by the next sync.
+func (f *Follower) apply(
+	ctx context.Context,
+	changes, pending []*driver.Change,
+	last uint64,
+	final bool,
+) ([]*driver.Change, error) {
+	ops, deferred := applyChanges(changes)
+	deferred = append(pending, deferred...)
+
+	var retry []*driver.Change
+
+	for _, c := range deferred {
+		if final {
+			ops = append(ops, protavo.ApplyChange(c))
+			continue
+		}
+
+		c := c // capture loop variable
+		ops = append(
+			ops,
+			protavo.Attempt(
+				func(_ context.Context, _ driver.WriteTx, err error) error {
+					if protavo.IsDuplicateKeyError(err) {
+						retry = append(retry, c)
+						return nil
+					}
+
+					return err
+				},
+				protavo.ApplyChange(c),
+			),
+		)
+	}
+
+	if err := f.DB.Write(ctx, ops...); err != nil {
+		return nil, err
+	}
+
+	seq := last
+
+	for _, c := range retry {
+		if c.Sequence <= seq {
+			seq = c.Sequence - 1
+		}
+	}
+
+	if seq == f.seq {
+		return retry, nil
+	}
+
+	if f.Checkpoint != nil {
+		if err := f.Checkpoint.Store(ctx, seq); err != nil {
+			return nil, err
+		}
+	}
+
+	f.seq = seq
+
+	return retry, nil
+}
+
+// reset deletes every document in the follower database, so that the leader's
+// documents can be replicated again from the beginning.
+//
+// The documents are deleted before the checkpoint is reset, so that if the
+// checkpoint can not be stored the next sync resets the follower again.
+func (f *Follower) reset(ctx context.Context) error {
+	// a delete-where operation with a nil filter deletes every document
+	if err := f.DB.Write(ctx, &driver.DeleteWhere{}); err != nil {
+		return err
+	}
+
+	if f.Checkpoint != nil {
+		if err := f.Checkpoint.Store(ctx, 0); err != nil {
+			return err
+		}
+	}
+
+	f.seq = 0
+
+	return nil
+}
+
+// applyChanges returns the operations that apply the given changes, and the
+// changes that must be applied again once those operations have been executed.
+//
+// A unique key may have moved between documents since the follower's last
+// sync, in which case the document that currently has the key on the follower
+// may not have been updated yet. Any document with unique keys is therefore
+// first applied without them, and then again with them once all other changes
+// have been applied.
+func applyChanges(changes []*driver.Change) ([]driver.Operation, []*driver.Change) {
+	ops := make([]driver.Operation, 0, len(changes))
+	var deferred []*driver.Change
+
+	for _, c := range changes {
+		if c.IsDeleted() || !hasUniqueKeys(c.Document) {
+			ops = append(ops, protavo.ApplyChange(c))
+			continue
+		}
+
+		doc := *c.Document
+		doc.Keys = sharedKeys(doc.Keys)
+		doc.DerivedKeys = sharedKeys(doc.DerivedKeys)
+
+		ops = append(
+			ops,
+			protavo.ApplyChange(&driver.Change{
+				Sequence:   c.Sequence,
+				DocumentID: c.DocumentID,
+				Document:   &doc,
+			}),
+		)
+
+		deferred = append(deferred, c)
+	}
+
+	return ops, deferred
+}
+
+// hasUniqueKeys returns true if doc has any unique keys, including derived
+// keys.
+func hasUniqueKeys(doc *document.Document) bool {
+	for _, t := range doc.IndexedKeys() {
+		if t == document.UniqueKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sharedKeys returns the shared keys in keys.
+func sharedKeys(keys document.Keys) document.Keys {
+	r := document.Keys{}
+
+	for k, t := range keys {
+		if t == document.SharedKey {
+			r[k] = t
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,344 @@
+package replication_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"time"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	. "github.com/jmalloc/protavo/src/protavo/replication"
+	"github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+var _ = g.Describe("Follower", func() {
+	transports := []struct {
+		Name      string
+		NewSource func(leader *protavo.DB) (Source, func())
+	}{
+		{
+			"in-process",
+			func(leader *protavo.DB) (Source, func()) {
+				return leader, func() {}
+			},
+		},
+		{
+			"HTTP",
+			func(leader *protavo.DB) (Source, func()) {
+				server := httptest.NewServer(&Handler{Leader: leader})
+				return &HTTPSource{URL: server.URL}, server.Close
+			},
+		},
+	}
+
+	for _, t := range transports {
+		t := t
+
+		g.Context("using the "+t.Name+" transport", func() {
+			var (
+				ctx             = context.Background()
+				leader, replica *protavo.DB
+				source          Source
+				closeSource     func()
+				checkpoint      Checkpoint
+				follower        *Follower
+			)
+
+			g.BeforeEach(func() {
+				var err error
+				leader, err = protavobolt.OpenTemp(0600, nil)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				replica, err = protavobolt.OpenTemp(0600, nil)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				source, closeSource = t.NewSource(leader)
+
+				checkpoint = &DocumentCheckpoint{
+					DB:         replica.Namespace("replication"),
+					DocumentID: "checkpoint",
+				}
+
+				follower = &Follower{
+					Leader:     source,
+					DB:         replica,
+					Checkpoint: checkpoint,
+				}
+
+				err = leader.Save(
+					ctx,
+					&document.Document{
+						ID:      "doc-1",
+						Keys:    document.UniqueKeys("key-1"),
+						Content: document.StringContent("content-1"),
+					},
+					&document.Document{
+						ID:      "doc-2",
+						Content: document.StringContent("content-2"),
+					},
+				)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+
+			g.AfterEach(func() {
+				closeSource()
+				_ = leader.Close()
+				_ = replica.Close()
+			})
+
+			g.Describe("Sync", func() {
+				g.It("replicates documents with the same revisions and timestamps", func() {
+					n, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(n).To(m.Equal(2))
+
+					expected, err := leader.LoadAll(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					docs, err := replica.LoadAll(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(docs).To(m.HaveLen(len(expected)))
+
+					for i, doc := range docs {
+						m.Expect(doc.ID).To(m.Equal(expected[i].ID))
+						m.Expect(doc.Revision).To(m.Equal(expected[i].Revision))
+						m.Expect(doc.Keys).To(m.Equal(expected[i].Keys))
+						m.Expect(doc.Content).To(m.Equal(expected[i].Content))
+						m.Expect(doc.CreatedAt.Equal(expected[i].CreatedAt)).To(m.BeTrue())
+						m.Expect(doc.UpdatedAt.Equal(expected[i].UpdatedAt)).To(m.BeTrue())
+					}
+				})
+
+				g.It("replicates updates and deletions", func() {
+					_, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					doc, _, err := leader.Load(ctx, "doc-1")
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					doc.Content = document.StringContent("updated")
+					err = leader.Save(ctx, doc)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					_, err = leader.DeleteByID(ctx, "doc-2")
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					n, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(n).To(m.Equal(2))
+
+					docs, err := replica.LoadAll(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(docs).To(m.HaveLen(1))
+					m.Expect(docs[0].Revision).To(m.BeEquivalentTo(2))
+					m.Expect(docs[0].Content).To(m.Equal(document.StringContent("updated")))
+				})
+
+				g.It("does nothing if there are no new changes", func() {
+					_, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					n, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(n).To(m.Equal(0))
+				})
+
+				g.It("resumes from the checkpoint", func() {
+					_, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					err = leader.Save(ctx, &document.Document{
+						ID:      "doc-3",
+						Content: document.StringContent("content-3"),
+					})
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					follower = &Follower{
+						Leader:     source,
+						DB:         replica,
+						Checkpoint: checkpoint,
+					}
+
+					n, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(n).To(m.Equal(1))
+
+					docs, err := replica.LoadAll(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(docs).To(m.HaveLen(3))
+				})
+
+				g.It("replicates unique keys that have moved between documents", func() {
+					_, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					doc1, _, err := leader.Load(ctx, "doc-1")
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					doc2, _, err := leader.Load(ctx, "doc-2")
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					// move key-1 from doc-1 to doc-2, then modify doc-1 again so
+					// that its change is ordered after doc-2's
+					doc1.Keys = nil
+					err = leader.Save(ctx, doc1)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					doc2.Keys = document.UniqueKeys("key-1")
+					err = leader.Save(ctx, doc2)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					doc1.Content = document.StringContent("updated")
+					err = leader.Save(ctx, doc1)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					_, err = follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					doc, ok, err := replica.LoadByUniqueKey(ctx, "key-1")
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(ok).To(m.BeTrue())
+					m.Expect(doc.ID).To(m.Equal("doc-2"))
+				})
+
+				g.It("applies the changes in batches", func() {
+					follower.BatchSize = 1
+
+					n, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(n).To(m.Equal(2))
+
+					docs, err := replica.LoadAll(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(docs).To(m.HaveLen(2))
+
+					seq, err := checkpoint.Load(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(seq).To(m.BeEquivalentTo(2))
+				})
+
+				g.It("replicates unique keys that have moved between documents in different batches", func() {
+					follower.BatchSize = 1
+
+					_, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					doc1, _, err := leader.Load(ctx, "doc-1")
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					doc2, _, err := leader.Load(ctx, "doc-2")
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					// move key-1 from doc-1 to doc-2 in a single write, so that
+					// doc-2's change is ordered before doc-1's
+					doc1.Keys = nil
+					doc2.Keys = document.UniqueKeys("key-1")
+					err = leader.Write(
+						ctx,
+						protavo.Save(doc1),
+						protavo.Save(doc2),
+					)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					doc1.Content = document.StringContent("updated")
+					err = leader.Save(ctx, doc1)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					_, err = follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					doc, ok, err := replica.LoadByUniqueKey(ctx, "key-1")
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(ok).To(m.BeTrue())
+					m.Expect(doc.ID).To(m.Equal("doc-2"))
+				})
+
+				g.It("replicates every document again if the leader's namespace is deleted", func() {
+					_, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					err = leader.Write(
+						ctx,
+						protavo.DeleteNamespace(),
+						protavo.Save(&document.Document{
+							ID:      "doc-3",
+							Content: document.StringContent("content-3"),
+						}),
+					)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					n, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(n).To(m.Equal(1))
+
+					docs, err := replica.LoadAll(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(docs).To(m.HaveLen(1))
+					m.Expect(docs[0].ID).To(m.Equal("doc-3"))
+				})
+
+				g.It("replicates every document again if the checkpoint is ahead of the leader", func() {
+					err := replica.Save(ctx, &document.Document{
+						ID:      "doc-stale",
+						Content: document.StringContent("stale"),
+					})
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					err = checkpoint.Store(ctx, 1000)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					n, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(n).To(m.Equal(2))
+
+					docs, err := replica.LoadAll(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(docs).To(m.HaveLen(2))
+					m.Expect(docs[0].ID).To(m.Equal("doc-1"))
+					m.Expect(docs[1].ID).To(m.Equal("doc-2"))
+				})
+
+				g.It("serves filtered queries from the replica", func() {
+					_, err := follower.Sync(ctx)
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					docs, err := replica.LoadManyWhere(ctx, protavo.HasUniqueKeyIn("key-1"))
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+					m.Expect(docs).To(m.HaveLen(1))
+					m.Expect(docs[0].ID).To(m.Equal("doc-1"))
+				})
+			})
+
+			g.Describe("Run", func() {
+				g.It("applies changes until the context is canceled", func() {
+					follower.PollInterval = 10 * time.Millisecond
+
+					ctx, cancel := context.WithCancel(ctx)
+					defer cancel()
+
+					result := make(chan error, 1)
+					go func() {
+						result <- follower.Run(ctx)
+					}()
+
+					err := leader.Save(ctx, &document.Document{
+						ID:      "doc-3",
+						Content: document.StringContent("content-3"),
+					})
+					m.Expect(err).ShouldNot(m.HaveOccurred())
+
+					m.Eventually(func() bool {
+						_, ok, err := replica.Load(ctx, "doc-3")
+						m.Expect(err).ShouldNot(m.HaveOccurred())
+						return ok
+					}).Should(m.BeTrue())
+
+					cancel()
+					m.Eventually(result).Should(m.Receive(m.Equal(context.Canceled)))
+				})
+			})
+		})
+	}
+})
@@ -0,0 +1,213 @@
+package replication
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+)
+
+// contentType is the MIME type of the stream of frames served by Handler.
+const contentType = "application/vnd.protavo.replication.frames+protobuf"
+
+// Handler is an HTTP handler that serves the changes made to a leader
+// database to followers that use HTTPSource.
+type Handler struct {
+	// Leader is the source of the changes, typically the leader *protavo.DB.
+	Leader Source
+}
+
+// ServeHTTP streams the changes since the sequence number in the "since"
+// query parameter, as a sequence of length-delimited Frame messages.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var seq uint64
+
+	if s := r.URL.Query().Get("since"); s != "" {
+		var err error
+		seq, err = strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid sequence number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	bw := bufio.NewWriter(w)
+
+	err := h.Leader.ChangesSince(
+		r.Context(),
+		seq,
+		func(c *driver.Change) (bool, error) {
+			m, err := marshalChange(c)
+			if err != nil {
+				return false, err
+			}
+
+			return true, writeFrame(bw, &Frame{
+				Frame: &Frame_Change{Change: m},
+			})
+		},
+	)
+
+	// the response status has already been sent, so errors are reported to the
+	// follower in the final frame
+	if e, ok := err.(*protavo.StaleSequenceError); ok {
+		_ = writeFrame(bw, &Frame{
+			Frame: &Frame_StaleSequence{
+				StaleSequence: &StaleSequence{
+					Namespace:  e.Namespace,
+					GivenSeq:   e.GivenSeq,
+					CurrentSeq: e.CurrentSeq,
+					ResetSeq:   e.ResetSeq,
+				},
+			},
+		})
+	} else if err != nil {
+		_ = writeFrame(bw, &Frame{
+			Frame: &Frame_Error{Error: err.Error()},
+		})
+	} else {
+		_ = writeFrame(bw, &Frame{
+			Frame: &Frame_End{End: true},
+		})
+	}
+
+	_ = bw.Flush()
+}
+
+// HTTPSource is a Source that obtains the changes made to a leader database
+// from a Handler on another server.
+type HTTPSource struct {
+	// URL is the URL at which the leader's Handler is served.
+	URL string
+
+	// Client is the HTTP client used to make requests. If it is nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+}
+
+// ChangesSince calls fn once for each document that has changed on the leader
+// since the write with the sequence number seq, in the order of the changes.
+//
+// It stops iterating if fn returns false or a non-nil error.
+func (s *HTTPSource) ChangesSince(
+	ctx context.Context,
+	seq uint64,
+	fn driver.ChangeFunc,
+) error {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return err
+	}
+
+	q := u.Query()
+	q.Set("since", strconv.FormatUint(seq, 10))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	c := s.Client
+	if c == nil {
+		c = http.DefaultClient
+	}
+
+	res, err := c.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"leader responded with unexpected status: %s",
+			res.Status,
+		)
+	}
+
+	r := bufio.NewReader(res.Body)
+
+	for {
+		f, err := readFrame(r)
+		if err != nil {
+			return err
+		}
+
+		switch x := f.Frame.(type) {
+		case *Frame_Change:
+			c, err := unmarshalChange(x.Change)
+			if err != nil {
+				return err
+			}
+
+			ok, err := fn(c)
+			if !ok || err != nil {
+				return err
+			}
+		case *Frame_Error:
+			return errors.New("leader failed: " + x.Error)
+		case *Frame_StaleSequence:
+			return &protavo.StaleSequenceError{
+				Namespace:  x.StaleSequence.Namespace,
+				GivenSeq:   x.StaleSequence.GivenSeq,
+				CurrentSeq: x.StaleSequence.CurrentSeq,
+				ResetSeq:   x.StaleSequence.ResetSeq,
+			}
+		case *Frame_End:
+			return nil
+		default:
+			return errors.New("leader sent an unrecognized frame")
+		}
+	}
+}
+
+// writeFrame writes a length-delimited frame to w.
+func writeFrame(w io.Writer, f *Frame) error {
+	buf, err := proto.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	var n [binary.MaxVarintLen64]byte
+	if _, err := w.Write(n[:binary.PutUvarint(n[:], uint64(len(buf)))]); err != nil {
+		return err
+	}
+
+	_, err = w.Write(buf)
+	return err
+}
+
+// readFrame reads a length-delimited frame from r.
+func readFrame(r *bufio.Reader) (*Frame, error) {
+	n, err := binary.ReadUvarint(r)
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+
+	f := &Frame{}
+	return f, proto.Unmarshal(buf, f)
+}
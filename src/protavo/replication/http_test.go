@@ -0,0 +1,127 @@
+package replication_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	. "github.com/jmalloc/protavo/src/protavo/replication"
+	"github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+var _ = g.Describe("HTTPSource", func() {
+	var (
+		ctx    = context.Background()
+		leader *protavo.DB
+		server *httptest.Server
+		source *HTTPSource
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		leader, err = protavobolt.OpenTemp(0600, nil)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		server = httptest.NewServer(&Handler{Leader: leader})
+		source = &HTTPSource{URL: server.URL}
+
+		err = leader.Save(
+			ctx,
+			&document.Document{
+				ID:      "doc-1",
+				Content: document.StringContent("content-1"),
+			},
+			&document.Document{
+				ID:      "doc-2",
+				Content: document.StringContent("content-2"),
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.AfterEach(func() {
+		server.Close()
+		_ = leader.Close()
+	})
+
+	g.It("returns the same changes as the leader", func() {
+		var expected, changes []*driver.Change
+
+		err := leader.ChangesSince(
+			ctx,
+			0,
+			func(c *driver.Change) (bool, error) {
+				expected = append(expected, c)
+				return true, nil
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = source.ChangesSince(
+			ctx,
+			expected[0].Sequence,
+			func(c *driver.Change) (bool, error) {
+				changes = append(changes, c)
+				return true, nil
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(changes).To(m.HaveLen(1))
+		m.Expect(changes[0].Sequence).To(m.Equal(expected[1].Sequence))
+		m.Expect(changes[0].DocumentID).To(m.Equal("doc-2"))
+		m.Expect(changes[0].Document.Content).To(m.Equal(document.StringContent("content-2")))
+	})
+
+	g.It("stops iterating if the function returns false", func() {
+		var count int
+
+		err := source.ChangesSince(
+			ctx,
+			0,
+			func(*driver.Change) (bool, error) {
+				count++
+				return false, nil
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(count).To(m.Equal(1))
+	})
+
+	g.It("returns a stale sequence error if the leader's namespace has been deleted", func() {
+		err := leader.Write(ctx, protavo.DeleteNamespace())
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = source.ChangesSince(
+			ctx,
+			1,
+			func(*driver.Change) (bool, error) {
+				return true, nil
+			},
+		)
+		m.Expect(err).To(m.Equal(
+			&protavo.StaleSequenceError{
+				GivenSeq:   1,
+				CurrentSeq: 3,
+				ResetSeq:   3,
+			},
+		))
+	})
+
+	g.It("returns an error if the leader does not respond successfully", func() {
+		server.Config.Handler = http.NotFoundHandler()
+
+		err := source.ChangesSince(
+			ctx,
+			0,
+			func(*driver.Change) (bool, error) {
+				return true, nil
+			},
+		)
+		m.Expect(err).To(m.MatchError("leader responded with unexpected status: 404 Not Found"))
+	})
+})
@@ -0,0 +1,109 @@
+package replication
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+)
+
+// marshalChange converts a change from the public API to wire format.
+func marshalChange(c *driver.Change) (*Change, error) {
+	m := &Change{
+		Sequence:   c.Sequence,
+		DocumentId: c.DocumentID,
+	}
+
+	if c.IsDeleted() {
+		return m, nil
+	}
+
+	doc := c.Document
+	m.Document = &Document{
+		Revision:    doc.Revision,
+		Keys:        marshalKeys(doc.Keys),
+		Headers:     doc.Headers,
+		DerivedKeys: marshalKeys(doc.DerivedKeys),
+	}
+
+	var err error
+
+	if m.Document.Content, err = ptypes.MarshalAny(doc.Content); err != nil {
+		return nil, err
+	}
+
+	if m.Document.CreatedAt, err = ptypes.TimestampProto(doc.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	m.Document.UpdatedAt, err = ptypes.TimestampProto(doc.UpdatedAt)
+	return m, err
+}
+
+// unmarshalChange converts a change from wire format to the public API.
+//
+// The content type must be registered with the protocol buffers package in
+// the follower's process.
+func unmarshalChange(m *Change) (*driver.Change, error) {
+	c := &driver.Change{
+		Sequence:   m.Sequence,
+		DocumentID: m.DocumentId,
+	}
+
+	if m.Document == nil {
+		return c, nil
+	}
+
+	doc := &document.Document{
+		ID:       m.DocumentId,
+		Revision: m.Document.Revision,
+		Keys:     unmarshalKeys(m.Document.Keys),
+		Headers:  m.Document.Headers,
+	}
+
+	if len(m.Document.DerivedKeys) != 0 {
+		doc.DerivedKeys = unmarshalKeys(m.Document.DerivedKeys)
+	}
+
+	var x ptypes.DynamicAny
+	if err := ptypes.UnmarshalAny(m.Document.Content, &x); err != nil {
+		return nil, err
+	}
+
+	doc.Content = x.Message
+
+	var err error
+
+	if doc.CreatedAt, err = ptypes.Timestamp(m.Document.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if doc.UpdatedAt, err = ptypes.Timestamp(m.Document.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	c.Document = doc
+
+	return c, nil
+}
+
+// marshalKeys converts a key map from the public API to wire format.
+func marshalKeys(keys document.Keys) map[string]uint32 {
+	r := make(map[string]uint32, len(keys))
+
+	for k, t := range keys {
+		r[k] = uint32(t)
+	}
+
+	return r
+}
+
+// unmarshalKeys converts a key map from wire format to the public API.
+func unmarshalKeys(keys map[string]uint32) document.Keys {
+	r := make(document.Keys, len(keys))
+
+	for k, t := range keys {
+		r[k] = document.KeyType(t)
+	}
+
+	return r
+}
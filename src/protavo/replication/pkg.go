@@ -0,0 +1,4 @@
+// Package replication provides leader/follower replication between Protavo
+// databases, such that a follower database can serve read-only traffic as a
+// replica of the leader.
+package replication
@@ -0,0 +1,493 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: src/protavo/replication/replication.proto
+
+package replication
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import any "github.com/golang/protobuf/ptypes/any"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Frame is a single message within a stream of changes sent from a leader to
+// a follower.
+type Frame struct {
+	// Types that are valid to be assigned to Frame:
+	//	*Frame_Change
+	//	*Frame_Error
+	//	*Frame_End
+	//	*Frame_StaleSequence
+	Frame                isFrame_Frame `protobuf_oneof:"frame"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Frame) Reset()         { *m = Frame{} }
+func (m *Frame) String() string { return proto.CompactTextString(m) }
+func (*Frame) ProtoMessage()    {}
+func (*Frame) Descriptor() ([]byte, []int) {
+	return fileDescriptor_replication_efca1c15c0742393, []int{0}
+}
+func (m *Frame) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Frame.Unmarshal(m, b)
+}
+func (m *Frame) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Frame.Marshal(b, m, deterministic)
+}
+func (dst *Frame) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Frame.Merge(dst, src)
+}
+func (m *Frame) XXX_Size() int {
+	return xxx_messageInfo_Frame.Size(m)
+}
+func (m *Frame) XXX_DiscardUnknown() {
+	xxx_messageInfo_Frame.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Frame proto.InternalMessageInfo
+
+type isFrame_Frame interface {
+	isFrame_Frame()
+}
+
+type Frame_Change struct {
+	Change *Change `protobuf:"bytes,1,opt,name=change,proto3,oneof"`
+}
+
+type Frame_Error struct {
+	Error string `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+type Frame_End struct {
+	End bool `protobuf:"varint,3,opt,name=end,proto3,oneof"`
+}
+
+type Frame_StaleSequence struct {
+	StaleSequence *StaleSequence `protobuf:"bytes,4,opt,name=stale_sequence,json=staleSequence,proto3,oneof"`
+}
+
+func (*Frame_Change) isFrame_Frame() {}
+
+func (*Frame_Error) isFrame_Frame() {}
+
+func (*Frame_End) isFrame_Frame() {}
+
+func (*Frame_StaleSequence) isFrame_Frame() {}
+
+func (m *Frame) GetFrame() isFrame_Frame {
+	if m != nil {
+		return m.Frame
+	}
+	return nil
+}
+
+func (m *Frame) GetChange() *Change {
+	if x, ok := m.GetFrame().(*Frame_Change); ok {
+		return x.Change
+	}
+	return nil
+}
+
+func (m *Frame) GetError() string {
+	if x, ok := m.GetFrame().(*Frame_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (m *Frame) GetEnd() bool {
+	if x, ok := m.GetFrame().(*Frame_End); ok {
+		return x.End
+	}
+	return false
+}
+
+func (m *Frame) GetStaleSequence() *StaleSequence {
+	if x, ok := m.GetFrame().(*Frame_StaleSequence); ok {
+		return x.StaleSequence
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Frame) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Frame_OneofMarshaler, _Frame_OneofUnmarshaler, _Frame_OneofSizer, []interface{}{
+		(*Frame_Change)(nil),
+		(*Frame_Error)(nil),
+		(*Frame_End)(nil),
+		(*Frame_StaleSequence)(nil),
+	}
+}
+
+func _Frame_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Frame)
+	// frame
+	switch x := m.Frame.(type) {
+	case *Frame_Change:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Change); err != nil {
+			return err
+		}
+	case *Frame_Error:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.Error)
+	case *Frame_End:
+		t := uint64(0)
+		if x.End {
+			t = 1
+		}
+		b.EncodeVarint(3<<3 | proto.WireVarint)
+		b.EncodeVarint(t)
+	case *Frame_StaleSequence:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.StaleSequence); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Frame.Frame has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Frame_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Frame)
+	switch tag {
+	case 1: // frame.change
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Change)
+		err := b.DecodeMessage(msg)
+		m.Frame = &Frame_Change{msg}
+		return true, err
+	case 2: // frame.error
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Frame = &Frame_Error{x}
+		return true, err
+	case 3: // frame.end
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Frame = &Frame_End{x != 0}
+		return true, err
+	case 4: // frame.stale_sequence
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(StaleSequence)
+		err := b.DecodeMessage(msg)
+		m.Frame = &Frame_StaleSequence{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Frame_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Frame)
+	// frame
+	switch x := m.Frame.(type) {
+	case *Frame_Change:
+		s := proto.Size(x.Change)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Frame_Error:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.Error)))
+		n += len(x.Error)
+	case *Frame_End:
+		n += 1 // tag and wire
+		n += 1
+	case *Frame_StaleSequence:
+		s := proto.Size(x.StaleSequence)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// StaleSequence describes a sequence number for which the changes are no
+// longer available.
+type StaleSequence struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	GivenSeq             uint64   `protobuf:"varint,2,opt,name=given_seq,json=givenSeq,proto3" json:"given_seq,omitempty"`
+	CurrentSeq           uint64   `protobuf:"varint,3,opt,name=current_seq,json=currentSeq,proto3" json:"current_seq,omitempty"`
+	ResetSeq             uint64   `protobuf:"varint,4,opt,name=reset_seq,json=resetSeq,proto3" json:"reset_seq,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StaleSequence) Reset()         { *m = StaleSequence{} }
+func (m *StaleSequence) String() string { return proto.CompactTextString(m) }
+func (*StaleSequence) ProtoMessage()    {}
+func (*StaleSequence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_replication_efca1c15c0742393, []int{1}
+}
+func (m *StaleSequence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StaleSequence.Unmarshal(m, b)
+}
+func (m *StaleSequence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StaleSequence.Marshal(b, m, deterministic)
+}
+func (dst *StaleSequence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StaleSequence.Merge(dst, src)
+}
+func (m *StaleSequence) XXX_Size() int {
+	return xxx_messageInfo_StaleSequence.Size(m)
+}
+func (m *StaleSequence) XXX_DiscardUnknown() {
+	xxx_messageInfo_StaleSequence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StaleSequence proto.InternalMessageInfo
+
+func (m *StaleSequence) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StaleSequence) GetGivenSeq() uint64 {
+	if m != nil {
+		return m.GivenSeq
+	}
+	return 0
+}
+
+func (m *StaleSequence) GetCurrentSeq() uint64 {
+	if m != nil {
+		return m.CurrentSeq
+	}
+	return 0
+}
+
+func (m *StaleSequence) GetResetSeq() uint64 {
+	if m != nil {
+		return m.ResetSeq
+	}
+	return 0
+}
+
+// Change is the most recent modification of a document.
+type Change struct {
+	// sequence is the leader's sequence number for the write that made the
+	// change.
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// document_id is the ID of the document that was changed.
+	DocumentId string `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// document is the document as of the change. It is absent if the document
+	// was deleted.
+	Document             *Document `protobuf:"bytes,3,opt,name=document,proto3" json:"document,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Change) Reset()         { *m = Change{} }
+func (m *Change) String() string { return proto.CompactTextString(m) }
+func (*Change) ProtoMessage()    {}
+func (*Change) Descriptor() ([]byte, []int) {
+	return fileDescriptor_replication_efca1c15c0742393, []int{2}
+}
+func (m *Change) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Change.Unmarshal(m, b)
+}
+func (m *Change) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Change.Marshal(b, m, deterministic)
+}
+func (dst *Change) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Change.Merge(dst, src)
+}
+func (m *Change) XXX_Size() int {
+	return xxx_messageInfo_Change.Size(m)
+}
+func (m *Change) XXX_DiscardUnknown() {
+	xxx_messageInfo_Change.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Change proto.InternalMessageInfo
+
+func (m *Change) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Change) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+func (m *Change) GetDocument() *Document {
+	if m != nil {
+		return m.Document
+	}
+	return nil
+}
+
+// Document is the wire representation of a document.
+type Document struct {
+	Revision             uint64               `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Keys                 map[string]uint32    `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Headers              map[string]string    `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Content              *any.Any             `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DerivedKeys          map[string]uint32    `protobuf:"bytes,7,rep,name=derived_keys,json=derivedKeys,proto3" json:"derived_keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Document) Reset()         { *m = Document{} }
+func (m *Document) String() string { return proto.CompactTextString(m) }
+func (*Document) ProtoMessage()    {}
+func (*Document) Descriptor() ([]byte, []int) {
+	return fileDescriptor_replication_efca1c15c0742393, []int{3}
+}
+func (m *Document) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Document.Unmarshal(m, b)
+}
+func (m *Document) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Document.Marshal(b, m, deterministic)
+}
+func (dst *Document) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Document.Merge(dst, src)
+}
+func (m *Document) XXX_Size() int {
+	return xxx_messageInfo_Document.Size(m)
+}
+func (m *Document) XXX_DiscardUnknown() {
+	xxx_messageInfo_Document.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Document proto.InternalMessageInfo
+
+func (m *Document) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *Document) GetKeys() map[string]uint32 {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *Document) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *Document) GetContent() *any.Any {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *Document) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Document) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *Document) GetDerivedKeys() map[string]uint32 {
+	if m != nil {
+		return m.DerivedKeys
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Frame)(nil), "protavo.replication.Frame")
+	proto.RegisterType((*StaleSequence)(nil), "protavo.replication.StaleSequence")
+	proto.RegisterType((*Change)(nil), "protavo.replication.Change")
+	proto.RegisterType((*Document)(nil), "protavo.replication.Document")
+	proto.RegisterMapType((map[string]uint32)(nil), "protavo.replication.Document.DerivedKeysEntry")
+	proto.RegisterMapType((map[string]string)(nil), "protavo.replication.Document.HeadersEntry")
+	proto.RegisterMapType((map[string]uint32)(nil), "protavo.replication.Document.KeysEntry")
+}
+
+func init() {
+	proto.RegisterFile("src/protavo/replication/replication.proto", fileDescriptor_replication_efca1c15c0742393)
+}
+
+var fileDescriptor_replication_efca1c15c0742393 = []byte{
+	// 552 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc7, 0xe3, 0x3a, 0x5f, 0x1e, 0x37, 0xa8, 0x5a, 0x2a, 0x64, 0x5c, 0x50, 0xa3, 0x5c, 0x08,
+	0x1c, 0x5c, 0x29, 0x08, 0x41, 0x8b, 0x84, 0x94, 0x12, 0x50, 0x50, 0x4f, 0x6c, 0x38, 0x71, 0x89,
+	0xb6, 0xf6, 0x34, 0xb5, 0x9a, 0xac, 0x93, 0xb5, 0x1d, 0xc9, 0x37, 0x8e, 0x3c, 0x16, 0x6f, 0xc1,
+	0xeb, 0xa0, 0xdd, 0xf5, 0x26, 0xa6, 0x44, 0xa9, 0xb8, 0xed, 0xfc, 0xe7, 0xf7, 0xdf, 0xf9, 0xd0,
+	0xc0, 0xcb, 0x54, 0x84, 0x67, 0x4b, 0x91, 0x64, 0x6c, 0x9d, 0x9c, 0x09, 0x5c, 0xce, 0xe3, 0x90,
+	0x65, 0x71, 0xc2, 0xab, 0xef, 0x40, 0xe6, 0x13, 0xf2, 0xb8, 0xc4, 0x82, 0x4a, 0xca, 0x7f, 0x3a,
+	0x4b, 0x92, 0xd9, 0x1c, 0xd5, 0x17, 0xc9, 0x75, 0x7e, 0x73, 0xc6, 0x78, 0xa1, 0x79, 0xff, 0xf4,
+	0x7e, 0x2a, 0x8b, 0x17, 0x98, 0x66, 0x6c, 0xb1, 0xd4, 0x40, 0xef, 0x97, 0x05, 0x8d, 0xcf, 0x82,
+	0x2d, 0x90, 0xbc, 0x81, 0x66, 0x78, 0xcb, 0xf8, 0x0c, 0x3d, 0xab, 0x6b, 0xf5, 0xdd, 0xc1, 0x49,
+	0xb0, 0xa3, 0x56, 0xf0, 0x51, 0x21, 0xe3, 0x1a, 0x2d, 0x61, 0xf2, 0x04, 0x1a, 0x28, 0x44, 0x22,
+	0xbc, 0x83, 0xae, 0xd5, 0x77, 0xc6, 0x35, 0xaa, 0x43, 0x42, 0xc0, 0x46, 0x1e, 0x79, 0x76, 0xd7,
+	0xea, 0xb7, 0xc7, 0x35, 0x2a, 0x03, 0x72, 0x05, 0x8f, 0xd2, 0x8c, 0xcd, 0x71, 0x9a, 0xe2, 0x2a,
+	0x47, 0x1e, 0xa2, 0x57, 0x57, 0xa5, 0x7a, 0x3b, 0x4b, 0x4d, 0x24, 0x3a, 0x29, 0xc9, 0x71, 0x8d,
+	0x76, 0xd2, 0xaa, 0x70, 0xd9, 0x82, 0xc6, 0x8d, 0x6c, 0xbc, 0xf7, 0xd3, 0x82, 0xce, 0x5f, 0x2c,
+	0x79, 0x06, 0x0e, 0x67, 0x0b, 0x4c, 0x97, 0x2c, 0xd4, 0xd3, 0x38, 0x74, 0x2b, 0x90, 0x13, 0x70,
+	0x66, 0xf1, 0x1a, 0xb9, 0xec, 0x42, 0x75, 0x5d, 0xa7, 0x6d, 0x25, 0x4c, 0x70, 0x45, 0x4e, 0xc1,
+	0x0d, 0x73, 0x21, 0x90, 0x67, 0x2a, 0x6d, 0xab, 0x34, 0x94, 0x92, 0x04, 0x4e, 0xc0, 0x11, 0x98,
+	0xa2, 0x4e, 0xd7, 0xb5, 0x5b, 0x09, 0x13, 0x5c, 0xf5, 0x7e, 0x58, 0xd0, 0xd4, 0x1b, 0x22, 0x3e,
+	0xb4, 0x37, 0x53, 0x5a, 0x1a, 0x33, 0xb1, 0x2c, 0x12, 0x25, 0x61, 0xbe, 0x90, 0x55, 0xe2, 0x48,
+	0x6f, 0x8e, 0x82, 0x91, 0xbe, 0x44, 0xe4, 0x1c, 0xda, 0x26, 0x52, 0x2d, 0xb8, 0x83, 0xe7, 0x3b,
+	0x57, 0x34, 0x2a, 0x21, 0xba, 0xc1, 0x7b, 0xbf, 0xeb, 0xd0, 0x36, 0xb2, 0x6c, 0x42, 0xe0, 0x3a,
+	0x4e, 0xe3, 0x84, 0x9b, 0x26, 0x4c, 0x4c, 0xde, 0x43, 0xfd, 0x0e, 0x8b, 0xd4, 0x3b, 0xe8, 0xda,
+	0x7d, 0x77, 0xf0, 0x62, 0xef, 0xff, 0xc1, 0x15, 0x16, 0xe9, 0x27, 0x9e, 0x89, 0x82, 0x2a, 0x13,
+	0x19, 0x41, 0xeb, 0x16, 0x59, 0x84, 0x22, 0xf5, 0x6c, 0xe5, 0x7f, 0xb5, 0xdf, 0x3f, 0xd6, 0xb0,
+	0xfe, 0xc2, 0x58, 0x49, 0x00, 0xad, 0x30, 0xe1, 0x99, 0x9c, 0x52, 0x1f, 0xc2, 0x71, 0xa0, 0xef,
+	0x35, 0x30, 0xf7, 0x1a, 0x0c, 0x79, 0x41, 0x0d, 0x44, 0xce, 0x01, 0x42, 0x81, 0x2c, 0xc3, 0x68,
+	0xca, 0x32, 0xaf, 0xa1, 0x2c, 0xfe, 0x3f, 0x96, 0x6f, 0xe6, 0xc4, 0xa9, 0x53, 0xd2, 0x43, 0x65,
+	0xcd, 0x97, 0x91, 0xb1, 0x36, 0x1f, 0xb6, 0x96, 0xf4, 0x30, 0x23, 0x5f, 0xe1, 0x30, 0x42, 0x11,
+	0xaf, 0x31, 0x9a, 0xaa, 0x85, 0xb5, 0xd4, 0xc0, 0xc1, 0xfe, 0x81, 0x47, 0xda, 0xb1, 0xdd, 0x9b,
+	0x1b, 0x6d, 0x15, 0xff, 0x2d, 0x38, 0x9b, 0x0c, 0x39, 0x02, 0xfb, 0x0e, 0x8b, 0xf2, 0x4e, 0xe5,
+	0x93, 0x1c, 0x43, 0x63, 0xcd, 0xe6, 0x39, 0xaa, 0xcb, 0xe8, 0x50, 0x1d, 0x5c, 0x1c, 0xbc, 0xb3,
+	0xfc, 0x0b, 0x38, 0xac, 0xae, 0xf2, 0x21, 0xaf, 0x53, 0xf5, 0x7e, 0x80, 0xa3, 0xfb, 0x5d, 0xfd,
+	0x4f, 0xed, 0xcb, 0xce, 0x77, 0xb7, 0x32, 0xea, 0x75, 0x53, 0x6d, 0xed, 0xf5, 0x9f, 0x01, 0x00,
+	0x5d, 0x1e, 0xfe, 0x57, 0xbe, 0x04, 0x00, 0x00,
+}
@@ -0,0 +1,22 @@
+package replication
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo/driver"
+)
+
+// Source is a transport that provides a follower with the changes made to the
+// leader database.
+//
+// *protavo.DB implements Source, and can be used directly to replicate between
+// databases in the same process. HTTPSource obtains the changes from a leader
+// in another process.
+type Source interface {
+	// ChangesSince calls fn once for each document that has changed on the
+	// leader since the write with the sequence number seq, in the order of the
+	// changes.
+	//
+	// It stops iterating if fn returns false or a non-nil error.
+	ChangesSince(ctx context.Context, seq uint64, fn driver.ChangeFunc) error
+}
@@ -0,0 +1,329 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/filter"
+)
+
+// contentType is the MIME type of the request and response bodies.
+const contentType = "application/json"
+
+// Handler is an HTTP handler that exposes the documents in a database as a
+// REST API.
+//
+// Documents are addressed by the path "/<namespace>/<id>", where namespace is
+// a namespace within DB. Both components are URL-decoded, so they may contain
+// escaped slashes. Documents are represented as JSON objects, in which the
+// content is encoded using the JSON mapping for google.protobuf.Any.
+//
+// The following requests are supported:
+//
+//	- GET /<namespace>/<id> returns a document
+//	- PUT /<namespace>/<id> creates or updates a document
+//	- DELETE /<namespace>/<id> deletes a document
+//	- GET /<namespace>/ returns the documents within a namespace
+//
+// The collection request returns only those documents that have all of the
+// keys given by "key" query parameters, and any of the unique keys given by
+// "unique-key" query parameters.
+//
+// The document's revision is used as its ETag. An "If-Match" header on a PUT or
+// DELETE request is used as the revision of the document being modified, and
+// the request fails with "412 Precondition Failed" if it is not the current
+// revision. Otherwise, a PUT request uses the revision in the request body, or
+// 0 if the request has an "If-None-Match: *" header, and a DELETE request
+// deletes the document regardless of its revision.
+//
+// A PUT request that would violate a unique key fails with "409 Conflict".
+type Handler struct {
+	// DB is the database that contains the documents.
+	DB *protavo.DB
+
+	// Types resolves the type URLs of document content. If it is nil, the
+	// types registered with the protocol buffers package are used.
+	Types jsonpb.AnyResolver
+}
+
+// ServeHTTP handles a request to the REST API.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ns, id, ok := parsePath(r.URL)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	db := h.DB.Namespace(ns)
+
+	if id == "" {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		h.query(w, r, db)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.get(w, r, db, id)
+	case http.MethodPut:
+		h.put(w, r, db, id)
+	case http.MethodDelete:
+		h.delete(w, r, db, id)
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// get responds with the document with the given ID.
+func (h *Handler) get(w http.ResponseWriter, r *http.Request, db *protavo.DB, id string) {
+	doc, ok, err := db.Load(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	} else if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	tag := formatETag(doc.Revision)
+	w.Header().Set("ETag", tag)
+
+	if r.Header.Get("If-None-Match") == tag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	h.writeDocument(w, http.StatusOK, doc)
+}
+
+// put creates or updates the document with the given ID, and responds with the
+// saved document.
+func (h *Handler) put(w http.ResponseWriter, r *http.Request, db *protavo.DB, id string) {
+	resolver := h.resolver()
+	u := jsonpb.Unmarshaler{AnyResolver: resolver}
+
+	var m Document
+	if err := u.Unmarshal(r.Body, &m); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if m.Id != "" && m.Id != id {
+		http.Error(w, "the document ID does not match the URL", http.StatusBadRequest)
+		return
+	}
+
+	m.Id = id
+
+	if tag := r.Header.Get("If-Match"); tag != "" {
+		rev, ok := parseETag(tag)
+		if !ok {
+			http.Error(w, "invalid If-Match header", http.StatusBadRequest)
+			return
+		}
+
+		m.Revision = rev
+	} else if r.Header.Get("If-None-Match") == "*" {
+		m.Revision = 0
+	}
+
+	doc, err := unmarshalDocument(resolver, &m)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := db.Save(r.Context(), doc); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if doc.Revision == 1 {
+		status = http.StatusCreated
+	}
+
+	w.Header().Set("ETag", formatETag(doc.Revision))
+	h.writeDocument(w, status, doc)
+}
+
+// delete deletes the document with the given ID.
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request, db *protavo.DB, id string) {
+	if tag := r.Header.Get("If-Match"); tag != "" {
+		rev, ok := parseETag(tag)
+		if !ok {
+			http.Error(w, "invalid If-Match header", http.StatusBadRequest)
+			return
+		}
+
+		if err := db.Delete(
+			r.Context(),
+			&document.Document{ID: id, Revision: rev},
+		); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	ids, err := db.DeleteByID(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	} else if len(ids) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// query responds with the documents that match the filter described by the
+// request's query parameters.
+func (h *Handler) query(w http.ResponseWriter, r *http.Request, db *protavo.DB) {
+	var conds []filter.Condition
+
+	q := r.URL.Query()
+
+	if keys := q["key"]; len(keys) != 0 {
+		conds = append(conds, protavo.HasKeys(keys...))
+	}
+
+	if keys := q["unique-key"]; len(keys) != 0 {
+		conds = append(conds, protavo.HasUniqueKeyIn(keys...))
+	}
+
+	var (
+		docs []*document.Document
+		err  error
+	)
+
+	if len(conds) == 0 {
+		docs, err = db.LoadAll(r.Context())
+	} else {
+		docs, err = db.LoadManyWhere(r.Context(), conds...)
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	list := &DocumentList{
+		Documents: make([]*Document, len(docs)),
+	}
+
+	for i, doc := range docs {
+		if list.Documents[i], err = marshalDocument(doc); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	h.writeMessage(w, http.StatusOK, list)
+}
+
+// writeDocument writes the JSON representation of doc to w.
+func (h *Handler) writeDocument(w http.ResponseWriter, status int, doc *document.Document) {
+	m, err := marshalDocument(doc)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.writeMessage(w, status, m)
+}
+
+// writeMessage writes the JSON representation of m to w.
+func (h *Handler) writeMessage(w http.ResponseWriter, status int, m proto.Message) {
+	j := jsonpb.Marshaler{AnyResolver: h.resolver()}
+
+	s, err := j.MarshalToString(m)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(s))
+}
+
+// resolver returns the resolver used to find content types.
+func (h *Handler) resolver() jsonpb.AnyResolver {
+	if h.Types == nil {
+		return globalRegistry{}
+	}
+
+	return h.Types
+}
+
+// writeError writes an error response with a status code that describes err.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	if protavo.IsOptimisticLockError(err) {
+		status = http.StatusPreconditionFailed
+	} else if protavo.IsDuplicateKeyError(err) {
+		status = http.StatusConflict
+	} else if protavo.IsNotFoundError(err) || protavo.IsNamespaceNotFoundError(err) {
+		status = http.StatusNotFound
+	} else if protavo.IsInvalidDocumentError(err) {
+		status = http.StatusBadRequest
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+// parsePath returns the namespace and document ID from u. id is empty if u
+// refers to the namespace itself.
+func parsePath(u *url.URL) (ns, id string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(u.EscapedPath(), "/"), "/")
+	if len(parts) != 2 {
+		return "", "", false
+	}
+
+	var err error
+
+	ns, err = url.PathUnescape(parts[0])
+	if err != nil || ns == "" {
+		return "", "", false
+	}
+
+	id, err = url.PathUnescape(parts[1])
+	if err != nil {
+		return "", "", false
+	}
+
+	return ns, id, true
+}
+
+// formatETag returns the ETag for the given document revision.
+func formatETag(rev uint64) string {
+	return fmt.Sprintf(`"%d"`, rev)
+}
+
+// parseETag returns the document revision represented by the given ETag.
+func parseETag(tag string) (uint64, bool) {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+
+	rev, err := strconv.ParseUint(tag[1:len(tag)-1], 10, 64)
+	return rev, err == nil
+}
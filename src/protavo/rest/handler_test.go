@@ -0,0 +1,244 @@
+package rest_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	. "github.com/jmalloc/protavo/src/protavo/rest"
+	"github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+var _ = g.Describe("Handler", func() {
+	var (
+		ctx     = context.Background()
+		db      *protavo.DB
+		ns      *protavo.DB
+		handler *Handler
+		server  *httptest.Server
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		db, err = protavobolt.OpenTemp(0600, nil)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		ns = db.Namespace("ns")
+		handler = &Handler{DB: db}
+		server = httptest.NewServer(handler)
+
+		err = ns.Save(
+			ctx,
+			&document.Document{
+				ID: "doc-1",
+				Keys: document.Keys{
+					"key-a": document.SharedKey,
+					"key-u": document.UniqueKey,
+				},
+				Headers: document.Headers{"h": "v"},
+				Content: document.StringContent("content-1"),
+			},
+			&document.Document{
+				ID:      "doc-2",
+				Keys:    document.SharedKeys("key-a", "key-b"),
+				Content: document.StringContent("content-2"),
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.AfterEach(func() {
+		server.Close()
+		_ = db.Close()
+	})
+
+	do := func(method, path, body string, headers ...string) (*http.Response, string) {
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		for i := 0; i < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer res.Body.Close()
+
+		data, err := ioutil.ReadAll(res.Body)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		return res, string(data)
+	}
+
+	content := func(s string) string {
+		return `{"@type": "` + document.TypeURL(document.StringContent("")) + `", "value": "` + s + `"}`
+	}
+
+	g.Describe("GET /<namespace>/<id>", func() {
+		g.It("responds with the document", func() {
+			res, body := do("GET", "/ns/doc-1", "")
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusOK))
+			m.Expect(res.Header.Get("Content-Type")).To(m.Equal("application/json"))
+			m.Expect(res.Header.Get("ETag")).To(m.Equal(`"1"`))
+			m.Expect(body).To(m.ContainSubstring(`"id":"doc-1"`))
+			m.Expect(body).To(m.ContainSubstring(`"uniqueKeys":["key-u"]`))
+			m.Expect(body).To(m.ContainSubstring(`"sharedKeys":["key-a"]`))
+			m.Expect(body).To(m.ContainSubstring(`"headers":{"h":"v"}`))
+			m.Expect(body).To(m.ContainSubstring(`"value":"content-1"`))
+		})
+
+		g.It("responds with 304 if the ETag matches", func() {
+			res, _ := do("GET", "/ns/doc-1", "", "If-None-Match", `"1"`)
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusNotModified))
+		})
+
+		g.It("responds with 404 if the document does not exist", func() {
+			res, _ := do("GET", "/ns/doc-x", "")
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusNotFound))
+		})
+
+		g.It("responds with 404 if the document is in another namespace", func() {
+			res, _ := do("GET", "/other/doc-1", "")
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusNotFound))
+		})
+
+		g.It("unescapes the document ID", func() {
+			err := ns.Save(ctx, &document.Document{
+				ID:      "a/b",
+				Content: document.StringContent("<content>"),
+			})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			res, _ := do("GET", "/ns/a%2Fb", "")
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusOK))
+		})
+	})
+
+	g.Describe("PUT /<namespace>/<id>", func() {
+		g.It("creates a new document", func() {
+			res, body := do(
+				"PUT",
+				"/ns/doc-3",
+				`{"sharedKeys": ["key-c"], "content": `+content("content-3")+`}`,
+			)
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusCreated))
+			m.Expect(res.Header.Get("ETag")).To(m.Equal(`"1"`))
+			m.Expect(body).To(m.ContainSubstring(`"revision":"1"`))
+
+			doc, ok, err := ns.Load(ctx, "doc-3")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+			m.Expect(doc.Keys).To(m.Equal(document.SharedKeys("key-c")))
+			m.Expect(doc.Content).To(m.Equal(document.StringContent("content-3")))
+		})
+
+		g.It("updates the document if the If-Match header matches the current revision", func() {
+			res, _ := do("PUT", "/ns/doc-1", `{"content": `+content("updated")+`}`, "If-Match", `"1"`)
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusOK))
+			m.Expect(res.Header.Get("ETag")).To(m.Equal(`"2"`))
+
+			doc, _, err := ns.Load(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(doc.Content).To(m.Equal(document.StringContent("updated")))
+		})
+
+		g.It("uses the revision in the body if there is no If-Match header", func() {
+			res, _ := do("PUT", "/ns/doc-1", `{"revision": "1", "content": `+content("updated")+`}`)
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusOK))
+		})
+
+		g.It("responds with 412 if the If-Match header does not match the current revision", func() {
+			res, _ := do("PUT", "/ns/doc-1", `{"content": `+content("updated")+`}`, "If-Match", `"2"`)
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusPreconditionFailed))
+		})
+
+		g.It("responds with 412 if the document exists and If-None-Match is '*'", func() {
+			res, _ := do("PUT", "/ns/doc-1", `{"revision": "1", "content": `+content("updated")+`}`, "If-None-Match", "*")
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusPreconditionFailed))
+		})
+
+		g.It("responds with 409 if a unique key conflicts with another document", func() {
+			res, _ := do("PUT", "/ns/doc-3", `{"uniqueKeys": ["key-u"], "content": `+content("content-3")+`}`)
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusConflict))
+		})
+
+		g.It("responds with 400 if the ID in the body does not match the URL", func() {
+			res, _ := do("PUT", "/ns/doc-3", `{"id": "doc-4", "content": `+content("content-3")+`}`)
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusBadRequest))
+		})
+
+		g.It("responds with 400 if the content type can not be resolved", func() {
+			handler.Types = &Registry{}
+
+			res, _ := do("PUT", "/ns/doc-3", `{"content": `+content("content-3")+`}`)
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusBadRequest))
+		})
+
+		g.It("accepts content types in the registry", func() {
+			r := &Registry{}
+			r.Add(document.StringContent(""))
+			handler.Types = r
+
+			res, _ := do("PUT", "/ns/doc-3", `{"content": `+content("content-3")+`}`)
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusCreated))
+		})
+	})
+
+	g.Describe("DELETE /<namespace>/<id>", func() {
+		g.It("deletes the document", func() {
+			res, _ := do("DELETE", "/ns/doc-1", "")
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusNoContent))
+
+			_, ok, err := ns.Load(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeFalse())
+		})
+
+		g.It("deletes the document if the If-Match header matches the current revision", func() {
+			res, _ := do("DELETE", "/ns/doc-1", "", "If-Match", `"1"`)
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusNoContent))
+		})
+
+		g.It("responds with 412 if the If-Match header does not match the current revision", func() {
+			res, _ := do("DELETE", "/ns/doc-1", "", "If-Match", `"2"`)
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusPreconditionFailed))
+		})
+
+		g.It("responds with 404 if the document does not exist", func() {
+			res, _ := do("DELETE", "/ns/doc-x", "")
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusNotFound))
+		})
+	})
+
+	g.Describe("GET /<namespace>/", func() {
+		g.It("responds with all documents in the namespace", func() {
+			res, body := do("GET", "/ns/", "")
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusOK))
+			m.Expect(body).To(m.ContainSubstring(`"id":"doc-1"`))
+			m.Expect(body).To(m.ContainSubstring(`"id":"doc-2"`))
+		})
+
+		g.It("responds with the documents that have all of the given keys", func() {
+			_, body := do("GET", "/ns/?key=key-a&key=key-b", "")
+			m.Expect(body).NotTo(m.ContainSubstring(`"id":"doc-1"`))
+			m.Expect(body).To(m.ContainSubstring(`"id":"doc-2"`))
+		})
+
+		g.It("responds with the documents that have any of the given unique keys", func() {
+			_, body := do("GET", "/ns/?unique-key=key-u&unique-key=key-x", "")
+			m.Expect(body).To(m.ContainSubstring(`"id":"doc-1"`))
+			m.Expect(body).NotTo(m.ContainSubstring(`"id":"doc-2"`))
+		})
+
+		g.It("responds with 405 for other methods", func() {
+			res, _ := do("POST", "/ns/", "")
+			m.Expect(res.StatusCode).To(m.Equal(http.StatusMethodNotAllowed))
+		})
+	})
+})
@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/jmalloc/protavo/src/protavo/document"
+)
+
+// Registry is a jsonpb.AnyResolver that resolves the type URLs of a specific
+// set of message types.
+//
+// It can be used to restrict the content types that Handler accepts, or to
+// make types that are not registered with the protocol buffers package
+// available to it.
+type Registry struct {
+	types map[string]reflect.Type
+}
+
+// Add adds the types of the given messages to the registry.
+func (r *Registry) Add(messages ...proto.Message) {
+	if r.types == nil {
+		r.types = map[string]reflect.Type{}
+	}
+
+	for _, m := range messages {
+		r.types[document.TypeURL(m)] = reflect.TypeOf(m).Elem()
+	}
+}
+
+// Resolve returns a new message of the type identified by url.
+func (r *Registry) Resolve(url string) (proto.Message, error) {
+	t, ok := r.types[url]
+	if !ok {
+		return nil, errors.New("unknown content type: " + url)
+	}
+
+	return reflect.New(t).Interface().(proto.Message), nil
+}
+
+// globalRegistry is a jsonpb.AnyResolver that resolves type URLs using the
+// types registered with the protocol buffers package.
+type globalRegistry struct{}
+
+// Resolve returns a new message of the type identified by url.
+func (globalRegistry) Resolve(url string) (proto.Message, error) {
+	name := url[strings.LastIndex(url, "/")+1:]
+
+	t := proto.MessageType(name)
+	if t == nil {
+		return nil, errors.New("unknown content type: " + url)
+	}
+
+	return reflect.New(t.Elem()).Interface().(proto.Message), nil
+}
+
+// marshalDocument converts a document from the public API to its JSON
+// representation.
+func marshalDocument(doc *document.Document) (*Document, error) {
+	m := &Document{
+		Id:         doc.ID,
+		UniqueKeys: doc.UniqueKeys(),
+		SharedKeys: doc.SharedKeys(),
+		Headers:    doc.Headers,
+		Revision:   doc.Revision,
+	}
+
+	sort.Strings(m.UniqueKeys)
+	sort.Strings(m.SharedKeys)
+
+	var err error
+
+	if m.Content, err = ptypes.MarshalAny(doc.Content); err != nil {
+		return nil, err
+	}
+
+	if m.CreatedAt, err = ptypes.TimestampProto(doc.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	m.UpdatedAt, err = ptypes.TimestampProto(doc.UpdatedAt)
+	return m, err
+}
+
+// unmarshalDocument converts a document from its JSON representation to the
+// public API.
+//
+// The timestamps are ignored, as they are always set by the driver.
+func unmarshalDocument(r jsonpb.AnyResolver, m *Document) (*document.Document, error) {
+	if m.Content == nil {
+		return nil, errors.New("the document has no content")
+	}
+
+	doc := &document.Document{
+		ID:       m.Id,
+		Headers:  m.Headers,
+		Revision: m.Revision,
+	}
+
+	if len(m.UniqueKeys) != 0 || len(m.SharedKeys) != 0 {
+		doc.Keys = document.Keys{}
+
+		for _, k := range m.SharedKeys {
+			doc.Keys[k] = document.SharedKey
+		}
+
+		for _, k := range m.UniqueKeys {
+			doc.Keys[k] = document.UniqueKey
+		}
+	}
+
+	var err error
+	doc.Content, err = unmarshalContent(r, m.Content)
+
+	return doc, err
+}
+
+// unmarshalContent returns the message contained in c, using r to resolve its
+// type.
+func unmarshalContent(r jsonpb.AnyResolver, c *any.Any) (proto.Message, error) {
+	m, err := r.Resolve(c.TypeUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, proto.Unmarshal(c.Value, m)
+}
@@ -0,0 +1,4 @@
+// Package rest provides an HTTP handler that exposes a Protavo database as a
+// JSON-based REST API, for use by administrative scripts and by consumers that
+// are not written in Go.
+package rest
@@ -0,0 +1,189 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: src/protavo/rest/rest.proto
+
+package rest
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import any "github.com/golang/protobuf/ptypes/any"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Document is the JSON representation of a document.
+type Document struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UniqueKeys           []string             `protobuf:"bytes,2,rep,name=unique_keys,json=uniqueKeys,proto3" json:"unique_keys,omitempty"`
+	SharedKeys           []string             `protobuf:"bytes,3,rep,name=shared_keys,json=sharedKeys,proto3" json:"shared_keys,omitempty"`
+	Headers              map[string]string    `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Content              *any.Any             `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Revision             uint64               `protobuf:"varint,6,opt,name=revision,proto3" json:"revision,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Document) Reset()         { *m = Document{} }
+func (m *Document) String() string { return proto.CompactTextString(m) }
+func (*Document) ProtoMessage()    {}
+func (*Document) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rest_5481085cfc39668a, []int{0}
+}
+func (m *Document) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Document.Unmarshal(m, b)
+}
+func (m *Document) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Document.Marshal(b, m, deterministic)
+}
+func (dst *Document) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Document.Merge(dst, src)
+}
+func (m *Document) XXX_Size() int {
+	return xxx_messageInfo_Document.Size(m)
+}
+func (m *Document) XXX_DiscardUnknown() {
+	xxx_messageInfo_Document.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Document proto.InternalMessageInfo
+
+func (m *Document) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Document) GetUniqueKeys() []string {
+	if m != nil {
+		return m.UniqueKeys
+	}
+	return nil
+}
+
+func (m *Document) GetSharedKeys() []string {
+	if m != nil {
+		return m.SharedKeys
+	}
+	return nil
+}
+
+func (m *Document) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *Document) GetContent() *any.Any {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *Document) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *Document) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Document) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+// DocumentList is the JSON representation of the result of a query.
+type DocumentList struct {
+	Documents            []*Document `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *DocumentList) Reset()         { *m = DocumentList{} }
+func (m *DocumentList) String() string { return proto.CompactTextString(m) }
+func (*DocumentList) ProtoMessage()    {}
+func (*DocumentList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rest_5481085cfc39668a, []int{1}
+}
+func (m *DocumentList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DocumentList.Unmarshal(m, b)
+}
+func (m *DocumentList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DocumentList.Marshal(b, m, deterministic)
+}
+func (dst *DocumentList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DocumentList.Merge(dst, src)
+}
+func (m *DocumentList) XXX_Size() int {
+	return xxx_messageInfo_DocumentList.Size(m)
+}
+func (m *DocumentList) XXX_DiscardUnknown() {
+	xxx_messageInfo_DocumentList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DocumentList proto.InternalMessageInfo
+
+func (m *DocumentList) GetDocuments() []*Document {
+	if m != nil {
+		return m.Documents
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Document)(nil), "protavo.rest.Document")
+	proto.RegisterMapType((map[string]string)(nil), "protavo.rest.Document.HeadersEntry")
+	proto.RegisterType((*DocumentList)(nil), "protavo.rest.DocumentList")
+}
+
+func init() { proto.RegisterFile("src/protavo/rest/rest.proto", fileDescriptor_rest_5481085cfc39668a) }
+
+var fileDescriptor_rest_5481085cfc39668a = []byte{
+	// 350 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0x4f, 0x4b, 0xf3, 0x40,
+	0x10, 0xc6, 0x49, 0xd2, 0x7f, 0x99, 0x96, 0x97, 0x97, 0xa5, 0xbc, 0xec, 0x1b, 0x0f, 0x0d, 0xf5,
+	0x92, 0xd3, 0x16, 0xaa, 0x07, 0x2d, 0x78, 0xa8, 0x54, 0x10, 0xf4, 0x14, 0x3c, 0x79, 0x29, 0xdb,
+	0x64, 0x6c, 0x43, 0xdb, 0xdd, 0xba, 0xbb, 0x29, 0xe4, 0xa3, 0xf8, 0x6d, 0x25, 0xd9, 0x44, 0x8b,
+	0x0a, 0x5e, 0x42, 0xe6, 0x99, 0xdf, 0x33, 0x3b, 0xf3, 0xc0, 0x99, 0x56, 0xc9, 0xe4, 0xa0, 0xa4,
+	0xe1, 0x47, 0x39, 0x51, 0xa8, 0x4d, 0xf5, 0x61, 0xa5, 0x22, 0xc9, 0xa0, 0x6e, 0xb0, 0x52, 0x0b,
+	0xfe, 0xaf, 0xa5, 0x5c, 0xef, 0xb0, 0xa2, 0xe5, 0x2a, 0x7f, 0x99, 0x70, 0x51, 0x58, 0x30, 0x18,
+	0x7d, 0x6d, 0x99, 0x6c, 0x8f, 0xda, 0xf0, 0xfd, 0xc1, 0x02, 0xe3, 0x37, 0x0f, 0x7a, 0x0b, 0x99,
+	0xe4, 0x7b, 0x14, 0x86, 0xfc, 0x01, 0x37, 0x4b, 0xa9, 0x13, 0x3a, 0x91, 0x1f, 0xbb, 0x59, 0x4a,
+	0x46, 0xd0, 0xcf, 0x45, 0xf6, 0x9a, 0xe3, 0x72, 0x8b, 0x85, 0xa6, 0x6e, 0xe8, 0x45, 0x7e, 0x0c,
+	0x56, 0x7a, 0xc0, 0x42, 0x97, 0x80, 0xde, 0x70, 0x85, 0xa9, 0x05, 0x3c, 0x0b, 0x58, 0xa9, 0x02,
+	0x6e, 0xa0, 0xbb, 0x41, 0x9e, 0xa2, 0xd2, 0xb4, 0x15, 0x7a, 0x51, 0x7f, 0x7a, 0xce, 0x4e, 0x57,
+	0x67, 0xcd, 0xd3, 0xec, 0xde, 0x52, 0x77, 0xc2, 0xa8, 0x22, 0x6e, 0x3c, 0x84, 0x41, 0x37, 0x91,
+	0xc2, 0xa0, 0x30, 0xb4, 0x1d, 0x3a, 0x51, 0x7f, 0x3a, 0x64, 0xf6, 0x20, 0xd6, 0x1c, 0xc4, 0xe6,
+	0xa2, 0x88, 0x1b, 0x88, 0x04, 0xd0, 0x53, 0x78, 0xcc, 0x74, 0x26, 0x05, 0xed, 0x84, 0x4e, 0xd4,
+	0x8a, 0x3f, 0x6a, 0x72, 0x0d, 0x90, 0x28, 0xe4, 0x06, 0xd3, 0x25, 0x37, 0xb4, 0x5b, 0x8d, 0x0b,
+	0xbe, 0x8d, 0x7b, 0x6a, 0xf2, 0x89, 0xfd, 0x9a, 0x9e, 0x9b, 0xd2, 0x9a, 0x1f, 0xd2, 0xc6, 0xda,
+	0xfb, 0xdd, 0x5a, 0xd3, 0x73, 0x13, 0xcc, 0x60, 0x70, 0x7a, 0x1a, 0xf9, 0x0b, 0xde, 0x16, 0x8b,
+	0x3a, 0xe3, 0xf2, 0x97, 0x0c, 0xa1, 0x7d, 0xe4, 0xbb, 0x1c, 0xa9, 0x5b, 0x69, 0xb6, 0x98, 0xb9,
+	0x57, 0xce, 0x78, 0x01, 0x83, 0x26, 0x9f, 0xc7, 0x4c, 0x1b, 0x72, 0x09, 0x7e, 0x5a, 0xd7, 0x9a,
+	0x3a, 0x55, 0x9c, 0xff, 0x7e, 0x8e, 0x33, 0xfe, 0x04, 0x6f, 0x3b, 0xcf, 0xad, 0xb2, 0xb7, 0xea,
+	0x54, 0x8b, 0x5e, 0xbc, 0x0f, 0x00, 0x4c, 0x08, 0x8d, 0x64, 0x59, 0x02, 0x00, 0x00,
+}
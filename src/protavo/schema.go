@@ -0,0 +1,63 @@
+package protavo
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+)
+
+// prepare validates the documents saved by ops against s, and sets the keys
+// that are derived from their content, before the operations are executed.
+//
+// Operations within an Attempt() are prepared along with the others. It
+// returns the error for the first invalid document, if any.
+func prepare(s *document.Schema, ops []driver.Operation) error {
+	for _, op := range ops {
+		switch op := op.(type) {
+		case *driver.Save:
+			keys, err := s.Prepare(op.Document)
+			if err != nil {
+				return err
+			}
+
+			setDerivedKeys(s, op, keys)
+		case *driver.Attempt:
+			if err := prepare(s, op.Operations); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// schemaTx is a driver.WriteTx that applies a schema to each document that is
+// saved directly within a transaction started by DB.BeginWrite().
+type schemaTx struct {
+	driver.WriteTx
+
+	schema *document.Schema
+}
+
+func (tx *schemaTx) Save(ctx context.Context, op *driver.Save) {
+	keys, err := tx.schema.Prepare(op.Document)
+	if err != nil {
+		op.MarkExecuted(err)
+		return
+	}
+
+	setDerivedKeys(tx.schema, op, keys)
+
+	tx.WriteTx.Save(ctx, op)
+}
+
+// setDerivedKeys sets the derived keys of op to keys, if there is a schema.
+//
+// Without a schema, any derived keys that are already set on op are retained,
+// such that they can be supplied directly.
+func setDerivedKeys(s *document.Schema, op *driver.Save, keys document.Keys) {
+	if s != nil {
+		op.DerivedKeys = keys
+	}
+}
@@ -0,0 +1,168 @@
+package protavo_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+
+	bolt "github.com/coreos/bbolt"
+	. "github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+var _ = g.Describe("WithSchema", func() {
+	var (
+		ctx    = context.Background()
+		dir    string
+		schema *document.Schema
+		doc    *document.Document
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "protavo-")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		schema = (&document.Schema{}).
+			AddHeaderConstraint("author", document.Constraint{MaxLength: 5})
+
+		doc = &document.Document{
+			ID:      "doc-1",
+			Headers: document.Headers{"author": "Alice Jones"},
+			Content: document.StringContent("<content>"),
+		}
+	})
+
+	g.AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	expectInvalid := func(err error) {
+		m.Expect(err).To(m.Equal(
+			&document.ValidationError{
+				DocumentID: "doc-1",
+				Violations: []string{"header 'author' is longer than 5 bytes"},
+			},
+		))
+	}
+
+	for _, batch := range []bool{false, true} {
+		batch := batch // capture loop variable
+
+		desc := "without batched writes"
+		if batch {
+			desc = "with batched writes"
+		}
+
+		g.Context(desc, func() {
+			var db *DB
+
+			g.BeforeEach(func() {
+				var err error
+				db, err = openBolt(dir, batch, WithSchema(schema))
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+
+			g.AfterEach(func() {
+				_ = db.Close()
+			})
+
+			g.It("rejects documents that are not valid", func() {
+				err := db.Save(ctx, doc)
+				expectInvalid(err)
+
+				_, ok, err := db.Load(ctx, "doc-1")
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(ok).To(m.BeFalse())
+			})
+
+			g.It("rejects documents saved within a namespace", func() {
+				err := db.Namespace("ns").Save(ctx, doc)
+				expectInvalid(err)
+			})
+
+			g.It("rejects documents saved within an Attempt()", func() {
+				err := db.Write(ctx, Attempt(nil, Save(doc)))
+				expectInvalid(err)
+			})
+
+			g.It("rejects documents saved within a transaction", func() {
+				tx, err := db.BeginWrite(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				defer tx.Close()
+
+				op := &driver.Save{Document: doc}
+				op.ExecuteInWriteTx(ctx, tx)
+				expectInvalid(op.Err())
+			})
+
+			g.It("validates documents before they are passed to before-save hooks", func() {
+				called := false
+				db.BeforeSave(
+					func(_ context.Context, _ driver.WriteTx, doc *document.Document) error {
+						called = true
+						return nil
+					},
+				)
+
+				err := db.Save(ctx, doc)
+				expectInvalid(err)
+				m.Expect(called).To(m.BeFalse())
+			})
+
+			g.It("reports the violations of the schema along with those of document.Validate()", func() {
+				doc.Content = nil
+
+				err := db.Save(ctx, doc)
+				m.Expect(err).To(m.Equal(
+					&document.ValidationError{
+						DocumentID: "doc-1",
+						Violations: []string{
+							"document content must not be nil",
+							"header 'author' is longer than 5 bytes",
+						},
+					},
+				))
+			})
+
+			g.It("does not affect other databases", func() {
+				_ = db.Close()
+
+				var err error
+				db, err = openBolt(dir, batch)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+
+				err = db.Save(ctx, doc)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			})
+		})
+	}
+})
+
+// openBolt opens a DB that uses a BoltDB file within dir.
+func openBolt(dir string, batch bool, opts ...Option) (*DB, error) {
+	d, err := newBoltDriver(dir, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDB(d, opts...)
+}
+
+// newBoltDriver returns a BoltDB driver that uses a file within dir.
+func newBoltDriver(dir string, batch bool) (*protavobolt.ExclusiveDriver, error) {
+	bdb, err := bolt.Open(path.Join(dir, "bolt.db"), 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protavobolt.ExclusiveDriver{
+		DB:          bdb,
+		BatchWrites: batch,
+	}, nil
+}
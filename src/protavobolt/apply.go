@@ -0,0 +1,98 @@
+package protavobolt
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// executeApplyChange applies a change obtained from another database.
+func executeApplyChange(
+	ctx context.Context,
+	tx *database.Tx,
+	ns string,
+	c *driver.Change,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if c.IsDeleted() {
+		s, ok, err := database.OpenStore(tx, ns)
+		if !ok || err != nil {
+			return err
+		}
+
+		rec, exists, err := s.TryGetRecord(c.DocumentID)
+		if !exists || err != nil {
+			return err
+		}
+
+		return applyDelete(s, c.DocumentID, rec, true, nil)
+	}
+
+	s, err := database.CreateStore(tx, ns)
+	if err != nil {
+		return err
+	}
+
+	rec, exists, err := s.TryGetRecord(c.DocumentID)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		if _, err := purgeDeletedDocument(s, c.DocumentID); err != nil {
+			return err
+		}
+	}
+
+	new, err := newReplicaRecord(s, c.Document)
+	if err != nil {
+		return err
+	}
+
+	if err := putRecord(s, c.DocumentID, rec, new); err != nil {
+		return err
+	}
+
+	return putContent(s, c.Document)
+}
+
+// newReplicaRecord returns a record for a document that is a replica of doc,
+// preserving its revision, timestamps and derived keys.
+func newReplicaRecord(
+	s *database.Store,
+	doc *document.Document,
+) (*database.Record, error) {
+	createdAt, err := ptypes.TimestampProto(doc.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt, err := ptypes.TimestampProto(doc.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	seq, err := s.NextSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &database.Record{
+		Revision:    doc.Revision,
+		Headers:     s.IndexedHeaders(doc.Headers),
+		ContentType: document.TypeURL(doc.Content),
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		Sequence:    seq,
+	}
+
+	rec.Keys, rec.DerivedKeys = marshalRecordKeys(doc.Keys, doc.DerivedKeys)
+
+	return rec, nil
+}
@@ -0,0 +1,138 @@
+package protavobolt
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// executePutAttachment stores an attachment of an existing document.
+func executePutAttachment(
+	ctx context.Context,
+	tx *database.Tx,
+	ns string,
+	id, name string,
+	r io.Reader,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if name == "" {
+		return fmt.Errorf(
+			"cannot attach to '%s', the attachment name is empty",
+			id,
+		)
+	}
+
+	s, ok, err := database.OpenStore(tx, ns)
+	if err != nil {
+		return err
+	}
+
+	op := fmt.Sprintf("attach '%s' to", name)
+
+	if !ok {
+		return &protavo.NamespaceNotFoundError{
+			Namespace: ns,
+			Operation: op,
+		}
+	}
+
+	_, ok, err = s.TryGetRecord(id)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return &protavo.NotFoundError{
+			DocumentID: id,
+			Operation:  op,
+		}
+	}
+
+	return s.PutAttachment(id, name, r)
+}
+
+// executeGetAttachment writes the content of an attachment to w.
+func executeGetAttachment(
+	ctx context.Context,
+	tx *database.Tx,
+	ns string,
+	id, name string,
+	w io.Writer,
+) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	s, ok, err := database.OpenStore(tx, ns)
+	if !ok || err != nil {
+		return false, err
+	}
+
+	// the attachments of soft-deleted documents are retained, but hidden until
+	// the document is restored
+	if s.IsDeleted(id) {
+		return false, nil
+	}
+
+	return s.GetAttachment(id, name, w)
+}
+
+// executeListAttachments returns the attachments of a document.
+func executeListAttachments(
+	ctx context.Context,
+	tx *database.Tx,
+	ns string,
+	id string,
+) ([]driver.Attachment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s, ok, err := database.OpenStore(tx, ns)
+	if !ok || err != nil || s.IsDeleted(id) {
+		return nil, err
+	}
+
+	var attachments []driver.Attachment
+
+	return attachments, s.ListAttachments(
+		id,
+		func(name string, size uint64) error {
+			attachments = append(
+				attachments,
+				driver.Attachment{
+					Name: name,
+					Size: int64(size),
+				},
+			)
+
+			return nil
+		},
+	)
+}
+
+// executeDeleteAttachment deletes an attachment of a document.
+func executeDeleteAttachment(
+	ctx context.Context,
+	tx *database.Tx,
+	ns string,
+	id, name string,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s, ok, err := database.OpenStore(tx, ns)
+	if !ok || err != nil || s.IsDeleted(id) {
+		return err
+	}
+
+	return s.DeleteAttachment(id, name)
+}
@@ -0,0 +1,251 @@
+package protavobolt
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// Write atomically executes a set of read/write operations.
+//
+// If d.BatchWrites is true, concurrent calls are coalesced into a single
+// BoltDB transaction, otherwise each call uses its own transaction.
+//
+// If ctx is canceled, or its deadline is exceeded, before the batch begins to
+// execute the caller's operations, Write() returns immediately and the
+// operations are never executed. Once execution has begun, Write() waits for
+// the result, as the operations may have been committed.
+func (d *ExclusiveDriver) Write(
+	ctx context.Context,
+	ns string,
+	ops []driver.Operation,
+) error {
+	if !d.BatchWrites {
+		return driver.ExecuteWrite(ctx, d, ns, ops)
+	}
+
+	if err := d.init(ctx); err != nil {
+		return err
+	}
+
+	c := &batchCall{
+		ctx:    ctx,
+		ns:     ns,
+		ops:    ops,
+		result: make(chan batchResult, 1),
+	}
+
+	d.batchMutex.Lock()
+	d.pending = append(d.pending, c)
+	if !d.batching {
+		d.batching = true
+		go d.runBatches()
+	}
+	d.batchMutex.Unlock()
+
+	var r batchResult
+
+	select {
+	case r = <-c.result:
+	case <-ctx.Done():
+		if c.abandon() {
+			return ctx.Err()
+		}
+
+		r = <-c.result
+	}
+
+	if r.panicValue != nil {
+		panic(r.panicValue)
+	}
+
+	return r.err
+}
+
+// batchCall is a single call to ExclusiveDriver.Write() that is executed as
+// part of a batch.
+type batchCall struct {
+	ctx    context.Context
+	ns     string
+	ops    []driver.Operation
+	result chan batchResult
+
+	// state is one of the batchCallXXX constants. It must be accessed
+	// atomically.
+	state int32
+}
+
+const (
+	// batchCallPending is the state of a call that has not been executed.
+	batchCallPending int32 = iota
+
+	// batchCallStarted is the state of a call that is being executed by a
+	// batch.
+	batchCallStarted
+
+	// batchCallAbandoned is the state of a call that was abandoned by the
+	// caller before it was executed. It is never executed.
+	batchCallAbandoned
+)
+
+// start marks the call as being executed. It returns false if the call has
+// been abandoned.
+func (c *batchCall) start() bool {
+	return atomic.CompareAndSwapInt32(&c.state, batchCallPending, batchCallStarted)
+}
+
+// abandon marks the call as abandoned. It returns false if the call has
+// already started.
+func (c *batchCall) abandon() bool {
+	return atomic.CompareAndSwapInt32(&c.state, batchCallPending, batchCallAbandoned)
+}
+
+// batchResult is the result of a batchCall.
+type batchResult struct {
+	err        error
+	panicValue interface{}
+}
+
+// runBatches executes the pending calls in batches until there are none left.
+//
+// Calls that are made while a batch is being executed are queued, and executed
+// together in the next batch once the previous batch has been committed. There
+// is no delay before the first batch is executed, so an uncontended writer is
+// never slowed by batching.
+func (d *ExclusiveDriver) runBatches() {
+	for {
+		d.batchMutex.Lock()
+
+		calls := d.pending
+		if n := d.DB.MaxBatchSize; n > 0 && len(calls) > n {
+			calls = calls[:n]
+			d.pending = d.pending[n:]
+		} else {
+			d.pending = nil
+		}
+
+		if len(calls) == 0 {
+			d.batching = false
+			d.batchMutex.Unlock()
+			return
+		}
+
+		d.batchMutex.Unlock()
+
+		for len(calls) > 0 {
+			calls = d.execBatch(calls)
+		}
+	}
+}
+
+// execBatch executes calls within a single transaction.
+//
+// Each call is executed within its own savepoint, such that a failure only
+// undoes the changes made by that call. If the transaction can not be used for
+// the remaining calls, they are returned so that they can be retried in a new
+// transaction.
+//
+// Calls that are abandoned by their callers while waiting for the transaction
+// to begin, or for earlier calls to execute, are skipped.
+func (d *ExclusiveDriver) execBatch(calls []*batchCall) []*batchCall {
+	btx, err := d.DB.Begin(true)
+	if err != nil {
+		fail(calls, err)
+		return nil
+	}
+
+	tx := d.newTx(btx)
+
+	for i, c := range calls {
+		if !c.start() {
+			calls[i] = nil
+			continue
+		}
+
+		r, ok := execBatchCall(tx, c)
+
+		if !ok {
+			// the changes made by c could not be undone, so the transaction can
+			// not be committed. c and the calls before it have already been
+			// executed, so they can not be retried.
+			_ = btx.Rollback()
+			c.result <- r
+			fail(calls[:i], r.err)
+			return calls[i+1:]
+		}
+
+		if r.err != nil || r.panicValue != nil {
+			c.result <- r
+			calls[i] = nil
+		}
+	}
+
+	err = btx.Commit()
+
+	for _, c := range calls {
+		if c != nil {
+			c.result <- batchResult{err: err}
+		}
+	}
+
+	return nil
+}
+
+// execBatchCall executes the operations in c within a savepoint of tx.
+//
+// If any of the operations fail, or panic, the changes made by c are undone.
+// It returns false if the changes could not be undone. Otherwise, the savepoint
+// is released, such that the journal does not grow for the rest of the batch.
+func execBatchCall(tx *database.Tx, c *batchCall) (r batchResult, ok bool) {
+	sp := tx.Savepoint()
+
+	defer func() {
+		if v := recover(); v != nil {
+			r, ok = batchResult{panicValue: v}, true
+		}
+
+		if r.err != nil || r.panicValue != nil {
+			if err := tx.RollbackTo(sp); err != nil {
+				r.err = err
+				r.panicValue = nil
+				ok = false
+				return
+			}
+		}
+
+		if err := tx.Release(sp); err != nil {
+			r.err = err
+			r.panicValue = nil
+			ok = false
+		}
+	}()
+
+	if err := c.ctx.Err(); err != nil {
+		return batchResult{err: err}, true
+	}
+
+	wtx := &writeTx{
+		readTx: readTx{c.ns, tx},
+	}
+
+	for _, op := range c.ops {
+		op.ExecuteInWriteTx(c.ctx, wtx)
+
+		if err := op.Err(); err != nil {
+			return batchResult{err: err}, true
+		}
+	}
+
+	return batchResult{}, true
+}
+
+// fail sends err to each of the given calls.
+func fail(calls []*batchCall, err error) {
+	for _, c := range calls {
+		if c != nil {
+			c.result <- batchResult{err: err}
+		}
+	}
+}
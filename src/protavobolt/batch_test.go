@@ -0,0 +1,222 @@
+package protavobolt_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver/drivertest"
+	. "github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+func init() {
+	var dir string
+
+	drivertest.Describe(
+		"protavobolt.ExclusiveDriver (batched writes)",
+		func() (*protavo.DB, error) {
+			var db *protavo.DB
+			var err error
+			dir, db, err = openBatched()
+			return db, err
+		},
+		func() {
+			_ = os.RemoveAll(dir)
+		},
+	)
+}
+
+var _ = g.Describe("ExclusiveDriver (batched writes)", func() {
+	var (
+		ctx = context.Background()
+		dir string
+		db  *protavo.DB
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		dir, db, err = openBatched()
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = db.Save(
+			ctx,
+			&document.Document{
+				ID:      "doc-0",
+				Keys:    document.UniqueKeys("uniq"),
+				Content: document.StringContent("content-0"),
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.AfterEach(func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	// concurrently calls each of the given functions, and waits for them to
+	// return.
+	concurrently := func(fns ...func()) {
+		var wg sync.WaitGroup
+
+		for _, fn := range fns {
+			wg.Add(1)
+
+			go func(fn func()) {
+				defer g.GinkgoRecover()
+				defer wg.Done()
+				fn()
+			}(fn)
+		}
+
+		wg.Wait()
+	}
+
+	// save returns a function that saves a document with the given ID and
+	// unique key, and expects the given result.
+	save := func(id, key string, expectErr bool) func() {
+		return func() {
+			err := db.Save(
+				ctx,
+				&document.Document{
+					ID:      id,
+					Keys:    document.UniqueKeys(key),
+					Content: document.StringContent(id),
+				},
+			)
+
+			if expectErr {
+				m.Expect(protavo.IsDuplicateKeyError(err)).To(m.BeTrue())
+			} else {
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			}
+		}
+	}
+
+	g.It("isolates callers from the failure of other callers in the same batch", func() {
+		var fns []func()
+
+		for i := 1; i <= 5; i++ {
+			fns = append(fns, save(
+				fmt.Sprintf("doc-%d", i),
+				fmt.Sprintf("uniq-%d", i),
+				false,
+			))
+		}
+
+		fns = append(fns, save("doc-6", "uniq", true)) // doc-0 already has this key
+
+		concurrently(fns...)
+
+		docs, err := db.LoadAll(ctx)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(docs).To(m.HaveLen(6))
+
+		_, ok, err := db.Load(ctx, "doc-6")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(ok).To(m.BeFalse())
+	})
+
+	g.It("propagates panics to the caller that caused them", func() {
+		concurrently(
+			save("doc-1", "uniq-1", false),
+			func() {
+				m.Expect(func() {
+					_ = db.Write(
+						ctx,
+						protavo.Save(&document.Document{
+							ID:      "doc-2",
+							Content: document.StringContent("content-2"),
+						}),
+						protavo.FetchAll(
+							func(*document.Document) (bool, error) {
+								panic("<panic>")
+							},
+						),
+					)
+				}).To(m.Panic())
+			},
+		)
+
+		docs, err := db.LoadAll(ctx)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(docs).To(m.HaveLen(2))
+	})
+
+	g.It("returns an error if the context is canceled before the caller's operations are executed", func() {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := db.DeleteNamespace(canceled)
+		m.Expect(err).To(m.Equal(context.Canceled))
+
+		docs, err := db.LoadAll(ctx)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(docs).To(m.HaveLen(1))
+	})
+
+	g.It("returns an error if the deadline is exceeded while waiting for the batch to begin", func() {
+		// hold BoltDB's write lock, so that the batch can not begin
+		tx, err := db.BeginWrite(ctx)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		err = db.Save(
+			timeout,
+			&document.Document{
+				ID:      "doc-1",
+				Content: document.StringContent("content-1"),
+			},
+		)
+		m.Expect(err).To(m.Equal(context.DeadlineExceeded))
+
+		err = tx.Close()
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		// this write is executed after the batch that contains the abandoned
+		// call has completed
+		save("doc-2", "uniq-2", false)()
+
+		_, ok, err := db.Load(ctx, "doc-1")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(ok).To(m.BeFalse())
+	})
+})
+
+// openBatched returns a database that uses a temporary file and batches
+// writes.
+//
+// It returns the temporary directory that contains the file, which must be
+// removed after the database is closed.
+func openBatched() (string, *protavo.DB, error) {
+	dir, err := ioutil.TempDir("", "protavobolt-")
+	if err != nil {
+		return "", nil, err
+	}
+
+	bdb, err := bolt.Open(path.Join(dir, "bolt.db"), 0600, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	db, err := protavo.NewDB(
+		&ExclusiveDriver{
+			DB:          bdb,
+			BatchWrites: true,
+			TextIndex:   drivertest.TextIndex,
+		},
+	)
+
+	return dir, db, err
+}
@@ -0,0 +1,180 @@
+package protavobolt_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync/atomic"
+	"testing"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	. "github.com/jmalloc/protavo/src/protavobolt"
+)
+
+// BenchmarkSave_SharedKey measures the time taken to add a document to a
+// shared key that is already used by many other documents. The time taken
+// should not depend on the number of existing documents.
+func BenchmarkSave_SharedKey(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000, 100000} {
+		b.Run(
+			fmt.Sprintf("members=%d", n),
+			func(b *testing.B) {
+				db, cleanup := openBenchmarkDB(b)
+				defer cleanup()
+
+				populateSharedKey(b, db, "shared", n)
+
+				ctx := context.Background()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					if err := db.Save(
+						ctx,
+						&document.Document{
+							ID:      fmt.Sprintf("new-%d", i),
+							Keys:    document.SharedKeys("shared"),
+							Content: document.StringContent(""),
+						},
+					); err != nil {
+						b.Fatal(err)
+					}
+				}
+			},
+		)
+	}
+}
+
+// BenchmarkDelete_SharedKey measures the time taken to remove a document from
+// a shared key that is used by many other documents. The time taken should not
+// depend on the number of existing documents.
+func BenchmarkDelete_SharedKey(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000, 100000} {
+		b.Run(
+			fmt.Sprintf("members=%d", n),
+			func(b *testing.B) {
+				db, cleanup := openBenchmarkDB(b)
+				defer cleanup()
+
+				populateSharedKey(b, db, "shared", n+b.N)
+
+				ctx := context.Background()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					if _, err := db.DeleteByID(
+						ctx,
+						fmt.Sprintf("doc-%d", i),
+					); err != nil {
+						b.Fatal(err)
+					}
+				}
+			},
+		)
+	}
+}
+
+// openBenchmarkDB returns a database that uses a temporary file, with syncing
+// disabled so that disk performance does not dominate the results.
+//
+// The returned function closes the database and removes the file.
+func openBenchmarkDB(b *testing.B) (*protavo.DB, func()) {
+	dir, err := ioutil.TempDir("", "protavobolt-")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	bdb, err := bolt.Open(path.Join(dir, "bolt.db"), 0600, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	bdb.NoSync = true
+
+	db, err := protavo.NewDB(
+		&ExclusiveDriver{DB: bdb},
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return db, func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// populateSharedKey saves n documents that all have the given shared key.
+func populateSharedKey(b *testing.B, db *protavo.DB, key string, n int) {
+	ops := make([]driver.Operation, n)
+
+	for i := range ops {
+		ops[i] = protavo.Save(&document.Document{
+			ID:      fmt.Sprintf("doc-%d", i),
+			Keys:    document.SharedKeys(key),
+			Content: document.StringContent(""),
+		})
+	}
+
+	if err := db.Write(context.Background(), ops...); err != nil {
+		b.Fatal(err)
+	}
+}
+
+// BenchmarkWrite_Concurrent measures the throughput of many concurrent small
+// writes, with and without batching. Syncing is enabled, as sharing the cost
+// of syncing is the purpose of batching.
+func BenchmarkWrite_Concurrent(b *testing.B) {
+	for _, batch := range []bool{false, true} {
+		b.Run(
+			fmt.Sprintf("batched=%t", batch),
+			func(b *testing.B) {
+				dir, err := ioutil.TempDir("", "protavobolt-")
+				if err != nil {
+					b.Fatal(err)
+				}
+				defer os.RemoveAll(dir)
+
+				bdb, err := bolt.Open(path.Join(dir, "bolt.db"), 0600, nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				db, err := protavo.NewDB(
+					&ExclusiveDriver{
+						DB:          bdb,
+						BatchWrites: batch,
+					},
+				)
+				if err != nil {
+					b.Fatal(err)
+				}
+				defer db.Close()
+
+				var n uint64
+				ctx := context.Background()
+
+				b.SetParallelism(16)
+				b.ResetTimer()
+
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						if err := db.Save(
+							ctx,
+							&document.Document{
+								ID:      fmt.Sprintf("doc-%d", atomic.AddUint64(&n, 1)),
+								Content: document.StringContent(""),
+							},
+						); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			},
+		)
+	}
+}
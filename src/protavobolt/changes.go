@@ -0,0 +1,76 @@
+package protavobolt
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// executeChangesSince calls fn for each document that has changed since the
+// write with the sequence number seq.
+//
+// It returns a StaleSequenceError if the namespace has been deleted since seq,
+// or if seq is greater than the sequence number of the namespace's most recent
+// write.
+func executeChangesSince(
+	ctx context.Context,
+	tx *database.Tx,
+	ns string,
+	seq uint64,
+	fn driver.ChangeFunc,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if seq != 0 {
+		current, reset := database.NamespaceSequence(tx, ns)
+
+		if seq < reset || seq > current {
+			return &protavo.StaleSequenceError{
+				Namespace:  ns,
+				GivenSeq:   seq,
+				CurrentSeq: current,
+				ResetSeq:   reset,
+			}
+		}
+	}
+
+	s, ok, err := database.OpenStore(tx, ns)
+	if !ok || err != nil {
+		return err
+	}
+
+	return s.ScanChanges(seq, func(seq uint64, id string) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		change := &driver.Change{
+			Sequence:   seq,
+			DocumentID: id,
+		}
+
+		// a change without a record is the deletion of the document
+		rec, exists, err := s.TryGetRecord(id)
+		if err != nil {
+			return false, err
+		}
+
+		if exists {
+			c, err := s.GetContent(id)
+			if err != nil {
+				return false, err
+			}
+
+			change.Document, err = newDocument(id, rec, c)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		return fn(change)
+	})
+}
@@ -0,0 +1,168 @@
+package protavobolt
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// Codec is a compression algorithm used to compress document content.
+type Codec interface {
+	// Name returns a unique name for the codec. It is stored alongside the
+	// compressed content, and is used to find the codec that can decompress
+	// it, so it must never change.
+	Name() string
+
+	// Compress returns the compressed form of data.
+	Compress(data []byte) ([]byte, error)
+
+	// Decompress returns the original form of data compressed by Compress().
+	Decompress(data []byte) ([]byte, error)
+}
+
+// Compression describes how document content is compressed.
+type Compression struct {
+	// Codec is the codec used to compress content. It must be registered using
+	// RegisterCodec().
+	Codec Codec
+
+	// Threshold is the minimum size of the content that is compressed, in
+	// bytes. Smaller content is stored uncompressed, as the overhead of
+	// compression tends to outweigh any saving.
+	Threshold int
+}
+
+// Gzip is a codec that uses gzip at the default compression level.
+var Gzip Codec = GzipCodec{Level: gzip.DefaultCompression}
+
+// GzipCodec is a codec that uses gzip at a specific compression level.
+type GzipCodec struct {
+	// Level is the compression level, as per gzip.NewWriterLevel().
+	Level int
+}
+
+// Name returns "gzip".
+func (c GzipCodec) Name() string {
+	return "gzip"
+}
+
+// Compress returns the compressed form of data.
+func (c GzipCodec) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w, err := gzip.NewWriterLevel(&buf, c.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decompress returns the original form of data compressed by Compress().
+func (c GzipCodec) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+var (
+	codecsMutex sync.RWMutex
+	codecs      = map[string]Codec{}
+)
+
+func init() {
+	RegisterCodec(Gzip)
+}
+
+// RegisterCodec makes a codec available for compressing and decompressing
+// document content. The Gzip codec is registered by default.
+//
+// It panics if a different codec with the same name is already registered.
+func RegisterCodec(c Codec) {
+	codecsMutex.Lock()
+	defer codecsMutex.Unlock()
+
+	n := c.Name()
+
+	if x, ok := codecs[n]; ok && x != c {
+		panic(fmt.Sprintf("a different codec named '%s' is already registered", n))
+	}
+
+	codecs[n] = c
+}
+
+// lookupCodec returns the registered codec with the given name.
+func lookupCodec(n string) (Codec, error) {
+	codecsMutex.RLock()
+	defer codecsMutex.RUnlock()
+
+	if c, ok := codecs[n]; ok {
+		return c, nil
+	}
+
+	return nil, fmt.Errorf("unknown compression codec '%s'", n)
+}
+
+// codecCompressor is a database.Compressor that uses registered codecs.
+type codecCompressor struct {
+	compression func(ns string) *Compression
+}
+
+func (c *codecCompressor) Compress(ns string, data []byte) (*database.Compressed, error) {
+	if c.compression == nil {
+		return nil, nil
+	}
+
+	cfg := c.compression(ns)
+	if cfg == nil || len(data) < cfg.Threshold {
+		return nil, nil
+	}
+
+	n := cfg.Codec.Name()
+
+	// the codec is looked up by name to ensure that the compressed content can
+	// be decompressed again
+	if _, err := lookupCodec(n); err != nil {
+		return nil, err
+	}
+
+	buf, err := cfg.Codec.Compress(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// there's no point storing the compressed form if it's not any smaller
+	if len(buf) >= len(data) {
+		return nil, nil
+	}
+
+	return &database.Compressed{
+		Codec: n,
+		Data:  buf,
+	}, nil
+}
+
+func (c *codecCompressor) Decompress(m *database.Compressed) ([]byte, error) {
+	codec, err := lookupCodec(m.Codec)
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.Decompress(m.Data)
+}
@@ -0,0 +1,286 @@
+package protavobolt_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver/drivertest"
+	. "github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+func init() {
+	var dir string
+
+	drivertest.Describe(
+		"protavobolt.ExclusiveDriver (compressed)",
+		func() (*protavo.DB, error) {
+			var err error
+			dir, err = ioutil.TempDir("", "protavobolt-")
+			if err != nil {
+				return nil, err
+			}
+
+			db, _, err := openCompressed(
+				path.Join(dir, "bolt.db"),
+				func(string) *Compression {
+					return &Compression{Codec: Gzip}
+				},
+			)
+
+			return db, err
+		},
+		func() {
+			_ = os.RemoveAll(dir)
+		},
+	)
+}
+
+var _ = g.Describe("ExclusiveDriver (compressed)", func() {
+	var (
+		ctx   = context.Background()
+		dir   string
+		file  string
+		large string
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "protavobolt-")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		file = path.Join(dir, "bolt.db")
+		large = strings.Repeat("<compressible>", 1000)
+	})
+
+	g.AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	// compressAll returns compression settings that compress content larger
+	// than 100 bytes in every namespace.
+	compressAll := func(string) *Compression {
+		return &Compression{
+			Codec:     Gzip,
+			Threshold: 100,
+		}
+	}
+
+	// save saves a document with the given ID and content to the ns namespace
+	// of a database that uses the given compression settings.
+	save := func(fn func(string) *Compression, ns, id, content string) {
+		db, _, err := openCompressed(file, fn)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer db.Close()
+
+		err = db.Namespace(ns).Save(
+			ctx,
+			&document.Document{
+				ID:      id,
+				Content: document.StringContent(content),
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	}
+
+	// load loads the content of a document from the ns namespace of a database
+	// that uses the given compression settings.
+	load := func(fn func(string) *Compression, ns, id string) (string, error) {
+		db, _, err := openCompressed(file, fn)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer db.Close()
+
+		doc, ok, err := db.Namespace(ns).Load(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		m.Expect(ok).To(m.BeTrue())
+
+		return document.GetStringContent(doc.Content), nil
+	}
+
+	// stats returns the stats for the ns namespace.
+	stats := func(ns string) Stats {
+		db, d, err := openCompressed(file, nil)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer db.Close()
+
+		s, err := d.Stats(ctx, ns)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		return s
+	}
+
+	g.It("compresses content that is larger than the threshold", func() {
+		save(compressAll, "ns", "doc-1", large)
+
+		data, err := ioutil.ReadFile(file)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(string(data)).NotTo(m.ContainSubstring(large))
+
+		s := stats("ns")
+		m.Expect(s.Documents).To(m.Equal(1))
+		m.Expect(s.ContentBytes).To(m.BeNumerically(">", len(large)))
+		m.Expect(s.StoredBytes).To(m.BeNumerically("<", len(large)/10))
+		m.Expect(s.CompressionRatio()).To(m.BeNumerically(">", 10))
+
+		c, err := load(compressAll, "ns", "doc-1")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(c).To(m.Equal(large))
+	})
+
+	g.It("does not compress content that is smaller than the threshold", func() {
+		save(compressAll, "ns", "doc-1", "<small>")
+
+		s := stats("ns")
+		m.Expect(s.ContentBytes).To(m.BeNumerically(">", 0))
+		m.Expect(s.StoredBytes).To(m.Equal(s.ContentBytes))
+		m.Expect(s.CompressionRatio()).To(m.Equal(1.0))
+	})
+
+	g.It("uses the settings for each namespace", func() {
+		fn := func(ns string) *Compression {
+			if ns == "compressed" {
+				return compressAll(ns)
+			}
+
+			return nil
+		}
+
+		save(fn, "compressed", "doc-1", large)
+		save(fn, "uncompressed", "doc-1", large)
+
+		m.Expect(stats("compressed").CompressionRatio()).To(m.BeNumerically(">", 10))
+		m.Expect(stats("uncompressed").CompressionRatio()).To(m.Equal(1.0))
+	})
+
+	g.It("can read uncompressed content", func() {
+		save(nil, "ns", "doc-1", large)
+
+		c, err := load(compressAll, "ns", "doc-1")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(c).To(m.Equal(large))
+	})
+
+	g.It("can read compressed content when compression is disabled", func() {
+		save(compressAll, "ns", "doc-1", large)
+
+		c, err := load(nil, "ns", "doc-1")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(c).To(m.Equal(large))
+	})
+
+	g.It("updates the stats when content is replaced or deleted", func() {
+		save(nil, "ns", "doc-1", large)
+		save(nil, "ns", "doc-2", large)
+
+		before := stats("ns")
+
+		db, _, err := openCompressed(file, compressAll)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		doc, _, err := db.Namespace("ns").Load(ctx, "doc-1")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = db.Namespace("ns").Save(ctx, doc)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		_, err = db.Namespace("ns").DeleteByID(ctx, "doc-2")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		db.Close()
+
+		after := stats("ns")
+		m.Expect(after.ContentBytes).To(m.Equal(before.ContentBytes / 2))
+		m.Expect(after.StoredBytes).To(m.BeNumerically("<", len(large)/10))
+	})
+
+	g.It("compresses content before encrypting it", func() {
+		db, d, err := openCompressed(file, compressAll)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		d.KeyProvider = &StaticKeyProvider{
+			CurrentKeyID: "key-1",
+			Keys: map[string][]byte{
+				"key-1": bytes.Repeat([]byte{1}, 32),
+			},
+		}
+
+		err = db.Save(
+			ctx,
+			&document.Document{
+				ID:      "doc-1",
+				Content: document.StringContent(large),
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		doc, _, err := db.Load(ctx, "doc-1")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(document.GetStringContent(doc.Content)).To(m.Equal(large))
+
+		s, err := d.Stats(ctx, "")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(s.CompressionRatio()).To(m.BeNumerically(">", 10))
+
+		db.Close()
+	})
+
+	g.It("returns an error if the codec is not registered", func() {
+		db, _, err := openCompressed(
+			file,
+			func(string) *Compression {
+				return &Compression{Codec: unregisteredCodec{}}
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer db.Close()
+
+		err = db.Save(
+			ctx,
+			&document.Document{
+				ID:      "doc-1",
+				Content: document.StringContent(large),
+			},
+		)
+		m.Expect(err).To(m.MatchError("unknown compression codec 'unregistered'"))
+	})
+})
+
+// unregisteredCodec is a codec that is never registered.
+type unregisteredCodec struct {
+	GzipCodec
+}
+
+func (unregisteredCodec) Name() string {
+	return "unregistered"
+}
+
+// openCompressed returns a database that uses the given file and compression
+// settings.
+func openCompressed(
+	file string,
+	fn func(string) *Compression,
+) (*protavo.DB, *ExclusiveDriver, error) {
+	bdb, err := bolt.Open(file, 0600, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d := &ExclusiveDriver{
+		DB:          bdb,
+		Compression: fn,
+		TextIndex:   drivertest.TextIndex,
+	}
+
+	db, err := protavo.NewDB(d)
+	return db, d, err
+}
@@ -0,0 +1,37 @@
+package protavobolt
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// RebuildContentTypeIndex re-indexes the content type of every document, in
+// all namespaces.
+//
+// The content type index is maintained automatically as documents are saved
+// and deleted. The on-disk format upgrade adds existing documents to the index,
+// except for those with compressed or encrypted content. The index is not used
+// to find documents within a namespace until all such documents have been
+// saved again, or the index has been rebuilt.
+//
+// It is performed within a single transaction. It returns the number of
+// documents that were indexed.
+func (d *ExclusiveDriver) RebuildContentTypeIndex(ctx context.Context) (int, error) {
+	return d.reindex(
+		ctx,
+		func(s *database.Store, rec *database.Record, doc *document.Document) error {
+			new := proto.Clone(rec).(*database.Record)
+			new.ContentType = document.TypeURL(doc.Content)
+
+			if err := s.UpdateContentType(doc.ID, rec, new); err != nil {
+				return err
+			}
+
+			return s.PutRecord(doc.ID, new)
+		},
+		nil,
+	)
+}
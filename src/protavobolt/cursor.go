@@ -0,0 +1,206 @@
+package protavobolt
+
+import (
+	"context"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavo/filter"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// executeCursor returns a cursor over the documents that match f.
+func executeCursor(
+	ctx context.Context,
+	tx *database.Tx,
+	ns string,
+	f *filter.Filter,
+) (driver.Cursor, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s, ok, err := database.OpenStore(tx, ns)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return &noop{}, nil
+	}
+
+	return selectStrategy(s, f).Cursor(), nil
+}
+
+// Cursor is the implementation of the "no-op" strategy for cursors.
+func (qs *noop) Cursor() driver.Cursor {
+	return qs
+}
+
+// Next returns the next document, of which there are none.
+func (*noop) Next(context.Context) (*document.Document, bool, error) {
+	return nil, false, nil
+}
+
+// Cursor is the implementation of the "scan records" strategy for cursors.
+func (qs *scanRecords) Cursor() driver.Cursor {
+	return &scanCursor{strategy: qs}
+}
+
+// Cursor is the implementation of the "use document ID first" strategy for
+// cursors.
+func (qs *useIDFirst) Cursor() driver.Cursor {
+	c, conds := qs.conds.ExtractIsOneOf()
+
+	ids := make([]string, 0, len(c.Values))
+	for id := range c.Values {
+		ids = append(ids, id)
+	}
+
+	return &idCursor{
+		store:    qs.store,
+		ids:      ids,
+		conds:    conds,
+		optional: true,
+	}
+}
+
+// Cursor is the implementation of the "use unique key first" strategy for
+// cursors.
+func (qs *useUniqueKeyFirst) Cursor() driver.Cursor {
+	c, conds := qs.conds.ExtractHasUniqueKeyIn()
+
+	var ids []string
+	for key := range c.Values {
+		if id, ok := qs.store.GetUniqueKeyDocumentID(key); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	return &idCursor{
+		store: qs.store,
+		ids:   ids,
+		conds: conds,
+	}
+}
+
+// Cursor is the implementation of the "use index first" strategy for cursors.
+func (qs *useIndexFirst) Cursor() driver.Cursor {
+	ids, conds := qs.index.candidates(qs.store)
+
+	return &idCursor{
+		store: qs.store,
+		ids:   ids,
+		conds: conds,
+	}
+}
+
+// scanCursor is a cursor that iterates over all records and passes them
+// through a filter in memory.
+type scanCursor struct {
+	strategy *scanRecords
+	cursor   *bolt.Cursor
+}
+
+// Next returns the next document that matches the filter.
+func (c *scanCursor) Next(ctx context.Context) (*document.Document, bool, error) {
+	var k, v []byte
+
+	if c.cursor == nil {
+		c.cursor = c.strategy.store.Records.Cursor()
+		k, v = c.cursor.First()
+	} else {
+		k, v = c.cursor.Next()
+	}
+
+	for ; k != nil; k, v = c.cursor.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		rec, err := database.UnmarshalRecord(v)
+		if err != nil {
+			return nil, false, err
+		}
+
+		id := string(k)
+
+		match, err := isFilterSatisfiedByRecord(c.strategy.store, c.strategy.filter, id, rec)
+		if err != nil {
+			return nil, false, err
+		} else if match {
+			return loadDocument(c.strategy.store, id, rec)
+		}
+	}
+
+	return nil, false, nil
+}
+
+// idCursor is a cursor that loads a set of candidate documents by their ID,
+// then applies the remaining set of filters in-memory.
+type idCursor struct {
+	store *database.Store
+	ids   []string
+	conds *conditions
+
+	// optional is true if ids may contain the IDs of documents that do not
+	// exist.
+	optional bool
+}
+
+// Next returns the next candidate document that satisfies the conditions.
+func (c *idCursor) Next(ctx context.Context) (*document.Document, bool, error) {
+	for len(c.ids) != 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		id := c.ids[0]
+		c.ids = c.ids[1:]
+
+		rec, err := c.record(id)
+		if err != nil {
+			return nil, false, err
+		} else if rec == nil {
+			continue
+		}
+
+		match, err := c.conds.AreSatisfiedBy(c.store, id, rec)
+		if err != nil {
+			return nil, false, err
+		} else if match {
+			return loadDocument(c.store, id, rec)
+		}
+	}
+
+	return nil, false, nil
+}
+
+// record returns the record for the document with the given ID, or nil if
+// the document does not exist and c.optional is true.
+func (c *idCursor) record(id string) (*database.Record, error) {
+	if c.optional {
+		rec, _, err := c.store.TryGetRecord(id)
+		return rec, err
+	}
+
+	return c.store.GetRecord(id)
+}
+
+// loadDocument returns the document with the given ID and record.
+func loadDocument(
+	s *database.Store,
+	id string,
+	rec *database.Record,
+) (*document.Document, bool, error) {
+	c, err := s.GetContent(id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	doc, err := newDocument(id, rec, c)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return doc, true, nil
+}
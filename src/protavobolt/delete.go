@@ -1,7 +1,8 @@
 package protavobolt
 
 import (
-	bolt "github.com/coreos/bbolt"
+	"context"
+
 	"github.com/jmalloc/protavo/src/protavo"
 	"github.com/jmalloc/protavo/src/protavo/document"
 	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
@@ -10,10 +11,15 @@ import (
 // executeDelete deletes the given documents, provided their revisions match
 // the currently persisted revisions.
 func executeDelete(
-	tx *bolt.Tx,
+	ctx context.Context,
+	tx *database.Tx,
 	ns string,
 	doc *document.Document,
 ) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	s, ok, err := database.OpenStore(tx, ns)
 	if err != nil {
 		return err
@@ -46,13 +52,5 @@ func executeDelete(
 		return nil
 	}
 
-	if err := s.DeleteRecord(doc.ID); err != nil {
-		return err
-	}
-
-	if err := s.DeleteContent(doc.ID); err != nil {
-		return err
-	}
-
-	return s.UpdateKeys(doc.ID, rec.Keys, nil)
+	return applyDelete(s, doc.ID, rec, true, nil)
 }
@@ -1,7 +1,8 @@
 package protavobolt
 
 import (
-	bolt "github.com/coreos/bbolt"
+	"context"
+
 	"github.com/jmalloc/protavo/src/protavo/driver"
 	"github.com/jmalloc/protavo/src/protavo/filter"
 	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
@@ -10,17 +11,22 @@ import (
 // executeDeleteWhere deletes documents that match f, regardless of whether
 // their revisions match the currently persisted revisions.
 func executeDeleteWhere(
-	tx *bolt.Tx,
+	ctx context.Context,
+	tx *database.Tx,
 	ns string,
 	f *filter.Filter,
 	fn driver.DeleteWhereFunc,
 ) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	s, ok, err := database.OpenStore(tx, ns)
 	if !ok || err != nil {
 		return err
 	}
 
-	return selectStrategy(s, f).DeleteWhere(fn)
+	return selectStrategy(s, f).DeleteWhere(ctx, fn)
 }
 
 // applyDelete executes the side-effects of a delete-where operation.
@@ -37,7 +43,35 @@ func applyDelete(
 		}
 	}
 
-	if err := s.DeleteContent(id); err != nil {
+	// the content and attachments of soft-deleted documents are retained, so
+	// that the document can be restored
+	if !s.IsSoftDeleteEnabled() {
+		if err := s.DeleteContent(id); err != nil {
+			return err
+		}
+
+		if err := s.DeleteAttachments(id); err != nil {
+			return err
+		}
+	}
+
+	if err := s.DeleteText(id); err != nil {
+		return err
+	}
+
+	if err := s.UpdateHeaders(id, rec.Headers, nil); err != nil {
+		return err
+	}
+
+	if err := s.UpdateTimes(id, rec, nil); err != nil {
+		return err
+	}
+
+	if err := s.UpdateContentType(id, rec, nil); err != nil {
+		return err
+	}
+
+	if err := s.RecordChange(id, rec, nil); err != nil {
 		return err
 	}
 
@@ -45,6 +79,12 @@ func applyDelete(
 		return err
 	}
 
+	if s.IsSoftDeleteEnabled() {
+		if err := putDeletedRecord(s, id, rec); err != nil {
+			return err
+		}
+	}
+
 	if fn != nil {
 		return fn(id)
 	}
@@ -53,15 +93,19 @@ func applyDelete(
 }
 
 // DeleteWhere is the implementation of the "no-op" strategy for deleting.
-func (*noop) DeleteWhere(driver.DeleteWhereFunc) error {
+func (*noop) DeleteWhere(context.Context, driver.DeleteWhereFunc) error {
 	return nil
 }
 
 // DeleteWhere is the implementation of the "scan records" strategy for deleting.
-func (qs *scanRecords) DeleteWhere(fn driver.DeleteWhereFunc) error {
+func (qs *scanRecords) DeleteWhere(ctx context.Context, fn driver.DeleteWhereFunc) error {
 	cur := qs.store.Records.Cursor()
 
 	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		rec, err := database.UnmarshalRecord(v)
 		if err != nil {
 			return err
@@ -69,12 +113,15 @@ func (qs *scanRecords) DeleteWhere(fn driver.DeleteWhereFunc) error {
 
 		id := string(k)
 
-		if !isFilterSatisfiedByRecord(qs.filter, id, rec) {
+		match, err := isFilterSatisfiedByRecord(qs.store, qs.filter, id, rec)
+		if err != nil {
+			return err
+		} else if !match {
 			continue
 		}
 
 		// use the cursor to delete the record so that we don't invalidate it
-		if err := cur.Delete(); err != nil {
+		if err := qs.store.DeleteRecordAt(cur, id); err != nil {
 			return err
 		}
 
@@ -88,8 +135,14 @@ func (qs *scanRecords) DeleteWhere(fn driver.DeleteWhereFunc) error {
 
 // DeleteWhere is the implementation of the "use document ID first" strategy for
 // deleting.
-func (qs *useIDFirst) DeleteWhere(fn driver.DeleteWhereFunc) error {
-	for id := range qs.conds.ExtractIsOneOf().Values {
+func (qs *useIDFirst) DeleteWhere(ctx context.Context, fn driver.DeleteWhereFunc) error {
+	c, conds := qs.conds.ExtractIsOneOf()
+
+	for id := range c.Values {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		rec, exists, err := qs.store.TryGetRecord(id)
 		if err != nil {
 			return err
@@ -99,7 +152,10 @@ func (qs *useIDFirst) DeleteWhere(fn driver.DeleteWhereFunc) error {
 			continue
 		}
 
-		if !qs.conds.AreSatisfiedBy(id, rec) {
+		match, err := conds.AreSatisfiedBy(qs.store, id, rec)
+		if err != nil {
+			return err
+		} else if !match {
 			continue
 		}
 
@@ -113,14 +169,15 @@ func (qs *useIDFirst) DeleteWhere(fn driver.DeleteWhereFunc) error {
 
 // DeleteWhere is the implementation of the "use unique key first" strategy for
 // deleting.
-func (qs *useUniqueKeyFirst) DeleteWhere(fn driver.DeleteWhereFunc) error {
-	for key := range qs.conds.ExtractHasUniqueKeyIn().Values {
-		k, err := qs.store.GetKey(key)
-		if err != nil {
+func (qs *useUniqueKeyFirst) DeleteWhere(ctx context.Context, fn driver.DeleteWhereFunc) error {
+	c, conds := qs.conds.ExtractHasUniqueKeyIn()
+
+	for key := range c.Values {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
 
-		id, ok := k.GetUniqueDocumentID()
+		id, ok := qs.store.GetUniqueKeyDocumentID(key)
 		if !ok {
 			continue
 		}
@@ -130,9 +187,11 @@ func (qs *useUniqueKeyFirst) DeleteWhere(fn driver.DeleteWhereFunc) error {
 			return err
 		}
 
-		if !qs.conds.AreSatisfiedBy(id, rec) {
+		match, err := conds.AreSatisfiedBy(qs.store, id, rec)
+		if err != nil {
+			return err
+		} else if !match {
 			continue
-
 		}
 
 		if err := applyDelete(qs.store, id, rec, true, fn); err != nil {
@@ -143,21 +202,25 @@ func (qs *useUniqueKeyFirst) DeleteWhere(fn driver.DeleteWhereFunc) error {
 	return nil
 }
 
-// DeleteWhere is the implementation of the "use keys first" strategy for
+// DeleteWhere is the implementation of the "use index first" strategy for
 // deleting.
-func (qs *useKeysFirst) DeleteWhere(fn driver.DeleteWhereFunc) error {
-	ids, err := qs.findDocumentIDs()
-	if err != nil {
-		return err
-	}
+func (qs *useIndexFirst) DeleteWhere(ctx context.Context, fn driver.DeleteWhereFunc) error {
+	ids, conds := qs.index.candidates(qs.store)
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-	for id := range ids {
 		rec, err := qs.store.GetRecord(id)
 		if err != nil {
 			return err
 		}
 
-		if !qs.conds.AreSatisfiedBy(id, rec) {
+		match, err := conds.AreSatisfiedBy(qs.store, id, rec)
+		if err != nil {
+			return err
+		} else if !match {
 			continue
 		}
 
@@ -0,0 +1,138 @@
+package protavobolt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// KeyProvider supplies the keys used to encrypt document content.
+//
+// Keys must be 16, 24 or 32 bytes long, to select AES-128, AES-192 or AES-256,
+// respectively.
+type KeyProvider interface {
+	// CurrentKey returns the key used to encrypt content, along with its ID.
+	// The ID is stored alongside the encrypted content.
+	CurrentKey() (id string, key []byte, err error)
+
+	// Key returns the key with the given ID. It is used to decrypt content,
+	// which may have been encrypted with a key that is no longer current.
+	Key(id string) ([]byte, error)
+}
+
+// StaticKeyProvider is a KeyProvider that uses a fixed set of keys.
+type StaticKeyProvider struct {
+	// CurrentKeyID is the ID of the key used to encrypt content.
+	CurrentKeyID string
+
+	// Keys is a map of key ID to key. It must contain the current key, and any
+	// previous keys that are still needed to decrypt existing content.
+	Keys map[string][]byte
+}
+
+// CurrentKey returns the key used to encrypt content, along with its ID.
+func (p *StaticKeyProvider) CurrentKey() (string, []byte, error) {
+	k, err := p.Key(p.CurrentKeyID)
+	return p.CurrentKeyID, k, err
+}
+
+// Key returns the key with the given ID.
+func (p *StaticKeyProvider) Key(id string) ([]byte, error) {
+	if k, ok := p.Keys[id]; ok {
+		return k, nil
+	}
+
+	return nil, fmt.Errorf("unknown encryption key '%s'", id)
+}
+
+// RotateKeys re-encrypts the content and attachments of every document that
+// are not encrypted with the current key, in all namespaces. Content that is
+// not encrypted at all is also encrypted.
+//
+// It is performed within a single transaction. It returns the number of
+// documents that were re-encrypted.
+func (d *ExclusiveDriver) RotateKeys(ctx context.Context) (int, error) {
+	if d.KeyProvider == nil {
+		return 0, errors.New("can not rotate keys, no key provider is configured")
+	}
+
+	if err := d.init(ctx); err != nil {
+		return 0, err
+	}
+
+	btx, err := d.begin(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+	defer btx.Rollback()
+
+	n, err := database.ResealContent(d.newTx(btx))
+	if err != nil {
+		return 0, err
+	}
+
+	return n, btx.Commit()
+}
+
+// aesSealer is an implementation of database.Sealer that uses AES-GCM.
+type aesSealer struct {
+	keys KeyProvider
+}
+
+func (s *aesSealer) Seal(plaintext, aad []byte) (*database.Sealed, error) {
+	id, key, err := s.keys.CurrentKey()
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return &database.Sealed{
+		KeyId:      id,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, aad),
+	}, nil
+}
+
+func (s *aesSealer) Open(m *database.Sealed, aad []byte) ([]byte, error) {
+	key, err := s.keys.Key(m.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return gcm.Open(nil, m.Nonce, m.Ciphertext, aad)
+}
+
+func (s *aesSealer) IsCurrent(m *database.Sealed) bool {
+	id, _, err := s.keys.CurrentKey()
+	return err == nil && id == m.KeyId
+}
+
+// newGCM returns an AES-GCM cipher that uses the given key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(b)
+}
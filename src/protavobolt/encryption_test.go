@@ -0,0 +1,271 @@
+package protavobolt_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver/drivertest"
+	. "github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+func init() {
+	var dir string
+
+	drivertest.Describe(
+		"protavobolt.ExclusiveDriver (encrypted)",
+		func() (*protavo.DB, error) {
+			var err error
+			dir, err = ioutil.TempDir("", "protavobolt-")
+			if err != nil {
+				return nil, err
+			}
+
+			db, _, err := openEncrypted(
+				path.Join(dir, "bolt.db"),
+				&StaticKeyProvider{
+					CurrentKeyID: "key-1",
+					Keys: map[string][]byte{
+						"key-1": bytes.Repeat([]byte{1}, 32),
+					},
+				},
+			)
+
+			return db, err
+		},
+		func() {
+			_ = os.RemoveAll(dir)
+		},
+	)
+}
+
+var _ = g.Describe("ExclusiveDriver (encrypted)", func() {
+	var (
+		ctx  = context.Background()
+		dir  string
+		file string
+		keys *StaticKeyProvider
+		doc  *document.Document
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "protavobolt-")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		file = path.Join(dir, "bolt.db")
+
+		keys = &StaticKeyProvider{
+			CurrentKeyID: "key-1",
+			Keys: map[string][]byte{
+				"key-1": bytes.Repeat([]byte{1}, 32),
+				"key-2": bytes.Repeat([]byte{2}, 32),
+			},
+		}
+
+		doc = &document.Document{
+			ID:      "doc-1",
+			Keys:    document.UniqueKeys("uniq"),
+			Headers: map[string]string{"email": "<header-pii>"},
+			Content: document.StringContent("<content-pii>"),
+		}
+	})
+
+	g.AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	// save saves doc to a database that uses the given key provider.
+	save := func(kp KeyProvider) {
+		db, _, err := openEncrypted(file, kp)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer db.Close()
+
+		err = db.Save(ctx, doc)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	}
+
+	// load loads doc from a database that uses the given key provider.
+	load := func(kp KeyProvider) (*document.Document, error) {
+		db, _, err := openEncrypted(file, kp)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer db.Close()
+
+		d, _, err := db.LoadByUniqueKey(ctx, "uniq")
+		return d, err
+	}
+
+	g.It("does not store the content or headers in plaintext", func() {
+		save(keys)
+
+		data, err := ioutil.ReadFile(file)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(string(data)).NotTo(m.ContainSubstring("<header-pii>"))
+		m.Expect(string(data)).NotTo(m.ContainSubstring("<content-pii>"))
+
+		d, err := load(keys)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(d.Equal(doc)).To(m.BeTrue())
+	})
+
+	g.It("does not store attachments in plaintext", func() {
+		save(keys)
+
+		db, _, err := openEncrypted(file, keys)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = db.PutAttachment(ctx, "doc-1", "file", strings.NewReader("<attachment-pii>"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		db.Close()
+
+		data, err := ioutil.ReadFile(file)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(string(data)).NotTo(m.ContainSubstring("<attachment-pii>"))
+
+		db, _, err = openEncrypted(file, keys)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer db.Close()
+
+		var buf bytes.Buffer
+		ok, err := db.GetAttachment(ctx, "doc-1", "file", &buf)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(ok).To(m.BeTrue())
+		m.Expect(buf.String()).To(m.Equal("<attachment-pii>"))
+	})
+
+	g.It("returns an error if encrypted content is loaded without a key provider", func() {
+		save(keys)
+
+		_, err := load(nil)
+		m.Expect(err).To(m.MatchError(
+			"content for 'doc-1' is encrypted, but no key provider is configured",
+		))
+	})
+
+	g.It("returns an error if the key is unknown", func() {
+		save(keys)
+
+		delete(keys.Keys, "key-1")
+
+		_, err := load(keys)
+		m.Expect(err).Should(m.HaveOccurred())
+	})
+
+	g.It("can read unencrypted content", func() {
+		save(nil)
+
+		d, err := load(keys)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(d.Equal(doc)).To(m.BeTrue())
+	})
+
+	g.Describe("RotateKeys", func() {
+		rotate := func() int {
+			db, d, err := openEncrypted(file, keys)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			n, err := d.RotateKeys(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			return n
+		}
+
+		g.It("re-encrypts content that uses a previous key", func() {
+			save(keys)
+
+			keys.CurrentKeyID = "key-2"
+
+			d, err := load(keys)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(d.Equal(doc)).To(m.BeTrue())
+
+			m.Expect(rotate()).To(m.Equal(1))
+			m.Expect(rotate()).To(m.Equal(0))
+
+			delete(keys.Keys, "key-1")
+
+			d, err = load(keys)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(d.Equal(doc)).To(m.BeTrue())
+		})
+
+		g.It("re-encrypts attachments that use a previous key", func() {
+			save(keys)
+
+			db, _, err := openEncrypted(file, keys)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			err = db.PutAttachment(ctx, "doc-1", "file", strings.NewReader("<attachment>"))
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			db.Close()
+
+			keys.CurrentKeyID = "key-2"
+			rotate()
+			delete(keys.Keys, "key-1")
+
+			db, _, err = openEncrypted(file, keys)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			var buf bytes.Buffer
+			ok, err := db.GetAttachment(ctx, "doc-1", "file", &buf)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+			m.Expect(buf.String()).To(m.Equal("<attachment>"))
+		})
+
+		g.It("encrypts unencrypted content in all namespaces", func() {
+			db, _, err := openEncrypted(file, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			err = db.Namespace("a.b").Save(ctx, doc)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			db.Close()
+
+			doc.Revision = 0
+			save(nil)
+
+			m.Expect(rotate()).To(m.Equal(2))
+
+			_, err = load(nil)
+			m.Expect(err).Should(m.HaveOccurred())
+
+			db, _, err = openEncrypted(file, keys)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			d, ok, err := db.Namespace("a.b").Load(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+			m.Expect(d.Headers).To(m.Equal(doc.Headers))
+			m.Expect(document.GetStringContent(d.Content)).To(m.Equal("<content-pii>"))
+		})
+	})
+})
+
+// openEncrypted returns a database that uses the given file and key provider.
+func openEncrypted(
+	file string,
+	kp KeyProvider,
+) (*protavo.DB, *ExclusiveDriver, error) {
+	bdb, err := bolt.Open(file, 0600, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d := &ExclusiveDriver{
+		DB:          bdb,
+		KeyProvider: kp,
+		TextIndex:   drivertest.TextIndex,
+	}
+
+	db, err := protavo.NewDB(d)
+	return db, d, err
+}
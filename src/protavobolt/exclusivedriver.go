@@ -5,41 +5,148 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"sync"
+	"sync/atomic"
 
 	bolt "github.com/coreos/bbolt"
 	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
 	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
 )
 
 // ExclusiveDriver is an implementation of protavo.Driver backed by a BoltDB
 // database that is held open for the life-time of the driver.
+//
+// OpenExclusive() and OpenTemp() create a driver configured by options. A
+// driver may also be constructed directly from an open BoltDB database, and
+// passed to protavo.NewDB(). Its fields must not be modified once it is in
+// use.
 type ExclusiveDriver struct {
-	DB      *bolt.DB
+	DB *bolt.DB
+
+	// BatchWrites enables group-commit of concurrent writes. If it is true,
+	// concurrent calls to DB.Write() and its convenience methods are coalesced
+	// into a single BoltDB transaction, such that they share the cost of
+	// syncing to disk.
+	//
+	// Each call is isolated from the failure of the others. The number of calls
+	// in each batch is limited by DB.MaxBatchSize.
+	//
+	// Transactions started explicitly using BeginWrite() are never batched.
+	BatchWrites bool
+
+	// KeyProvider, if non-nil, supplies the keys used to encrypt document
+	// content and headers using AES-GCM. Document IDs, keys, revisions and
+	// timestamps are not encrypted, so that they remain queryable.
+	//
+	// Existing unencrypted content remains readable, and is encrypted the next
+	// time it is saved, or when RotateKeys() is called.
+	//
+	// WARNING: encryption does not extend to the indexes. The following are
+	// always stored on disk in plaintext, even when KeyProvider is set:
+	//
+	//   - every term from the content fields and headers in TextIndex
+	//   - the value of every header listed in IndexedHeaders
+	//   - every document key
+	//
+	// Do not add fields or headers that contain sensitive data, such as
+	// personally identifiable information, to TextIndex or IndexedHeaders, or
+	// use such data in keys, if it must be encrypted at rest.
+	KeyProvider KeyProvider
+
+	// IndexedHeaders is the list of header names that are indexed, such that
+	// documents can be found by the value of these headers without loading the
+	// content of every document.
+	//
+	// Conditions on any other header are still supported, but require the
+	// content of each candidate document to be loaded, and possibly decrypted.
+	//
+	// If a namespace already contains documents when a header is added to this
+	// list, the header's index is not used to find documents within that
+	// namespace until RebuildHeaderIndex() is called.
+	//
+	// The values of indexed headers are stored in plaintext, even if KeyProvider
+	// is set.
+	IndexedHeaders []string
+
+	// TextIndex determines which content fields and headers are searchable
+	// using protavo.MatchesText(). If it is nil, no text is searchable.
+	//
+	// Documents saved before a field or header was added to the index are not
+	// searchable by that field or header until they are saved again, or
+	// RebuildTextIndex() is called. The terms in the index are stored in
+	// plaintext, even if KeyProvider is set.
+	TextIndex *document.TextIndex
+
+	// Compression, if non-nil, returns the settings used to compress the
+	// content of documents saved within the namespace ns. If it returns nil,
+	// content within that namespace is stored uncompressed.
+	//
+	// Content is compressed before it is encrypted. Compressed content remains
+	// readable regardless of the current settings, provided that its codec is
+	// registered.
+	Compression func(ns string) *Compression
+
+	// SoftDelete, if non-nil, causes deleted documents to be retained, such that
+	// they can be restored using DB.Restore(). Soft-deleted documents are hidden
+	// from all read operations, and are removed permanently when they are purged
+	// using DB.PurgeDeleted().
+	//
+	// The ID of a soft-deleted document can not be reused until it is restored
+	// or purged. Attempting to save a new document with the same ID fails with
+	// an OptimisticLockError.
+	//
+	// Documents that were soft-deleted remain restorable even if soft deletion
+	// is later disabled.
+	SoftDelete *SoftDelete
+
 	onClose func() error
+
+	// initSem is a semaphore that is held while the database is upgraded, such
+	// that callers can stop waiting if their context is canceled. It is created
+	// by initOnce. initDone is set atomically once the upgrade is complete.
+	initOnce sync.Once
+	initSem  chan struct{}
+	initDone int32
+	initErr  error
+
+	batchMutex sync.Mutex
+	batching   bool
+	pending    []*batchCall
 }
 
 // OpenExclusive returns a BoltDB-based database that is locked for exclusive
 // use by this process.
+//
+// The driver is configured by options. Use WithDBOptions() to apply options
+// to the returned DB.
 func OpenExclusive(
 	file string,
 	mode os.FileMode,
 	opts *bolt.Options,
+	options ...Option,
 ) (*protavo.DB, error) {
 	db, err := bolt.Open(file, mode, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return protavo.NewDB(
-		&ExclusiveDriver{db, nil},
+	return newDB(
+		&ExclusiveDriver{DB: db},
+		options,
 	)
 }
 
 // OpenTemp returns a BoltDB-based database that uses a temporary file.
 // The file is deleted when the database is closed.
+//
+// The driver is configured by options. Use WithDBOptions() to apply options
+// to the returned DB.
 func OpenTemp(
 	mode os.FileMode,
 	opts *bolt.Options,
+	options ...Option,
 ) (*protavo.DB, error) {
 	dir, err := ioutil.TempDir("", "protavobolt-")
 	if err != nil {
@@ -52,13 +159,14 @@ func OpenTemp(
 		return nil, err
 	}
 
-	return protavo.NewDB(
+	return newDB(
 		&ExclusiveDriver{
-			db,
-			func() error {
+			DB: db,
+			onClose: func() error {
 				return os.RemoveAll(dir)
 			},
 		},
+		options,
 	)
 }
 
@@ -67,12 +175,16 @@ func (d *ExclusiveDriver) BeginRead(
 	ctx context.Context,
 	ns string,
 ) (driver.ReadTx, error) {
-	tx, err := d.DB.Begin(false)
+	if err := d.init(ctx); err != nil {
+		return nil, err
+	}
+
+	tx, err := d.begin(ctx, false)
 	if err != nil {
 		return nil, err
 	}
 
-	return &readTx{ns, tx}, nil
+	return &readTx{ns, d.newTx(tx)}, nil
 }
 
 // BeginWrite starts a new read/write transaction.
@@ -80,16 +192,151 @@ func (d *ExclusiveDriver) BeginWrite(
 	ctx context.Context,
 	ns string,
 ) (driver.WriteTx, error) {
-	tx, err := d.DB.Begin(true)
+	if err := d.init(ctx); err != nil {
+		return nil, err
+	}
+
+	tx, err := d.begin(ctx, true)
 	if err != nil {
 		return nil, err
 	}
 
 	return &writeTx{
-		readTx: readTx{ns, tx},
+		readTx: readTx{ns, d.newTx(tx)},
 	}, nil
 }
 
+// begin starts a new BoltDB transaction.
+//
+// BoltDB only allows a single writable transaction at a time, and blocks until
+// it can be obtained. begin returns ctx.Err() if ctx is canceled or its
+// deadline is exceeded before the transaction is started.
+func (d *ExclusiveDriver) begin(ctx context.Context, writable bool) (*bolt.Tx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// there's no need to wait in a separate goroutine if the context can never
+	// be canceled
+	if ctx.Done() == nil {
+		return d.DB.Begin(writable)
+	}
+
+	type result struct {
+		tx  *bolt.Tx
+		err error
+	}
+
+	ch := make(chan result, 1)
+
+	go func() {
+		tx, err := d.DB.Begin(writable)
+		ch <- result{tx, err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.tx, r.err
+	case <-ctx.Done():
+		// the transaction may still be started after the context is canceled, in
+		// which case it needs to be rolled back as soon as it is obtained
+		go func() {
+			if r := <-ch; r.tx != nil {
+				_ = r.tx.Rollback()
+			}
+		}()
+
+		return nil, ctx.Err()
+	}
+}
+
+// newTx returns a database.Tx that wraps the given BoltDB transaction.
+func (d *ExclusiveDriver) newTx(tx *bolt.Tx) *database.Tx {
+	dtx := database.NewTx(tx)
+	dtx.Compressor = &codecCompressor{d.Compression}
+	dtx.TextIndex = d.TextIndex
+
+	if len(d.IndexedHeaders) != 0 {
+		dtx.IndexedHeaders = map[string]struct{}{}
+
+		for _, n := range d.IndexedHeaders {
+			dtx.IndexedHeaders[n] = struct{}{}
+		}
+	}
+
+	if d.KeyProvider != nil {
+		dtx.Sealer = &aesSealer{d.KeyProvider}
+	}
+
+	if d.SoftDelete != nil {
+		dtx.SoftDelete = true
+		dtx.ReleaseUniqueKeys = d.SoftDelete.ReleaseUniqueKeys
+	}
+
+	return dtx
+}
+
+// init upgrades the database to the current on-disk format, if necessary.
+//
+// The upgrade is attempted the first time a transaction is started. If ctx is
+// canceled or its deadline is exceeded before the upgrade is complete, the
+// upgrade is rolled back and ctx.Err() is returned, in which case it is
+// attempted again the next time a transaction is started.
+func (d *ExclusiveDriver) init(ctx context.Context) error {
+	if atomic.LoadInt32(&d.initDone) != 0 {
+		return d.initErr
+	}
+
+	d.initOnce.Do(func() {
+		d.initSem = make(chan struct{}, 1)
+	})
+
+	select {
+	case d.initSem <- struct{}{}:
+		defer func() { <-d.initSem }()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if atomic.LoadInt32(&d.initDone) != 0 {
+		return d.initErr
+	}
+
+	err := d.upgrade(ctx)
+
+	// don't retain the error if the upgrade was abandoned by the caller, so that
+	// it can be retried by another
+	if err != nil && ctx.Err() != nil {
+		return err
+	}
+
+	d.initErr = err
+	atomic.StoreInt32(&d.initDone, 1)
+
+	return err
+}
+
+// upgrade upgrades the database to the current on-disk format within a single
+// transaction, or checks that it is already in the current format if it is
+// opened in read-only mode.
+func (d *ExclusiveDriver) upgrade(ctx context.Context) error {
+	if d.DB.IsReadOnly() {
+		return d.DB.View(database.CheckVersion)
+	}
+
+	tx, err := d.begin(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	if err := database.Upgrade(ctx, tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
 // Close closes the driver, freeing any resources and preventing further
 // operations.
 func (d *ExclusiveDriver) Close() error {
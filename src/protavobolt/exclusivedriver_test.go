@@ -1,17 +1,220 @@
 package protavobolt_test
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
 	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
 	"github.com/jmalloc/protavo/src/protavo/driver/drivertest"
 	. "github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
 )
 
 func init() {
+	var dir string
+
 	drivertest.Describe(
 		"protavobolt.ExclusiveDriver",
 		func() (*protavo.DB, error) {
-			return OpenTemp(0600, nil)
+			var err error
+			dir, err = ioutil.TempDir("", "protavobolt-")
+			if err != nil {
+				return nil, err
+			}
+
+			bdb, err := bolt.Open(path.Join(dir, "bolt.db"), 0600, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			return protavo.NewDB(
+				&ExclusiveDriver{
+					DB:        bdb,
+					TextIndex: drivertest.TextIndex,
+				},
+			)
+		},
+		func() {
+			_ = os.RemoveAll(dir)
 		},
-		nil,
 	)
 }
+
+var _ = g.Describe("ExclusiveDriver", func() {
+	var db *protavo.DB
+
+	g.BeforeEach(func() {
+		var err error
+		db, err = OpenTemp(0600, nil)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.AfterEach(func() {
+		_ = db.Close()
+	})
+
+	g.Describe("BeginWrite", func() {
+		g.It("returns an error if the context deadline is exceeded while waiting for another writer", func() {
+			tx, err := db.BeginWrite(context.Background())
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer tx.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			_, err = db.BeginWrite(ctx)
+			m.Expect(err).To(m.Equal(context.DeadlineExceeded))
+		})
+
+		g.It("starts the transaction once the other writer is finished", func() {
+			tx, err := db.BeginWrite(context.Background())
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				tx.Close()
+			}()
+
+			tx, err = db.BeginWrite(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			tx.Close()
+		})
+	})
+
+	g.Describe("Namespace", func() {
+		ctx := context.Background()
+
+		// names is a list of namespace names that are the same as the names of
+		// the buckets that make up a store
+		names := []string{
+			"records",
+			"content",
+			"keys",
+			"stats",
+			"attachments",
+			"text",
+			"headers",
+			"times",
+			"types",
+			"changes",
+			"deleted",
+		}
+
+		g.It("keeps the documents in sub-namespaces separate from their parent's store", func() {
+			err := db.Save(ctx, &document.Document{
+				ID:      "doc-root",
+				Content: document.StringContent("<content>"),
+			})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			for _, n := range names {
+				err := db.Namespace(n).Save(ctx, &document.Document{
+					ID:      "doc-" + n,
+					Content: document.StringContent("<content>"),
+				})
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			}
+
+			docs, err := db.LoadAll(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(docs).To(m.HaveLen(1))
+			m.Expect(docs[0].ID).To(m.Equal("doc-root"))
+
+			for _, n := range names {
+				docs, err := db.Namespace(n).LoadAll(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+				m.Expect(docs).To(m.HaveLen(1))
+				m.Expect(docs[0].ID).To(m.Equal("doc-" + n))
+			}
+		})
+
+		g.It("does not affect the parent's store when a sub-namespace is deleted", func() {
+			err := db.Save(ctx, &document.Document{
+				ID:      "doc-root",
+				Content: document.StringContent("<content>"),
+			})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			for _, n := range names {
+				err := db.Namespace(n).DeleteNamespace(ctx)
+				m.Expect(err).ShouldNot(m.HaveOccurred())
+			}
+
+			_, ok, err := db.Load(ctx, "doc-root")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+		})
+
+		g.It("returns no documents from a namespace that only contains sub-namespaces", func() {
+			err := db.Namespace("parent.child").Save(ctx, &document.Document{
+				ID:      "doc-1",
+				Content: document.StringContent("<content>"),
+			})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			docs, err := db.Namespace("parent").LoadAll(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(docs).To(m.BeEmpty())
+		})
+	})
+})
+
+var _ = g.Describe("OpenTemp", func() {
+	ctx := context.Background()
+
+	g.It("configures the driver using the given options", func() {
+		db, err := OpenTemp(
+			0600,
+			nil,
+			WithIndexedHeaders("tags"),
+			WithSoftDelete(&SoftDelete{}),
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer db.Close()
+
+		doc := &document.Document{
+			ID:      "doc-1",
+			Headers: document.Headers{"tags": "urgent"},
+			Content: document.StringContent("<content>"),
+		}
+
+		err = db.Save(ctx, doc)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		plan, err := db.Explain(ctx, protavo.HeaderEquals("tags", "urgent"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(plan.Strategy).To(m.Equal(StrategyUseHeaderFirst))
+
+		err = db.Delete(ctx, doc)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		ok, err := db.Restore(ctx, "doc-1")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(ok).To(m.BeTrue())
+	})
+
+	g.It("applies the DB options", func() {
+		schema := (&document.Schema{}).
+			AddHeaderConstraint("tags", document.Constraint{MaxLength: 3})
+
+		db, err := OpenTemp(0600, nil, WithDBOptions(protavo.WithSchema(schema)))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		defer db.Close()
+
+		err = db.Save(ctx, &document.Document{
+			ID:      "doc-1",
+			Headers: document.Headers{"tags": "urgent"},
+			Content: document.StringContent("<content>"),
+		})
+		m.Expect(protavo.IsInvalidDocumentError(err)).To(m.BeTrue())
+	})
+})
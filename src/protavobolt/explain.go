@@ -0,0 +1,80 @@
+package protavobolt
+
+import (
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavo/filter"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+const (
+	// StrategyNoop is the name of the query strategy used when a filter can not
+	// match any documents.
+	StrategyNoop = "noop"
+
+	// StrategyScanRecords is the name of the query strategy that iterates over
+	// all records and passes them through the filter in memory.
+	StrategyScanRecords = "scan-records"
+
+	// StrategyUseIDFirst is the name of the query strategy that retreives records
+	// by their ID, then applies the remaining conditions in memory.
+	StrategyUseIDFirst = "use-id-first"
+
+	// StrategyUseUniqueKeyFirst is the name of the query strategy that retreives
+	// records by a unique key, then applies the remaining conditions in memory.
+	StrategyUseUniqueKeyFirst = "use-unique-key-first"
+
+	// StrategyUseKeysFirst is the name of the query strategy that finds the
+	// intersection of documents that have all of a specific set of keys, then
+	// applies the remaining conditions in memory.
+	StrategyUseKeysFirst = "use-keys-first"
+
+	// StrategyUseKeyPrefixFirst is the name of the query strategy that finds
+	// the documents that have any key with a specific prefix by seeking within
+	// the key index, then applies the remaining conditions in memory.
+	StrategyUseKeyPrefixFirst = "use-key-prefix-first"
+
+	// StrategyUseTextFirst is the name of the query strategy that finds the
+	// documents that have the least-used of a specific set of text terms, then
+	// applies the remaining conditions in memory.
+	StrategyUseTextFirst = "use-text-first"
+
+	// StrategyUseHeaderFirst is the name of the query strategy that finds the
+	// documents that have a specific indexed header, then applies the remaining
+	// conditions in memory.
+	StrategyUseHeaderFirst = "use-header-first"
+
+	// StrategyUseTimeRangeFirst is the name of the query strategy that finds the
+	// documents that were created, or last updated, within a specific time range
+	// using a time-ordered index, then applies the remaining conditions in
+	// memory.
+	StrategyUseTimeRangeFirst = "use-time-range-first"
+
+	// StrategyUseContentTypeFirst is the name of the query strategy that finds
+	// the documents with content of a specific set of types, then applies the
+	// remaining conditions in memory.
+	StrategyUseContentTypeFirst = "use-content-type-first"
+)
+
+// executeExplain returns the query plan used to find the documents that match
+// f.
+func executeExplain(
+	tx *database.Tx,
+	ns string,
+	f *filter.Filter,
+) (*driver.QueryPlan, error) {
+	s, ok, err := database.OpenStore(tx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		// there is nothing to search if the namespace does not exist
+		return &driver.QueryPlan{
+			Filter:   filter.Optimize(f),
+			Strategy: StrategyNoop,
+		}, nil
+	}
+
+	_, plan := planStrategy(s, f)
+	return plan, nil
+}
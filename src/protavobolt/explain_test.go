@@ -0,0 +1,278 @@
+package protavobolt_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/filter"
+	. "github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	m "github.com/onsi/gomega"
+)
+
+var _ = g.Describe("Explain", func() {
+	ctx := context.Background()
+	var (
+		dir string
+		db  *protavo.DB
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "protavobolt-")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		bdb, err := bolt.Open(path.Join(dir, "bolt.db"), 0600, nil)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		db, err = protavo.NewDB(
+			&ExclusiveDriver{
+				DB: bdb,
+				TextIndex: (&document.TextIndex{}).
+					AddFields(&document.StringContentType{}, "value"),
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = db.Save(
+			ctx,
+			&document.Document{
+				ID: "doc-1",
+				Keys: document.Keys{
+					"uniq-1": document.UniqueKey,
+					"shar-a": document.SharedKey,
+					"shar-b": document.SharedKey,
+				},
+				Content: document.StringContent("apple banana"),
+			},
+			&document.Document{
+				ID:      "doc-2",
+				Keys:    document.SharedKeys("shar-a"),
+				Content: document.StringContent("banana"),
+			},
+			&document.Document{
+				ID:      "doc-3",
+				Keys:    document.SharedKeys("shar-a"),
+				Content: document.StringContent("banana"),
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.AfterEach(func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	table.DescribeTable(
+		"selects the cheapest strategy",
+		func(
+			c []filter.Condition,
+			strategy string,
+			cost int,
+			isFullScan bool,
+		) {
+			plan, err := db.Explain(ctx, c...)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(plan.Strategy).To(m.Equal(strategy))
+			m.Expect(plan.Cost).To(m.Equal(cost))
+			m.Expect(plan.IsFullScan).To(m.Equal(isFullScan))
+		},
+		table.Entry(
+			"no conditions",
+			[]filter.Condition{},
+			StrategyNoop,
+			0,
+			false,
+		),
+		table.Entry(
+			"IsOneOf",
+			[]filter.Condition{
+				protavo.IsOneOf("doc-1"),
+			},
+			StrategyUseIDFirst,
+			1,
+			false,
+		),
+		table.Entry(
+			"HasUniqueKeyIn",
+			[]filter.Condition{
+				protavo.HasUniqueKeyIn("uniq-1"),
+			},
+			StrategyUseUniqueKeyFirst,
+			1,
+			false,
+		),
+		table.Entry(
+			"HasKeys",
+			[]filter.Condition{
+				protavo.HasKeys("shar-b"),
+			},
+			StrategyUseKeysFirst,
+			1,
+			false,
+		),
+		table.Entry(
+			"HasKeyWithPrefix",
+			[]filter.Condition{
+				protavo.HasKeyWithPrefix("uniq-"),
+			},
+			StrategyUseKeyPrefixFirst,
+			1,
+			false,
+		),
+		table.Entry(
+			"HasKeyWithPrefix with keys that are used more times than there are documents",
+			[]filter.Condition{
+				protavo.HasKeyWithPrefix("shar-"),
+			},
+			StrategyScanRecords,
+			3,
+			true,
+		),
+		table.Entry(
+			"IsOneOf with fewer IDs than documents that have a key",
+			[]filter.Condition{
+				protavo.IsOneOf("doc-1", "doc-2"),
+				protavo.HasKeys("shar-a"),
+			},
+			StrategyUseIDFirst,
+			2,
+			false,
+		),
+		table.Entry(
+			"HasKeys with a key that is used by fewer documents than there are IDs",
+			[]filter.Condition{
+				protavo.IsOneOf("doc-1", "doc-2", "doc-3"),
+				protavo.HasKeys("shar-a", "shar-b"),
+			},
+			StrategyUseKeysFirst,
+			1,
+			false,
+		),
+		table.Entry(
+			"HasKeys with a key that is not used by any documents",
+			[]filter.Condition{
+				protavo.IsOneOf("doc-1"),
+				protavo.HasKeys("shar-a", "non-existent"),
+			},
+			StrategyUseKeysFirst,
+			0,
+			false,
+		),
+		table.Entry(
+			"MatchesText",
+			[]filter.Condition{
+				protavo.MatchesText("apple"),
+			},
+			StrategyUseTextFirst,
+			1,
+			false,
+		),
+		table.Entry(
+			"MatchesText with a term that is used by fewer documents than there are IDs",
+			[]filter.Condition{
+				protavo.IsOneOf("doc-1", "doc-2"),
+				protavo.MatchesText("banana apple"),
+			},
+			StrategyUseTextFirst,
+			1,
+			false,
+		),
+		table.Entry(
+			"MatchesText with a term that is not used by any documents",
+			[]filter.Condition{
+				protavo.MatchesText("banana cherry"),
+			},
+			StrategyUseTextFirst,
+			0,
+			false,
+		),
+		table.Entry(
+			"IsContentType",
+			[]filter.Condition{
+				protavo.IsContentType(&document.StringContentType{}),
+			},
+			StrategyUseContentTypeFirst,
+			3,
+			false,
+		),
+		table.Entry(
+			"IsContentType with a type that is not used by any documents",
+			[]filter.Condition{
+				protavo.IsContentTypeURL("type.googleapis.com/non-existent"),
+			},
+			StrategyUseContentTypeFirst,
+			0,
+			false,
+		),
+		table.Entry(
+			"CreatedBetween",
+			[]filter.Condition{
+				protavo.CreatedBetween(time.Now().Add(-time.Hour), time.Time{}),
+			},
+			StrategyUseTimeRangeFirst,
+			3,
+			false,
+		),
+		table.Entry(
+			"UpdatedBetween with a range that does not contain any documents",
+			[]filter.Condition{
+				protavo.UpdatedBetween(time.Time{}, time.Now().Add(-time.Hour)),
+			},
+			StrategyUseTimeRangeFirst,
+			0,
+			false,
+		),
+		table.Entry(
+			"UpdatedBetween with more documents than there are IDs",
+			[]filter.Condition{
+				protavo.IsOneOf("doc-1"),
+				protavo.UpdatedBetween(time.Now().Add(-time.Hour), time.Time{}),
+			},
+			StrategyUseIDFirst,
+			1,
+			false,
+		),
+		table.Entry(
+			"UpdatedBetween with disjoint ranges",
+			[]filter.Condition{
+				protavo.UpdatedBetween(time.Time{}, time.Now()),
+				protavo.UpdatedBetween(time.Now().Add(time.Hour), time.Time{}),
+			},
+			StrategyNoop,
+			0,
+			false,
+		),
+	)
+
+	g.It("uses a full scan when there is no filter", func() {
+		op := protavo.Explain()
+		op.Filter = nil
+
+		err := db.Read(ctx, op)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(op.Plan.Strategy).To(m.Equal(StrategyScanRecords))
+		m.Expect(op.Plan.Cost).To(m.Equal(3))
+		m.Expect(op.Plan.IsFullScan).To(m.BeTrue())
+	})
+
+	g.It("keeps the cost estimates up-to-date as documents are deleted", func() {
+		_, err := db.DeleteWhere(ctx, protavo.HasKeys("shar-a"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		op := protavo.Explain()
+		op.Filter = nil
+
+		err = db.Read(ctx, op)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(op.Plan.Cost).To(m.Equal(0))
+	})
+})
@@ -1,7 +1,8 @@
 package protavobolt
 
 import (
-	bolt "github.com/coreos/bbolt"
+	"context"
+
 	"github.com/jmalloc/protavo/src/protavo/driver"
 	"github.com/jmalloc/protavo/src/protavo/filter"
 	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
@@ -9,17 +10,22 @@ import (
 
 // executeFetch calls fn for each document that matches f.
 func executeFetch(
-	tx *bolt.Tx,
+	ctx context.Context,
+	tx *database.Tx,
 	ns string,
 	f *filter.Filter,
 	fn driver.FetchFunc,
 ) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	s, ok, err := database.OpenStore(tx, ns)
 	if !ok || err != nil {
 		return err
 	}
 
-	return selectStrategy(s, f).Fetch(fn)
+	return selectStrategy(s, f).Fetch(ctx, fn)
 }
 
 // applyFetch executes the side-effects of a fetch operation.
@@ -29,12 +35,7 @@ func applyFetch(
 	rec *database.Record,
 	fn driver.FetchFunc,
 ) (bool, error) {
-	c, err := s.GetContent(id)
-	if err != nil {
-		return false, err
-	}
-
-	doc, err := newDocument(id, rec, c)
+	doc, _, err := loadDocument(s, id, rec)
 	if err != nil {
 		return false, err
 	}
@@ -43,15 +44,19 @@ func applyFetch(
 }
 
 // Fetch is the implementation of the "no-op" strategy for fetching.
-func (*noop) Fetch(fn driver.FetchFunc) error {
+func (*noop) Fetch(context.Context, driver.FetchFunc) error {
 	return nil
 }
 
 // Fetch is the implementation of the "scan records" strategy for fetching.
-func (qs *scanRecords) Fetch(fn driver.FetchFunc) error {
+func (qs *scanRecords) Fetch(ctx context.Context, fn driver.FetchFunc) error {
 	cur := qs.store.Records.Cursor()
 
 	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		rec, err := database.UnmarshalRecord(v)
 		if err != nil {
 			return err
@@ -59,7 +64,10 @@ func (qs *scanRecords) Fetch(fn driver.FetchFunc) error {
 
 		id := string(k)
 
-		if !isFilterSatisfiedByRecord(qs.filter, id, rec) {
+		match, err := isFilterSatisfiedByRecord(qs.store, qs.filter, id, rec)
+		if err != nil {
+			return err
+		} else if !match {
 			continue
 		}
 
@@ -74,8 +82,14 @@ func (qs *scanRecords) Fetch(fn driver.FetchFunc) error {
 
 // Fetch is the implementation of the "use document ID first" strategy for
 // fetching.
-func (qs *useIDFirst) Fetch(fn driver.FetchFunc) error {
-	for id := range qs.conds.ExtractIsOneOf().Values {
+func (qs *useIDFirst) Fetch(ctx context.Context, fn driver.FetchFunc) error {
+	c, conds := qs.conds.ExtractIsOneOf()
+
+	for id := range c.Values {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		rec, exists, err := qs.store.TryGetRecord(id)
 		if err != nil {
 			return err
@@ -85,7 +99,10 @@ func (qs *useIDFirst) Fetch(fn driver.FetchFunc) error {
 			continue
 		}
 
-		if !qs.conds.AreSatisfiedBy(id, rec) {
+		match, err := conds.AreSatisfiedBy(qs.store, id, rec)
+		if err != nil {
+			return err
+		} else if !match {
 			continue
 		}
 
@@ -100,14 +117,15 @@ func (qs *useIDFirst) Fetch(fn driver.FetchFunc) error {
 
 // Fetch is the implementation of the "use unique key first" strategy for
 // fetching.
-func (qs *useUniqueKeyFirst) Fetch(fn driver.FetchFunc) error {
-	for key := range qs.conds.ExtractHasUniqueKeyIn().Values {
-		k, err := qs.store.GetKey(key)
-		if err != nil {
+func (qs *useUniqueKeyFirst) Fetch(ctx context.Context, fn driver.FetchFunc) error {
+	c, conds := qs.conds.ExtractHasUniqueKeyIn()
+
+	for key := range c.Values {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
 
-		id, ok := k.GetUniqueDocumentID()
+		id, ok := qs.store.GetUniqueKeyDocumentID(key)
 		if !ok {
 			continue
 		}
@@ -117,7 +135,10 @@ func (qs *useUniqueKeyFirst) Fetch(fn driver.FetchFunc) error {
 			return err
 		}
 
-		if !qs.conds.AreSatisfiedBy(id, rec) {
+		match, err := conds.AreSatisfiedBy(qs.store, id, rec)
+		if err != nil {
+			return err
+		} else if !match {
 			continue
 		}
 
@@ -130,20 +151,24 @@ func (qs *useUniqueKeyFirst) Fetch(fn driver.FetchFunc) error {
 	return nil
 }
 
-// Fetch is the implementation of the "use keys first" strategy for fetching.
-func (qs *useKeysFirst) Fetch(fn driver.FetchFunc) error {
-	ids, err := qs.findDocumentIDs()
-	if err != nil {
-		return err
-	}
+// Fetch is the implementation of the "use index first" strategy for fetching.
+func (qs *useIndexFirst) Fetch(ctx context.Context, fn driver.FetchFunc) error {
+	ids, conds := qs.index.candidates(qs.store)
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-	for id := range ids {
 		rec, err := qs.store.GetRecord(id)
 		if err != nil {
 			return err
 		}
 
-		if !qs.conds.AreSatisfiedBy(id, rec) {
+		match, err := conds.AreSatisfiedBy(qs.store, id, rec)
+		if err != nil {
+			return err
+		} else if !match {
 			continue
 		}
 
@@ -1,29 +1,37 @@
 package protavobolt
 
 import (
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
 	"github.com/jmalloc/protavo/src/protavo/filter"
 	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
 )
 
 // isFilterSatisfiedByRecord checks if a record matches a filter.
+//
+// Conditions that can not be checked using the record alone are checked using
+// the indexes in s, or failing that, the document's content.
 func isFilterSatisfiedByRecord(
+	s *database.Store,
 	c filter.Condition,
 	id string,
 	rec *database.Record,
-) bool {
-	ok, err := c.Accept(&recordMatcher{id, rec})
-	if err != nil {
-		panic(err)
-	}
-
-	return ok
+) (bool, error) {
+	return c.Accept(&recordMatcher{store: s, id: id, rec: rec})
 }
 
 // recordMatcher is a filter.Visitor that matches a filter against a document
 // record.
 type recordMatcher struct {
-	id  string
-	rec *database.Record
+	store *database.Store
+	id    string
+	rec   *database.Record
+
+	// content is the document's content. It is loaded the first time a
+	// condition that can not be checked using the record or the indexes is
+	// checked.
+	content *database.Content
 }
 
 func (m *recordMatcher) IsOneOf(c *filter.IsOneOf) (bool, error) {
@@ -62,3 +70,127 @@ func (m *recordMatcher) HasKeys(c *filter.HasKeys) (bool, error) {
 
 	return true, nil
 }
+
+func (m *recordMatcher) HasKeyWithPrefix(c *filter.HasKeyWithPrefix) (bool, error) {
+	for k := range m.rec.Keys {
+		if strings.HasPrefix(k, c.Prefix) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *recordMatcher) MatchesText(c *filter.MatchesText) (bool, error) {
+	for t := range c.Terms {
+		if !m.store.HasTerm(t, m.id) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (m *recordMatcher) HasHeader(c *filter.HasHeader) (bool, error) {
+	h, err := m.getHeaders(c.Name)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := h[c.Name]
+	return ok, nil
+}
+
+func (m *recordMatcher) HeaderEquals(c *filter.HeaderEquals) (bool, error) {
+	h, err := m.getHeaders(c.Name)
+	if err != nil {
+		return false, err
+	}
+
+	v, ok := h[c.Name]
+	return ok && v == c.Value, nil
+}
+
+func (m *recordMatcher) HeaderIn(c *filter.HeaderIn) (bool, error) {
+	h, err := m.getHeaders(c.Name)
+	if err != nil {
+		return false, err
+	}
+
+	v, ok := h[c.Name]
+	if !ok {
+		return false, nil
+	}
+
+	_, ok = c.Values[v]
+	return ok, nil
+}
+
+func (m *recordMatcher) CreatedBetween(c *filter.CreatedBetween) (bool, error) {
+	t, err := ptypes.Timestamp(m.rec.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	return c.Contains(t), nil
+}
+
+func (m *recordMatcher) UpdatedBetween(c *filter.UpdatedBetween) (bool, error) {
+	t, err := ptypes.Timestamp(m.rec.UpdatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	return c.Contains(t), nil
+}
+
+func (m *recordMatcher) IsContentType(c *filter.IsContentType) (bool, error) {
+	url := m.rec.ContentType
+
+	// documents saved before content types were indexed do not have the content
+	// type in their record
+	if url == "" {
+		content, err := m.getContent()
+		if err != nil {
+			return false, err
+		}
+
+		url = content.Content.GetTypeUrl()
+	}
+
+	_, ok := c.TypeURLs[url]
+	return ok, nil
+}
+
+// getHeaders returns a set of headers that includes the header with the given
+// name, if the document has it.
+//
+// Indexed headers are read from the record. Otherwise, the document's content
+// is loaded.
+func (m *recordMatcher) getHeaders(name string) (map[string]string, error) {
+	if m.store.IsHeaderIndexed(name) {
+		return m.rec.Headers, nil
+	}
+
+	c, err := m.getContent()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Headers, nil
+}
+
+// getContent returns the document's content, loading it if necessary, which
+// may require it to be decompressed and decrypted.
+func (m *recordMatcher) getContent() (*database.Content, error) {
+	if m.content == nil {
+		c, err := m.store.GetContent(m.id)
+		if err != nil {
+			return nil, err
+		}
+
+		m.content = c
+	}
+
+	return m.content, nil
+}
@@ -0,0 +1,36 @@
+package protavobolt
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// RebuildHeaderIndex re-indexes the headers of every document, in all
+// namespaces.
+//
+// The header index is maintained automatically as documents are saved and
+// deleted. A header's index is not used to find documents within a namespace
+// that already contained documents when the header was added to
+// d.IndexedHeaders, until the index has been rebuilt.
+//
+// It is performed within a single transaction. It returns the number of
+// documents that were indexed.
+func (d *ExclusiveDriver) RebuildHeaderIndex(ctx context.Context) (int, error) {
+	return d.reindex(
+		ctx,
+		func(s *database.Store, rec *database.Record, doc *document.Document) error {
+			headers := s.IndexedHeaders(doc.Headers)
+
+			if err := s.UpdateHeaders(doc.ID, rec.Headers, headers); err != nil {
+				return err
+			}
+
+			rec.Headers = headers
+
+			return s.PutRecord(doc.ID, rec)
+		},
+		(*database.Store).MarkHeaderIndexesComplete,
+	)
+}
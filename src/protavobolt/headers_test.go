@@ -0,0 +1,202 @@
+package protavobolt_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver/drivertest"
+	. "github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+func init() {
+	var dir string
+
+	drivertest.Describe(
+		"protavobolt.ExclusiveDriver (indexed headers)",
+		func() (*protavo.DB, error) {
+			var err error
+			dir, err = ioutil.TempDir("", "protavobolt-")
+			if err != nil {
+				return nil, err
+			}
+
+			db, _, err := openIndexed(path.Join(dir, "bolt.db"), "tags")
+			return db, err
+		},
+		func() {
+			_ = os.RemoveAll(dir)
+		},
+	)
+}
+
+var _ = g.Describe("ExclusiveDriver.IndexedHeaders", func() {
+	var (
+		ctx = context.Background()
+		dir string
+		db  *protavo.DB
+		d   *ExclusiveDriver
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "protavobolt-")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		db, d, err = openIndexed(path.Join(dir, "bolt.db"), "tags")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = db.Save(
+			ctx,
+			&document.Document{
+				ID:      "doc-1",
+				Headers: document.Headers{"tags": "urgent", "other": "x"},
+				Content: document.StringContent("<content>"),
+			},
+			&document.Document{
+				ID:      "doc-2",
+				Headers: document.Headers{"tags": "later", "other": "x"},
+				Content: document.StringContent("<content>"),
+			},
+			&document.Document{
+				ID:      "doc-3",
+				Content: document.StringContent("<content>"),
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.AfterEach(func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	g.It("uses the index for conditions on indexed headers", func() {
+		plan, err := db.Explain(ctx, protavo.HeaderEquals("tags", "urgent"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(plan.Strategy).To(m.Equal(StrategyUseHeaderFirst))
+		m.Expect(plan.Cost).To(m.Equal(1))
+
+		plan, err = db.Explain(ctx, protavo.HasHeader("tags"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(plan.Strategy).To(m.Equal(StrategyUseHeaderFirst))
+		m.Expect(plan.Cost).To(m.Equal(2))
+	})
+
+	g.It("scans all records for conditions on unindexed headers", func() {
+		plan, err := db.Explain(ctx, protavo.HeaderEquals("other", "x"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(plan.Strategy).To(m.Equal(StrategyScanRecords))
+		m.Expect(plan.IsFullScan).To(m.BeTrue())
+	})
+
+	g.It("removes documents from the index when their headers change", func() {
+		doc, ok, err := db.Load(ctx, "doc-1")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(ok).To(m.BeTrue())
+
+		doc.Headers = nil
+		err = db.Save(ctx, doc)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		docs, err := db.LoadManyWhere(ctx, protavo.HasHeader("tags"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(docs).To(m.HaveLen(1))
+		m.Expect(docs[0].ID).To(m.Equal("doc-2"))
+	})
+
+	g.When("a header is indexed after documents have been saved", func() {
+		g.BeforeEach(func() {
+			d.IndexedHeaders = append(d.IndexedHeaders, "other")
+
+			// save a document so that the new header is indexed by at least
+			// one write
+			err := db.Save(ctx, &document.Document{
+				ID:      "doc-4",
+				Headers: document.Headers{"other": "x"},
+				Content: document.StringContent("<content>"),
+			})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+		})
+
+		g.It("does not use the incomplete index", func() {
+			plan, err := db.Explain(ctx, protavo.HeaderEquals("other", "x"))
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(plan.Strategy).To(m.Equal(StrategyScanRecords))
+		})
+
+		g.It("finds the documents that were saved before the header was indexed", func() {
+			docs, err := db.LoadManyWhere(
+				ctx,
+				protavo.HeaderEquals("other", "x"),
+				protavo.HasHeader("tags"),
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(docs).To(m.HaveLen(2))
+		})
+	})
+
+	g.It("stops using the index for a header that is no longer indexed while the namespace is modified", func() {
+		d.IndexedHeaders = nil
+
+		err := db.Save(ctx, &document.Document{
+			ID:      "doc-4",
+			Headers: document.Headers{"tags": "urgent"},
+			Content: document.StringContent("<content>"),
+		})
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		d.IndexedHeaders = []string{"tags"}
+
+		plan, err := db.Explain(ctx, protavo.HeaderEquals("tags", "urgent"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(plan.Strategy).To(m.Equal(StrategyScanRecords))
+
+		docs, err := db.LoadManyWhere(ctx, protavo.HeaderEquals("tags", "urgent"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(docs).To(m.HaveLen(2))
+	})
+
+	g.Describe("RebuildHeaderIndex", func() {
+		g.It("indexes headers that were added after the documents were saved", func() {
+			d.IndexedHeaders = append(d.IndexedHeaders, "other")
+
+			n, err := d.RebuildHeaderIndex(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(n).To(m.Equal(3))
+
+			plan, err := db.Explain(ctx, protavo.HeaderEquals("other", "x"))
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(plan.Strategy).To(m.Equal(StrategyUseHeaderFirst))
+			m.Expect(plan.Cost).To(m.Equal(2))
+
+			docs, err := db.LoadManyWhere(ctx, protavo.HeaderEquals("other", "x"))
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(docs).To(m.HaveLen(2))
+		})
+	})
+})
+
+// openIndexed returns a database that uses a driver that indexes the headers
+// with the given names.
+func openIndexed(file string, headers ...string) (*protavo.DB, *ExclusiveDriver, error) {
+	bdb, err := bolt.Open(file, 0600, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d := &ExclusiveDriver{
+		DB:             bdb,
+		IndexedHeaders: headers,
+		TextIndex:      drivertest.TextIndex,
+	}
+
+	db, err := protavo.NewDB(d)
+	return db, d, err
+}
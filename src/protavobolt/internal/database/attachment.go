@@ -0,0 +1,343 @@
+package database
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/golang/protobuf/proto"
+	"github.com/jmalloc/protavo/src/protavo"
+)
+
+// attachmentChunkSize is the maximum size of each of the chunks in which the
+// content of an attachment is stored.
+const attachmentChunkSize = 64 * 1024
+
+// attachmentMetaKey is the key under which the Attachment message is stored
+// within an attachment's bucket. It does not conflict with the chunk keys,
+// which are always 8 bytes long.
+var attachmentMetaKey = []byte("meta")
+
+// PutAttachment stores an attachment of the document with the given ID,
+// replacing any existing attachment with the same name.
+//
+// The content is read from r and stored in chunks. If the transaction that the
+// store was opened in has a sealer, each chunk is encrypted.
+func (s *Store) PutAttachment(id, name string, r io.Reader) error {
+	if err := s.deleteAttachment(id, name); err != nil {
+		return err
+	}
+
+	db, err := s.Attachments.CreateBucketIfNotExists([]byte(id))
+	if err != nil {
+		return err
+	}
+
+	ab, err := db.CreateBucketIfNotExists([]byte(name))
+	if err != nil {
+		return err
+	}
+
+	meta := &Attachment{
+		Sealed: s.sealer != nil,
+	}
+
+	for i := uint64(0); ; i++ {
+		// a new buffer is needed for each chunk, as BoltDB retains the values
+		// passed to Put() until the transaction is committed
+		buf := make([]byte, attachmentChunkSize)
+
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			meta.Size += uint64(n)
+
+			if err := s.putChunk(ab, id, name, i, buf[:n]); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	buf, err := proto.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	return ab.Put(attachmentMetaKey, buf)
+}
+
+// GetAttachment writes the content of an attachment of the document with the
+// given ID to w.
+//
+// It returns false if the attachment does not exist.
+func (s *Store) GetAttachment(id, name string, w io.Writer) (bool, error) {
+	ab, meta, err := s.openAttachment(id, name)
+	if ab == nil || err != nil {
+		return false, err
+	}
+
+	if meta.Sealed && s.sealer == nil {
+		return false, fmt.Errorf(
+			"attachment '%s' of '%s' is encrypted, but no key provider is configured",
+			name,
+			id,
+		)
+	}
+
+	var size uint64
+
+	for i := uint64(0); size < meta.Size; i++ {
+		buf := ab.Get(chunkKey(i))
+		if buf == nil {
+			return false, &protavo.DataIntegrityError{
+				Namespace:  s.ns,
+				Bucket:     string(attachmentsBucket),
+				DocumentID: id,
+				Description: fmt.Sprintf(
+					"chunk %d of attachment '%s' of '%s' is missing",
+					i,
+					name,
+					id,
+				),
+			}
+		}
+
+		if meta.Sealed {
+			var m Sealed
+			if err := proto.Unmarshal(buf, &m); err != nil {
+				return false, err
+			}
+
+			buf, err = s.sealer.Open(&m, s.chunkAAD(id, name, i))
+			if err != nil {
+				return false, fmt.Errorf(
+					"unable to decrypt attachment '%s' of '%s': %s",
+					name,
+					id,
+					err,
+				)
+			}
+		}
+
+		if _, err := w.Write(buf); err != nil {
+			return false, err
+		}
+
+		size += uint64(len(buf))
+	}
+
+	return true, nil
+}
+
+// ListAttachments calls fn for each attachment of the document with the given
+// ID, in order of their name.
+func (s *Store) ListAttachments(id string, fn func(name string, size uint64) error) error {
+	db := s.Attachments.Bucket([]byte(id))
+	if db == nil {
+		return nil
+	}
+
+	return db.ForEach(func(k, _ []byte) error {
+		name := string(k)
+
+		_, meta, err := s.openAttachment(id, name)
+		if err != nil {
+			return err
+		}
+
+		return fn(name, meta.Size)
+	})
+}
+
+// DeleteAttachment deletes an attachment of the document with the given ID.
+// It is not an error to delete a non-existent attachment.
+func (s *Store) DeleteAttachment(id, name string) error {
+	if err := s.deleteAttachment(id, name); err != nil {
+		return err
+	}
+
+	db := s.Attachments.Bucket([]byte(id))
+	if db == nil {
+		return nil
+	}
+
+	// remove the document's bucket once it has no attachments left
+	if k, _ := db.Cursor().First(); k == nil {
+		return s.DeleteAttachments(id)
+	}
+
+	return nil
+}
+
+// DeleteAttachments deletes all of the attachments of the document with the
+// given ID.
+func (s *Store) DeleteAttachments(id string) error {
+	err := s.Attachments.DeleteBucket([]byte(id))
+
+	if err == bolt.ErrBucketNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// resealAttachments re-encrypts the attachments in the store that are not
+// encrypted with the sealer's current key.
+func (s *Store) resealAttachments() error {
+	type attachment struct{ id, name string }
+	var stale []attachment
+
+	if err := s.Attachments.ForEach(func(id, _ []byte) error {
+		return s.Attachments.Bucket(id).ForEach(func(name, _ []byte) error {
+			ok, err := s.isSealedWithCurrentKey(string(id), string(name))
+			if !ok && err == nil {
+				stale = append(stale, attachment{string(id), string(name)})
+			}
+
+			return err
+		})
+	}); err != nil {
+		return err
+	}
+
+	for _, a := range stale {
+		var buf bytes.Buffer
+
+		if _, err := s.GetAttachment(a.id, a.name, &buf); err != nil {
+			return err
+		}
+
+		if err := s.PutAttachment(a.id, a.name, &buf); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isSealedWithCurrentKey returns true if every chunk of an attachment is
+// encrypted with the sealer's current key.
+func (s *Store) isSealedWithCurrentKey(id, name string) (bool, error) {
+	ab, meta, err := s.openAttachment(id, name)
+	if err != nil || !meta.Sealed {
+		return false, err
+	}
+
+	current := true
+
+	err = ab.ForEach(func(k, v []byte) error {
+		if len(k) != len(chunkKey(0)) {
+			return nil
+		}
+
+		var m Sealed
+		if err := proto.Unmarshal(v, &m); err != nil {
+			return err
+		}
+
+		if !s.sealer.IsCurrent(&m) {
+			current = false
+		}
+
+		return nil
+	})
+
+	return current, err
+}
+
+// deleteAttachment deletes an attachment of the document with the given ID,
+// without removing the document's bucket.
+func (s *Store) deleteAttachment(id, name string) error {
+	db := s.Attachments.Bucket([]byte(id))
+	if db == nil {
+		return nil
+	}
+
+	err := db.DeleteBucket([]byte(name))
+
+	if err == bolt.ErrBucketNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// openAttachment returns the bucket and metadata of an attachment of the
+// document with the given ID. It returns a nil bucket if the attachment does
+// not exist.
+func (s *Store) openAttachment(id, name string) (*Bucket, *Attachment, error) {
+	db := s.Attachments.Bucket([]byte(id))
+	if db == nil {
+		return nil, nil, nil
+	}
+
+	ab := db.Bucket([]byte(name))
+	if ab == nil {
+		return nil, nil, nil
+	}
+
+	buf := ab.Get(attachmentMetaKey)
+	if buf == nil {
+		return nil, nil, &protavo.DataIntegrityError{
+			Namespace:  s.ns,
+			Bucket:     string(attachmentsBucket),
+			DocumentID: id,
+			Description: fmt.Sprintf(
+				"metadata for attachment '%s' of '%s' is missing",
+				name,
+				id,
+			),
+		}
+	}
+
+	var meta Attachment
+	if err := proto.Unmarshal(buf, &meta); err != nil {
+		return nil, nil, err
+	}
+
+	return ab, &meta, nil
+}
+
+// putChunk stores the i'th chunk of an attachment in ab, encrypting it if the
+// store has a sealer.
+func (s *Store) putChunk(ab *Bucket, id, name string, i uint64, buf []byte) error {
+	if s.sealer != nil {
+		m, err := s.sealer.Seal(buf, s.chunkAAD(id, name, i))
+		if err != nil {
+			return err
+		}
+
+		buf, err = proto.Marshal(m)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ab.Put(chunkKey(i), buf)
+}
+
+// chunkAAD returns the additional authenticated data used to encrypt the i'th
+// chunk of an attachment. It binds the ciphertext to its position within the
+// attachment, so that chunks can not be reordered or moved to a different
+// attachment.
+func (s *Store) chunkAAD(id, name string, i uint64) []byte {
+	return append(
+		[]byte(s.ns+"\x00"+id+"\x00"+name+"\x00"),
+		chunkKey(i)...,
+	)
+}
+
+// chunkKey returns the key under which the i'th chunk of an attachment is
+// stored.
+func chunkKey(i uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, i)
+	return k
+}
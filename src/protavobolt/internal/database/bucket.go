@@ -0,0 +1,260 @@
+package database
+
+import (
+	bolt "github.com/coreos/bbolt"
+)
+
+// Bucket is a BoltDB bucket that records changes in its transaction's journal,
+// so that they can be undone.
+type Bucket struct {
+	b    *bolt.Bucket
+	tx   *Tx
+	path [][]byte
+}
+
+// Get returns the value stored under k, or nil if there is no such value.
+func (b *Bucket) Get(k []byte) []byte {
+	return b.b.Get(k)
+}
+
+// Put stores v under k.
+func (b *Bucket) Put(k, v []byte) error {
+	if b.tx.isJournaling() {
+		b.tx.record(undoPut(b.path, k, b.b.Get(k)))
+	}
+
+	return b.b.Put(k, v)
+}
+
+// Delete deletes the value stored under k.
+func (b *Bucket) Delete(k []byte) error {
+	if b.tx.isJournaling() {
+		if v := b.b.Get(k); v != nil {
+			b.tx.record(undoPut(b.path, k, v))
+		}
+	}
+
+	return b.b.Delete(k)
+}
+
+// DeleteAt deletes the value at the current position of cur, which must be a
+// cursor over b. k must be the key at the cursor's current position.
+func (b *Bucket) DeleteAt(cur *bolt.Cursor, k []byte) error {
+	if b.tx.isJournaling() {
+		b.tx.record(undoPut(b.path, k, b.b.Get(k)))
+	}
+
+	return cur.Delete()
+}
+
+// Cursor returns a cursor over the values in the bucket.
+//
+// Changes made via the cursor are not journaled, use DeleteAt() to delete
+// values while iterating.
+func (b *Bucket) Cursor() *bolt.Cursor {
+	return b.b.Cursor()
+}
+
+// ForEach calls fn for each key/value pair in the bucket.
+func (b *Bucket) ForEach(fn func(k, v []byte) error) error {
+	return b.b.ForEach(fn)
+}
+
+// Bucket returns the nested bucket with the given name.
+// It returns nil if the bucket does not exist.
+func (b *Bucket) Bucket(name []byte) *Bucket {
+	nb := b.parent().Bucket(name)
+	if nb == nil {
+		return nil
+	}
+
+	return b.nested(nb, name)
+}
+
+// CreateBucketIfNotExists returns the nested bucket with the given name,
+// creating it if it does not exist.
+func (b *Bucket) CreateBucketIfNotExists(name []byte) (*Bucket, error) {
+	if nb := b.parent().Bucket(name); nb != nil {
+		return b.nested(nb, name), nil
+	}
+
+	nb, err := b.parent().CreateBucket(name)
+	if err != nil {
+		return nil, err
+	}
+
+	nested := b.nested(nb, name)
+
+	if b.tx.isJournaling() {
+		b.tx.record(undoCreateBucket(nested.path))
+	}
+
+	return nested, nil
+}
+
+// DeleteBucket deletes the nested bucket with the given name.
+func (b *Bucket) DeleteBucket(name []byte) error {
+	if b.tx.isJournaling() {
+		if nb := b.parent().Bucket(name); nb != nil {
+			b.tx.record(undoDeleteBucket(
+				b.nested(nb, name).path,
+				takeSnapshot(nb),
+			))
+		}
+	}
+
+	return b.parent().DeleteBucket(name)
+}
+
+// parent returns the underlying BoltDB object used to manipulate b's nested
+// buckets.
+func (b *Bucket) parent() interface {
+	Bucket([]byte) *bolt.Bucket
+	CreateBucket([]byte) (*bolt.Bucket, error)
+	DeleteBucket([]byte) error
+} {
+	if b.b == nil {
+		return b.tx.Bolt
+	}
+
+	return b.b
+}
+
+// nested returns a Bucket that wraps nb, a bucket nested within b.
+func (b *Bucket) nested(nb *bolt.Bucket, name []byte) *Bucket {
+	path := make([][]byte, len(b.path), len(b.path)+1)
+	copy(path, b.path)
+
+	return &Bucket{
+		b:    nb,
+		tx:   b.tx,
+		path: append(path, copyBytes(name)),
+	}
+}
+
+// undoPut returns an undo function that restores the value stored under k in
+// the bucket at the given path. If v is nil, the value is deleted.
+func undoPut(path [][]byte, k, v []byte) undo {
+	k = copyBytes(k)
+	v = copyBytes(v)
+
+	return func(tx *bolt.Tx) error {
+		b, err := resolve(tx, path)
+		if err != nil {
+			return err
+		}
+
+		if v == nil {
+			return b.Delete(k)
+		}
+
+		return b.Put(k, v)
+	}
+}
+
+// undoCreateBucket returns an undo function that deletes the bucket at the
+// given path.
+func undoCreateBucket(path [][]byte) undo {
+	return func(tx *bolt.Tx) error {
+		last := len(path) - 1
+
+		if last == 0 {
+			return tx.DeleteBucket(path[0])
+		}
+
+		b, err := resolve(tx, path[:last])
+		if err != nil {
+			return err
+		}
+
+		return b.DeleteBucket(path[last])
+	}
+}
+
+// undoDeleteBucket returns an undo function that recreates the bucket at the
+// given path, with the content captured by s.
+func undoDeleteBucket(path [][]byte, s *snapshot) undo {
+	return func(tx *bolt.Tx) error {
+		var (
+			b    *bolt.Bucket
+			err  error
+			last = len(path) - 1
+		)
+
+		if last == 0 {
+			b, err = tx.CreateBucket(path[0])
+		} else {
+			b, err = resolve(tx, path[:last])
+			if err == nil {
+				b, err = b.CreateBucket(path[last])
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return s.restore(b)
+	}
+}
+
+// snapshot is an in-memory copy of the content of a bucket, including its
+// nested buckets.
+type snapshot struct {
+	keys    [][]byte
+	values  [][]byte
+	names   [][]byte
+	buckets []*snapshot
+}
+
+// takeSnapshot returns a snapshot of the content of b.
+func takeSnapshot(b *bolt.Bucket) *snapshot {
+	s := &snapshot{}
+
+	_ = b.ForEach(func(k, v []byte) error {
+		if v == nil {
+			s.names = append(s.names, copyBytes(k))
+			s.buckets = append(s.buckets, takeSnapshot(b.Bucket(k)))
+		} else {
+			s.keys = append(s.keys, copyBytes(k))
+			s.values = append(s.values, copyBytes(v))
+		}
+
+		return nil
+	})
+
+	return s
+}
+
+// restore writes the content captured by s to b.
+func (s *snapshot) restore(b *bolt.Bucket) error {
+	for i, k := range s.keys {
+		if err := b.Put(k, s.values[i]); err != nil {
+			return err
+		}
+	}
+
+	for i, name := range s.names {
+		nb, err := b.CreateBucket(name)
+		if err != nil {
+			return err
+		}
+
+		if err := s.buckets[i].restore(nb); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyBytes returns a copy of buf. BoltDB values are only valid until the
+// next modification of the bucket, so anything retained in the journal must be
+// copied.
+func copyBytes(buf []byte) []byte {
+	if buf == nil {
+		return nil
+	}
+
+	return append([]byte{}, buf...)
+}
@@ -0,0 +1,153 @@
+package database
+
+import "encoding/binary"
+
+// The changes bucket contains two nested buckets that form an index of the
+// changes made to the documents in the store, ordered by sequence number.
+//
+// The log bucket maps each sequence number to the ID of the document that was
+// changed by the write with that sequence number. Only the most recent change
+// to each document is present. The sequence number of a document's most recent
+// change is stored in its record, or if it has been deleted, in the tombstones
+// bucket.
+
+//
+// The sequence number of each namespace is stored in the top-level sequences
+// bucket, rather than in the namespace's own bucket, so that it continues to
+// increase after the namespace is deleted. The sequence number at which each
+// namespace was most recently deleted is also recorded, so that a consumer of
+// the changes can tell that the changes since an earlier sequence number do
+// not include the deletion of every document.
+
+var (
+	sequencesBucket = []byte("protavo.sequences")
+	currentBucket   = []byte("current")
+	resetBucket     = []byte("reset")
+)
+
+// Sequence returns the sequence number of the most recent write to the store.
+func (s *Store) Sequence() uint64 {
+	return uint64(getCount(s.Sequences, namespaceKey(s.ns)))
+}
+
+// NextSequence returns the sequence number for a new write to the store.
+func (s *Store) NextSequence() (uint64, error) {
+	n := s.Sequence() + 1
+	return n, putCount(s.Sequences, namespaceKey(s.ns), int(n))
+}
+
+// NamespaceSequence returns the sequence number of the most recent write to
+// the ns namespace, and the sequence number at which the namespace was most
+// recently deleted. Either is 0 if there has been no such write.
+//
+// The sequence numbers are available even if the namespace's store does not
+// exist.
+func NamespaceSequence(tx *Tx, ns string) (current, reset uint64) {
+	b := tx.Bucket(sequencesBucket)
+	if b == nil {
+		return 0, 0
+	}
+
+	k := namespaceKey(ns)
+
+	if cb := b.Bucket(currentBucket); cb != nil {
+		current = uint64(getCount(cb, k))
+	}
+
+	if rb := b.Bucket(resetBucket); rb != nil {
+		reset = uint64(getCount(rb, k))
+	}
+
+	return current, reset
+}
+
+// recordReset records the deletion of the store with a new sequence number.
+func (s *Store) recordReset() error {
+	n, err := s.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	return putCount(s.Resets, namespaceKey(s.ns), int(n))
+}
+
+// RecordChange updates the changes index for a specific document.
+//
+// before is the document's record prior to the change, or nil if it is being
+// created. after is the record after the change, or nil if it is being
+// deleted, in which case a tombstone is recorded with a new sequence number.
+func (s *Store) RecordChange(id string, before, after *Record) error {
+	k := []byte(id)
+
+	// remove the document's previous change from the log, which may be its
+	// deletion if it is being re-created
+	if before != nil {
+		if before.Sequence != 0 {
+			if err := s.ChangeLog.Delete(marshalSequence(before.Sequence)); err != nil {
+				return err
+			}
+		}
+	} else if seq := s.Tombstones.Get(k); seq != nil {
+		if err := s.ChangeLog.Delete(seq); err != nil {
+			return err
+		}
+
+		if err := s.Tombstones.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	if after != nil {
+		return s.ChangeLog.Put(marshalSequence(after.Sequence), k)
+	}
+
+	n, err := s.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	seq := marshalSequence(n)
+
+	if err := s.ChangeLog.Put(seq, k); err != nil {
+		return err
+	}
+
+	return s.Tombstones.Put(k, seq)
+}
+
+// ScanChanges calls fn with the sequence number and document ID of each change
+// with a sequence number greater than since, in order.
+//
+// Iteration stops if fn returns false or a non-nil error.
+func (s *Store) ScanChanges(since uint64, fn func(seq uint64, id string) (bool, error)) error {
+	cur := s.ChangeLog.Cursor()
+
+	for k, v := cur.Seek(marshalSequence(since + 1)); k != nil; k, v = cur.Next() {
+		ok, err := fn(unmarshalSequence(k), string(v))
+		if !ok || err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// marshalSequence returns the binary representation of a sequence number,
+// which sorts in numeric order.
+func marshalSequence(n uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, n)
+	return buf
+}
+
+// unmarshalSequence returns the sequence number represented by buf.
+func unmarshalSequence(buf []byte) uint64 {
+	return binary.BigEndian.Uint64(buf)
+}
+
+// namespaceKey returns the key under which the sequence numbers of the ns
+// namespace are stored. BoltDB does not allow empty keys, so the name of every
+// namespace, including the root namespace, is prefixed with a dot.
+func namespaceKey(ns string) []byte {
+	return []byte("." + ns)
+}
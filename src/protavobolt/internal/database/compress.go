@@ -0,0 +1,12 @@
+package database
+
+// Compressor compresses and decompresses document content.
+type Compressor interface {
+	// Compress compresses data, which is the content of a document within the
+	// given namespace. It returns nil if the content should be stored
+	// uncompressed.
+	Compress(ns string, data []byte) (*Compressed, error)
+
+	// Decompress decompresses a message that was compressed by Compress().
+	Decompress(m *Compressed) ([]byte, error)
+}
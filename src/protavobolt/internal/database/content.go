@@ -4,33 +4,143 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/jmalloc/protavo/src/protavo"
 )
 
 // GetContent gets the content of the document with the given ID.
+//
+// If the content is encrypted or compressed, it is decrypted using the sealer
+// and decompressed using the compressor of the transaction that the store was
+// opened in.
 func (s *Store) GetContent(id string) (*Content, error) {
 	buf := s.Content.Get([]byte(id))
 	if buf == nil {
-		return nil, fmt.Errorf(
-			"data integrity error: content for '%s' is missing",
-			id,
-		)
+		return nil, &protavo.DataIntegrityError{
+			Namespace:   s.ns,
+			Bucket:      string(contentBucket),
+			DocumentID:  id,
+			Description: fmt.Sprintf("content for '%s' is missing", id),
+		}
 	}
 
-	var c Content
-	if err := proto.Unmarshal(buf, &c); err != nil {
+	c, err := unmarshalContent(buf)
+	if err != nil {
 		return nil, err
 	}
 
-	return &c, nil
+	if c.Sealed != nil {
+		if s.sealer == nil {
+			return nil, fmt.Errorf(
+				"content for '%s' is encrypted, but no key provider is configured",
+				id,
+			)
+		}
+
+		buf, err = s.sealer.Open(c.Sealed, s.aad(id))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to decrypt content for '%s': %s",
+				id,
+				err,
+			)
+		}
+
+		c, err = unmarshalContent(buf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Compressed != nil {
+		if s.compressor == nil {
+			return nil, fmt.Errorf(
+				"content for '%s' is compressed, but no compressor is configured",
+				id,
+			)
+		}
+
+		buf, err = s.compressor.Decompress(c.Compressed)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to decompress content for '%s': %s",
+				id,
+				err,
+			)
+		}
+
+		c, err = unmarshalContent(buf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
 }
 
 // PutContent saves the content for a document.
+//
+// If the transaction that the store was opened in has a compressor, the
+// content is compressed. If it has a sealer, the (possibly compressed) content
+// is then encrypted.
 func (s *Store) PutContent(id string, c *Content) error {
 	buf, err := proto.Marshal(c)
 	if err != nil {
 		return err
 	}
 
+	size := len(buf)
+
+	// env is the message that "wraps" the serialized content, if any
+	var env *Content
+
+	if s.compressor != nil {
+		m, err := s.compressor.Compress(s.ns, buf)
+		if err != nil {
+			return err
+		}
+
+		if m != nil {
+			env = &Content{Compressed: m}
+		}
+	}
+
+	if s.sealer != nil {
+		if env != nil {
+			buf, err = proto.Marshal(env)
+			if err != nil {
+				return err
+			}
+		}
+
+		m, err := s.sealer.Seal(buf, s.aad(id))
+		if err != nil {
+			return err
+		}
+
+		env = &Content{Sealed: m}
+	}
+
+	if env != nil {
+		env.OriginalSize = uint64(size)
+
+		buf, err = proto.Marshal(env)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.removeContentSize(id); err != nil {
+		return err
+	}
+
+	if err := addCount(s.Stats, contentBytesKey, size); err != nil {
+		return err
+	}
+
+	if err := addCount(s.Stats, storedBytesKey, len(buf)); err != nil {
+		return err
+	}
+
 	return s.Content.Put(
 		[]byte(id),
 		buf,
@@ -39,7 +149,64 @@ func (s *Store) PutContent(id string, c *Content) error {
 
 // DeleteContent deletes the content for the document with the given ID.
 func (s *Store) DeleteContent(id string) error {
+	if err := s.removeContentSize(id); err != nil {
+		return err
+	}
+
 	return s.Content.Delete(
 		[]byte(id),
 	)
 }
+
+// removeContentSize subtracts the size of the existing content for the
+// document with the given ID from the store's content size counters.
+func (s *Store) removeContentSize(id string) error {
+	buf := s.Content.Get([]byte(id))
+	if buf == nil {
+		return nil
+	}
+
+	original, stored, err := contentSize(buf)
+	if err != nil {
+		return err
+	}
+
+	if err := addCount(s.Stats, contentBytesKey, -original); err != nil {
+		return err
+	}
+
+	return addCount(s.Stats, storedBytesKey, -stored)
+}
+
+// aad returns the additional authenticated data used to encrypt the content of
+// the document with the given ID. It binds the ciphertext to the document, so
+// that encrypted content can not be moved to a different document.
+func (s *Store) aad(id string) []byte {
+	return []byte(s.ns + "\x00" + id)
+}
+
+// unmarshalContent unmarshals a Content message.
+func unmarshalContent(buf []byte) (*Content, error) {
+	var c Content
+
+	if err := proto.Unmarshal(buf, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// contentSize returns the original and stored size of the serialized content
+// in buf.
+func contentSize(buf []byte) (original, stored int, err error) {
+	c, err := unmarshalContent(buf)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if c.OriginalSize != 0 {
+		return int(c.OriginalSize), len(buf), nil
+	}
+
+	return len(buf), len(buf), nil
+}
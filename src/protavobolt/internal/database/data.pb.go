@@ -34,17 +34,37 @@ type Record struct {
 	CreatedAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	// updated_at is the time at which the document was last modified. The value
 	// is set automatically when the document is saved.
-	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// headers is the subset of the document's headers that are indexed. They
+	// are duplicated here so that conditions on indexed headers can be checked
+	// without loading the document's content.
+	Headers map[string]string `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// content_type is the type URL of the document's content. It is duplicated
+	// here so that the content type can be checked without loading the
+	// document's content. It is empty for documents saved before content types
+	// were indexed.
+	ContentType string `protobuf:"bytes,6,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// sequence is the namespace-wide sequence number of the write that last
+	// modified the document.
+	Sequence uint64 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// deleted_at is the time at which the document was soft-deleted. It is only
+	// set on the records of soft-deleted documents, which are not stored with
+	// the records of other documents.
+	DeletedAt *timestamp.Timestamp `protobuf:"bytes,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// derived_keys is the names of the keys that were derived from the
+	// document's content, rather than applied explicitly. They are included in
+	// keys, and are recomputed each time the document is saved.
+	DerivedKeys          []string `protobuf:"bytes,9,rep,name=derived_keys,json=derivedKeys,proto3" json:"derived_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Record) Reset()         { *m = Record{} }
 func (m *Record) String() string { return proto.CompactTextString(m) }
 func (*Record) ProtoMessage()    {}
 func (*Record) Descriptor() ([]byte, []int) {
-	return fileDescriptor_data_a587a6b6b981b685, []int{0}
+	return fileDescriptor_data_f9d82060dee54a7f, []int{0}
 }
 func (m *Record) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Record.Unmarshal(m, b)
@@ -92,13 +112,59 @@ func (m *Record) GetUpdatedAt() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *Record) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *Record) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *Record) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Record) GetDeletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
+}
+
+func (m *Record) GetDerivedKeys() []string {
+	if m != nil {
+		return m.DerivedKeys
+	}
+	return nil
+}
+
 // Content is container for a document's content.
 type Content struct {
 	// headers is an arbitrary set of key/value pairs that is persisted along
 	// with the document content.
 	Headers map[string]string `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// content is the application-defined document content.
-	Content              *any.Any `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Content *any.Any `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// sealed is the encrypted form of the document's content. If it is set, all
+	// other fields are empty, and the plaintext is a serialized Content message.
+	Sealed *Sealed `protobuf:"bytes,5,opt,name=sealed,proto3" json:"sealed,omitempty"`
+	// compressed is the compressed form of the document's content. If it is
+	// set, all other fields are empty, and the decompressed data is a serialized
+	// Content message.
+	Compressed *Compressed `protobuf:"bytes,6,opt,name=compressed,proto3" json:"compressed,omitempty"`
+	// original_size is the size of the serialized Content message that was
+	// sealed and/or compressed to produce this message. It is zero if the
+	// content is stored as-is.
+	OriginalSize         uint64   `protobuf:"varint,7,opt,name=original_size,json=originalSize,proto3" json:"original_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -108,7 +174,7 @@ func (m *Content) Reset()         { *m = Content{} }
 func (m *Content) String() string { return proto.CompactTextString(m) }
 func (*Content) ProtoMessage()    {}
 func (*Content) Descriptor() ([]byte, []int) {
-	return fileDescriptor_data_a587a6b6b981b685, []int{1}
+	return fileDescriptor_data_f9d82060dee54a7f, []int{1}
 }
 func (m *Content) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Content.Unmarshal(m, b)
@@ -142,7 +208,230 @@ func (m *Content) GetContent() *any.Any {
 	return nil
 }
 
+func (m *Content) GetSealed() *Sealed {
+	if m != nil {
+		return m.Sealed
+	}
+	return nil
+}
+
+func (m *Content) GetCompressed() *Compressed {
+	if m != nil {
+		return m.Compressed
+	}
+	return nil
+}
+
+func (m *Content) GetOriginalSize() uint64 {
+	if m != nil {
+		return m.OriginalSize
+	}
+	return 0
+}
+
+// Sealed is an encrypted message.
+type Sealed struct {
+	// key_id identifies the key that was used to encrypt the message.
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// nonce is the nonce that was used to encrypt the message.
+	Nonce []byte `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// ciphertext is the encrypted message.
+	Ciphertext           []byte   `protobuf:"bytes,3,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Sealed) Reset()         { *m = Sealed{} }
+func (m *Sealed) String() string { return proto.CompactTextString(m) }
+func (*Sealed) ProtoMessage()    {}
+func (*Sealed) Descriptor() ([]byte, []int) {
+	return fileDescriptor_data_f9d82060dee54a7f, []int{2}
+}
+func (m *Sealed) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Sealed.Unmarshal(m, b)
+}
+func (m *Sealed) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Sealed.Marshal(b, m, deterministic)
+}
+func (dst *Sealed) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sealed.Merge(dst, src)
+}
+func (m *Sealed) XXX_Size() int {
+	return xxx_messageInfo_Sealed.Size(m)
+}
+func (m *Sealed) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sealed.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Sealed proto.InternalMessageInfo
+
+func (m *Sealed) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *Sealed) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *Sealed) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
+// Compressed is a compressed message.
+type Compressed struct {
+	// codec is the name of the codec that was used to compress the message.
+	Codec string `protobuf:"bytes,1,opt,name=codec,proto3" json:"codec,omitempty"`
+	// data is the compressed message.
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Compressed) Reset()         { *m = Compressed{} }
+func (m *Compressed) String() string { return proto.CompactTextString(m) }
+func (*Compressed) ProtoMessage()    {}
+func (*Compressed) Descriptor() ([]byte, []int) {
+	return fileDescriptor_data_f9d82060dee54a7f, []int{3}
+}
+func (m *Compressed) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Compressed.Unmarshal(m, b)
+}
+func (m *Compressed) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Compressed.Marshal(b, m, deterministic)
+}
+func (dst *Compressed) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Compressed.Merge(dst, src)
+}
+func (m *Compressed) XXX_Size() int {
+	return xxx_messageInfo_Compressed.Size(m)
+}
+func (m *Compressed) XXX_DiscardUnknown() {
+	xxx_messageInfo_Compressed.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Compressed proto.InternalMessageInfo
+
+func (m *Compressed) GetCodec() string {
+	if m != nil {
+		return m.Codec
+	}
+	return ""
+}
+
+func (m *Compressed) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// Attachment describes a binary attachment of a document. The content of the
+// attachment is stored separately, in fixed-size chunks.
+type Attachment struct {
+	// size is the size of the attachment's content, in bytes.
+	Size uint64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	// sealed is true if each of the chunks is a serialized Sealed message.
+	Sealed               bool     `protobuf:"varint,2,opt,name=sealed,proto3" json:"sealed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Attachment) Reset()         { *m = Attachment{} }
+func (m *Attachment) String() string { return proto.CompactTextString(m) }
+func (*Attachment) ProtoMessage()    {}
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_data_f9d82060dee54a7f, []int{4}
+}
+func (m *Attachment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Attachment.Unmarshal(m, b)
+}
+func (m *Attachment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Attachment.Marshal(b, m, deterministic)
+}
+func (dst *Attachment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attachment.Merge(dst, src)
+}
+func (m *Attachment) XXX_Size() int {
+	return xxx_messageInfo_Attachment.Size(m)
+}
+func (m *Attachment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attachment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attachment proto.InternalMessageInfo
+
+func (m *Attachment) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Attachment) GetSealed() bool {
+	if m != nil {
+		return m.Sealed
+	}
+	return false
+}
+
+// TextTerms is the set of terms in a document's searchable text.
+type TextTerms struct {
+	// terms is the sorted list of terms.
+	Terms                []string `protobuf:"bytes,1,rep,name=terms,proto3" json:"terms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TextTerms) Reset()         { *m = TextTerms{} }
+func (m *TextTerms) String() string { return proto.CompactTextString(m) }
+func (*TextTerms) ProtoMessage()    {}
+func (*TextTerms) Descriptor() ([]byte, []int) {
+	return fileDescriptor_data_f9d82060dee54a7f, []int{5}
+}
+func (m *TextTerms) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TextTerms.Unmarshal(m, b)
+}
+func (m *TextTerms) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TextTerms.Marshal(b, m, deterministic)
+}
+func (dst *TextTerms) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TextTerms.Merge(dst, src)
+}
+func (m *TextTerms) XXX_Size() int {
+	return xxx_messageInfo_TextTerms.Size(m)
+}
+func (m *TextTerms) XXX_DiscardUnknown() {
+	xxx_messageInfo_TextTerms.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TextTerms proto.InternalMessageInfo
+
+func (m *TextTerms) GetTerms() []string {
+	if m != nil {
+		return m.Terms
+	}
+	return nil
+}
+
 // Key is an instance of a named key.
+//
+// It is the representation of a key used by version 1 of the on-disk format.
+// It is no longer read, as the key index of a version 1 store is rebuilt from
+// the document records when the store is upgraded, and is only retained to
+// describe the legacy format.
 type Key struct {
 	Type                 uint32          `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
 	Documents            map[string]bool `protobuf:"bytes,2,rep,name=documents,proto3" json:"documents,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
@@ -155,7 +444,7 @@ func (m *Key) Reset()         { *m = Key{} }
 func (m *Key) String() string { return proto.CompactTextString(m) }
 func (*Key) ProtoMessage()    {}
 func (*Key) Descriptor() ([]byte, []int) {
-	return fileDescriptor_data_a587a6b6b981b685, []int{2}
+	return fileDescriptor_data_f9d82060dee54a7f, []int{6}
 }
 func (m *Key) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Key.Unmarshal(m, b)
@@ -191,42 +480,62 @@ func (m *Key) GetDocuments() map[string]bool {
 
 func init() {
 	proto.RegisterType((*Record)(nil), "protavo.bolt.Record")
+	proto.RegisterMapType((map[string]string)(nil), "protavo.bolt.Record.HeadersEntry")
 	proto.RegisterMapType((map[string]uint32)(nil), "protavo.bolt.Record.KeysEntry")
 	proto.RegisterType((*Content)(nil), "protavo.bolt.Content")
 	proto.RegisterMapType((map[string]string)(nil), "protavo.bolt.Content.HeadersEntry")
+	proto.RegisterType((*Sealed)(nil), "protavo.bolt.Sealed")
+	proto.RegisterType((*Compressed)(nil), "protavo.bolt.Compressed")
+	proto.RegisterType((*Attachment)(nil), "protavo.bolt.Attachment")
+	proto.RegisterType((*TextTerms)(nil), "protavo.bolt.TextTerms")
 	proto.RegisterType((*Key)(nil), "protavo.bolt.Key")
 	proto.RegisterMapType((map[string]bool)(nil), "protavo.bolt.Key.DocumentsEntry")
 }
 
 func init() {
-	proto.RegisterFile("src/protavobolt/internal/database/data.proto", fileDescriptor_data_a587a6b6b981b685)
-}
-
-var fileDescriptor_data_a587a6b6b981b685 = []byte{
-	// 386 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xcf, 0x8e, 0xd3, 0x30,
-	0x10, 0xc6, 0xe5, 0xb6, 0x6c, 0x9b, 0xd9, 0x5d, 0x84, 0xac, 0x3d, 0x84, 0x1c, 0x20, 0xea, 0x29,
-	0x07, 0xe4, 0x48, 0xe5, 0x00, 0xac, 0x56, 0x48, 0xe5, 0x8f, 0x84, 0xd4, 0x9b, 0xc5, 0x89, 0x0b,
-	0x72, 0x92, 0x61, 0x89, 0x36, 0xb5, 0x23, 0xc7, 0xa9, 0xe4, 0x27, 0xe0, 0x11, 0x78, 0x10, 0x5e,
-	0x10, 0xc5, 0x8e, 0x4b, 0xca, 0x1e, 0x7a, 0x9b, 0xd1, 0xfc, 0xbe, 0xe4, 0xf7, 0x29, 0x81, 0x57,
-	0x9d, 0x2e, 0xf3, 0x56, 0x2b, 0x23, 0x0e, 0xaa, 0x50, 0x8d, 0xc9, 0x6b, 0x69, 0x50, 0x4b, 0xd1,
-	0xe4, 0x95, 0x30, 0xa2, 0x10, 0x1d, 0xba, 0x81, 0x0d, 0x88, 0xa2, 0x57, 0x23, 0xc9, 0x06, 0x34,
-	0x79, 0x7e, 0xaf, 0xd4, 0x7d, 0x83, 0x2e, 0xae, 0x8a, 0xfe, 0x47, 0x2e, 0xa4, 0xf5, 0x60, 0xf2,
-	0xf2, 0xff, 0x93, 0xa9, 0xf7, 0xd8, 0x19, 0xb1, 0x6f, 0x3d, 0xb0, 0xfe, 0x35, 0x83, 0x0b, 0x8e,
-	0xa5, 0xd2, 0x15, 0x4d, 0x60, 0xa5, 0xf1, 0x50, 0x77, 0xb5, 0x92, 0x31, 0x49, 0x49, 0xb6, 0xe0,
-	0xc7, 0x9d, 0x6e, 0x60, 0xf1, 0x80, 0xb6, 0x8b, 0x67, 0xe9, 0x3c, 0xbb, 0xdc, 0xbc, 0x60, 0xd3,
-	0xf7, 0x33, 0x9f, 0x67, 0x3b, 0xb4, 0xdd, 0x67, 0x69, 0xb4, 0xe5, 0x8e, 0xa5, 0xef, 0x00, 0x4a,
-	0x8d, 0xc2, 0x60, 0xf5, 0x5d, 0x98, 0x78, 0x9e, 0x92, 0xec, 0x72, 0x93, 0x30, 0x2f, 0xc4, 0x82,
-	0x10, 0xfb, 0x1a, 0x84, 0x78, 0x34, 0xd2, 0x5b, 0x33, 0x44, 0xfb, 0xb6, 0x0a, 0xd1, 0xc5, 0xf9,
-	0xe8, 0x48, 0x6f, 0x4d, 0xf2, 0x06, 0xa2, 0xa3, 0x08, 0x7d, 0x06, 0xf3, 0x07, 0xb4, 0xae, 0x4d,
-	0xc4, 0x87, 0x91, 0xde, 0xc0, 0x93, 0x83, 0x68, 0x7a, 0x8c, 0x67, 0x29, 0xc9, 0xae, 0xb9, 0x5f,
-	0x6e, 0x67, 0x6f, 0xc9, 0xfa, 0x0f, 0x81, 0xe5, 0x47, 0x25, 0x0d, 0x4a, 0x43, 0xef, 0x60, 0xf9,
-	0x13, 0x45, 0x85, 0xba, 0x8b, 0x89, 0x6b, 0xbc, 0x3e, 0x6d, 0x3c, 0x72, 0xec, 0x8b, 0x87, 0x7c,
-	0xeb, 0x10, 0xa1, 0x0c, 0x96, 0xa5, 0x07, 0x46, 0xf5, 0x9b, 0x47, 0xea, 0x5b, 0x69, 0x79, 0x80,
-	0x92, 0x5b, 0xb8, 0x9a, 0x3e, 0xe8, 0x9c, 0x75, 0x34, 0xb5, 0xfe, 0x4d, 0x60, 0xbe, 0x43, 0x4b,
-	0x29, 0x2c, 0x8c, 0x6d, 0xd1, 0x85, 0xae, 0xb9, 0x9b, 0xe9, 0x7b, 0x88, 0x2a, 0x55, 0xf6, 0x7b,
-	0x94, 0x26, 0x7c, 0xb9, 0xf4, 0xb4, 0xc7, 0x0e, 0x2d, 0xfb, 0x14, 0x10, 0xdf, 0xe2, 0x5f, 0x24,
-	0xb9, 0x83, 0xa7, 0xa7, 0xc7, 0x73, 0x66, 0xab, 0x89, 0xd9, 0x07, 0xf8, 0xb6, 0x0a, 0xbf, 0x6e,
-	0x71, 0xe1, 0x8a, 0xbf, 0xfe, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x03, 0xc7, 0xdc, 0xd9, 0xe6, 0x02,
-	0x00, 0x00,
+	proto.RegisterFile("src/protavobolt/internal/database/data.proto", fileDescriptor_data_f9d82060dee54a7f)
+}
+
+var fileDescriptor_data_f9d82060dee54a7f = []byte{
+	// 634 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4d, 0x6f, 0xd3, 0x4c,
+	0x10, 0x96, 0xf3, 0xed, 0x49, 0xfa, 0xea, 0xd5, 0xaa, 0x20, 0x93, 0x43, 0x71, 0xcd, 0x25, 0x87,
+	0xca, 0x91, 0x82, 0x04, 0xa5, 0x54, 0x48, 0xe1, 0x43, 0x02, 0xf5, 0xe6, 0x86, 0x0b, 0x97, 0x68,
+	0xe3, 0x1d, 0x5a, 0x2b, 0x8e, 0xd7, 0x78, 0x37, 0x51, 0xdd, 0x3f, 0xc2, 0x89, 0xff, 0xc2, 0x4f,
+	0x43, 0xfb, 0xd5, 0x26, 0x05, 0x94, 0x03, 0xb7, 0x99, 0xdd, 0xe7, 0xd9, 0x79, 0xf6, 0x99, 0xd9,
+	0x85, 0x13, 0x51, 0xa5, 0xe3, 0xb2, 0xe2, 0x92, 0x6e, 0xf8, 0x82, 0xe7, 0x72, 0x9c, 0x15, 0x12,
+	0xab, 0x82, 0xe6, 0x63, 0x46, 0x25, 0x5d, 0x50, 0x81, 0x3a, 0x88, 0x15, 0x84, 0x93, 0x81, 0x45,
+	0xc6, 0x0a, 0x3a, 0x7c, 0x72, 0xc5, 0xf9, 0x55, 0x8e, 0x9a, 0xce, 0x17, 0xeb, 0xaf, 0x63, 0x5a,
+	0xd4, 0x06, 0x38, 0x7c, 0xfa, 0x70, 0x4b, 0x66, 0x2b, 0x14, 0x92, 0xae, 0x4a, 0x03, 0x88, 0x7e,
+	0xb4, 0xa0, 0x93, 0x60, 0xca, 0x2b, 0x46, 0x86, 0xd0, 0xab, 0x70, 0x93, 0x89, 0x8c, 0x17, 0x81,
+	0x17, 0x7a, 0xa3, 0x56, 0x72, 0x97, 0x93, 0x09, 0xb4, 0x96, 0x58, 0x8b, 0xa0, 0x11, 0x36, 0x47,
+	0xfd, 0xc9, 0x51, 0xbc, 0x5d, 0x3f, 0x36, 0xfc, 0xf8, 0x02, 0x6b, 0xf1, 0xa1, 0x90, 0x55, 0x9d,
+	0x68, 0x2c, 0x79, 0x05, 0x90, 0x56, 0x48, 0x25, 0xb2, 0x39, 0x95, 0x41, 0x33, 0xf4, 0x46, 0xfd,
+	0xc9, 0x30, 0x36, 0x82, 0x62, 0x27, 0x28, 0x9e, 0x39, 0x41, 0x89, 0x6f, 0xd1, 0x53, 0xa9, 0xa8,
+	0xeb, 0x92, 0x39, 0x6a, 0x6b, 0x3f, 0xd5, 0xa2, 0xa7, 0x92, 0xbc, 0x86, 0xee, 0x35, 0x52, 0x86,
+	0x95, 0x08, 0xda, 0x5a, 0xec, 0xf1, 0x1f, 0xc5, 0x7e, 0x34, 0x18, 0xa3, 0xd7, 0x31, 0xc8, 0x31,
+	0x0c, 0x52, 0x5e, 0x48, 0x2c, 0xe4, 0x5c, 0xd6, 0x25, 0x06, 0x9d, 0xd0, 0x1b, 0xf9, 0x49, 0xdf,
+	0xae, 0xcd, 0xea, 0x12, 0x95, 0x4b, 0x02, 0xbf, 0xad, 0xb1, 0x48, 0x31, 0xe8, 0x1a, 0x97, 0x5c,
+	0xae, 0x64, 0x33, 0xcc, 0xd1, 0xca, 0xee, 0xed, 0x97, 0x6d, 0xd1, 0x53, 0xa9, 0x2a, 0x33, 0xac,
+	0xb2, 0x0d, 0xb2, 0xb9, 0x36, 0xda, 0x0f, 0x9b, 0xaa, 0xb2, 0x5d, 0x53, 0xd6, 0x0e, 0x5f, 0x82,
+	0x7f, 0x67, 0x31, 0xf9, 0x1f, 0x9a, 0x4b, 0xac, 0x75, 0x9f, 0xfc, 0x44, 0x85, 0xe4, 0x10, 0xda,
+	0x1b, 0x9a, 0xaf, 0x31, 0x68, 0x84, 0xde, 0xe8, 0x20, 0x31, 0xc9, 0x59, 0xe3, 0xd4, 0x1b, 0x9e,
+	0xc1, 0x60, 0xfb, 0xba, 0xfb, 0xb8, 0xfe, 0x16, 0x37, 0xfa, 0xd9, 0x80, 0xee, 0x3b, 0x73, 0x7d,
+	0x72, 0x7e, 0x6f, 0xad, 0xa7, 0xad, 0x8d, 0x76, 0xad, 0xb5, 0xb8, 0xbf, 0x78, 0x1b, 0x43, 0xd7,
+	0xfa, 0x68, 0x1b, 0x7a, 0xf8, 0x9b, 0x33, 0xd3, 0xa2, 0x4e, 0x1c, 0x88, 0x9c, 0x40, 0x47, 0x20,
+	0xcd, 0x91, 0x05, 0x6d, 0x0b, 0xdf, 0x29, 0x76, 0xa9, 0xf7, 0x12, 0x8b, 0x21, 0xa7, 0x00, 0x29,
+	0x5f, 0x95, 0x15, 0x0a, 0x81, 0x4c, 0xf7, 0xad, 0x3f, 0x09, 0x1e, 0xca, 0x73, 0xfb, 0xc9, 0x16,
+	0x96, 0x3c, 0x83, 0x03, 0x5e, 0x65, 0x57, 0x59, 0x41, 0xf3, 0xb9, 0xc8, 0x6e, 0x5d, 0x57, 0x07,
+	0x6e, 0xf1, 0x32, 0xbb, 0xc5, 0x7f, 0xb2, 0xf0, 0x33, 0x74, 0x8c, 0x58, 0xf2, 0x08, 0x3a, 0x4b,
+	0xac, 0xe7, 0x19, 0xb3, 0xc4, 0xf6, 0x12, 0xeb, 0x4f, 0x4c, 0x51, 0x0b, 0x5e, 0xa4, 0x86, 0x3a,
+	0x48, 0x4c, 0x42, 0x8e, 0x00, 0xd2, 0xac, 0xbc, 0xc6, 0x4a, 0xe2, 0x8d, 0x79, 0x3e, 0x83, 0x64,
+	0x6b, 0x25, 0x7a, 0x01, 0x70, 0x7f, 0x23, 0x75, 0x46, 0xca, 0x19, 0xa6, 0xee, 0x64, 0x9d, 0x10,
+	0x02, 0x2d, 0xf5, 0x6b, 0xd8, 0x83, 0x75, 0x1c, 0x9d, 0x02, 0x4c, 0xa5, 0xa4, 0xe9, 0xf5, 0x4a,
+	0xb9, 0x4c, 0xa0, 0xa5, 0x2f, 0x6d, 0x1e, 0xbc, 0x8e, 0xc9, 0xe3, 0x3b, 0xe7, 0x15, 0xaf, 0xe7,
+	0x3c, 0x8e, 0x8e, 0xc1, 0x9f, 0xe1, 0x8d, 0x9c, 0x61, 0xb5, 0x12, 0xaa, 0xa0, 0x54, 0x81, 0x1e,
+	0x05, 0x3f, 0x31, 0x49, 0xf4, 0xdd, 0x83, 0xe6, 0x05, 0xd6, 0xea, 0x58, 0xfd, 0x80, 0x3c, 0x3d,
+	0x8b, 0x3a, 0x26, 0x6f, 0xc0, 0x67, 0x3c, 0x5d, 0xab, 0xb2, 0xee, 0x23, 0x09, 0x77, 0x3b, 0x74,
+	0x81, 0x75, 0xfc, 0xde, 0x41, 0xcc, 0xf8, 0xdc, 0x53, 0x86, 0xe7, 0xf0, 0xdf, 0xee, 0xe6, 0xbe,
+	0x2e, 0xf4, 0xb6, 0xba, 0xf0, 0x16, 0xbe, 0xf4, 0xdc, 0x4f, 0xba, 0xe8, 0xe8, 0x89, 0x7b, 0xfe,
+	0x6b, 0x00, 0x67, 0xf9, 0x82, 0x1e, 0x75, 0x05, 0x00, 0x00,
 }
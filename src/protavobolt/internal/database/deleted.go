@@ -0,0 +1,112 @@
+package database
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// The deleted bucket contains a nested records bucket, which holds the record
+// of each soft-deleted document, and a nested keys bucket, which maps each
+// unique key that is reserved by a soft-deleted document to that document's ID.
+//
+// Soft-deleted documents are removed from every other index, but their content
+// and attachments are retained in the same buckets as those of other documents.
+
+// IsSoftDeleteEnabled returns true if deleted documents are retained, such that
+// they can be restored.
+func (s *Store) IsSoftDeleteEnabled() bool {
+	return s.softDelete
+}
+
+// TryGetDeletedRecord loads the record for the soft-deleted document with the
+// given ID.
+//
+// It returns false if there is no such document.
+func (s *Store) TryGetDeletedRecord(id string) (*Record, bool, error) {
+	buf := s.DeletedRecords.Get([]byte(id))
+	if buf == nil {
+		return nil, false, nil
+	}
+
+	rec, err := UnmarshalRecord(buf)
+	return rec, true, err
+}
+
+// PutDeletedRecord stores the record of a soft-deleted document.
+//
+// Unless the store is configured to release unique keys, the document's unique
+// keys remain reserved until the record is deleted.
+func (s *Store) PutDeletedRecord(id string, rec *Record) error {
+	buf, err := proto.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	if err := s.DeletedRecords.Put([]byte(id), buf); err != nil {
+		return err
+	}
+
+	if s.releaseUniqueKeys {
+		return nil
+	}
+
+	for key, t := range rec.Keys {
+		if t == UniqueKeyType {
+			if err := s.DeletedKeys.Put([]byte(key), []byte(id)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeleteDeletedRecord deletes the record of a soft-deleted document, and
+// releases any unique keys that it reserves.
+func (s *Store) DeleteDeletedRecord(id string, rec *Record) error {
+	for key, t := range rec.Keys {
+		if t == UniqueKeyType && string(s.DeletedKeys.Get([]byte(key))) == id {
+			if err := s.DeletedKeys.Delete([]byte(key)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.DeletedRecords.Delete([]byte(id))
+}
+
+// ScanDeletedRecords calls fn for each soft-deleted document, in order of
+// document ID.
+//
+// It stops iterating if fn returns false or a non-nil error.
+func (s *Store) ScanDeletedRecords(fn func(id string, rec *Record) (bool, error)) error {
+	cur := s.DeletedRecords.Cursor()
+
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		rec, err := UnmarshalRecord(v)
+		if err != nil {
+			return err
+		}
+
+		ok, err := fn(string(k), rec)
+		if !ok || err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getReservedKeyDocumentID returns the ID of the soft-deleted document that
+// reserves the given unique key.
+//
+// It returns false if the key is not reserved.
+func (s *Store) getReservedKeyDocumentID(key string) (string, bool) {
+	id := s.DeletedKeys.Get([]byte(key))
+	return string(id), id != nil
+}
+
+// IsDeleted returns true if the document with the given ID has been
+// soft-deleted.
+func (s *Store) IsDeleted(id string) bool {
+	return s.DeletedRecords.Get([]byte(id)) != nil
+}
@@ -0,0 +1,246 @@
+package database
+
+// The headers bucket contains a nested bucket for each indexed header name,
+// which in turn contains a nested bucket for each of that header's values. Each
+// value bucket contains an empty entry for each document that has the header
+// with that value.
+//
+// The number of documents with each value is stored in a similarly nested
+// bucket within the stats bucket.
+
+//
+// A header's index is only used to find documents once every document in the
+// store has been added to it, which is recorded in the complete-headers bucket.
+// A header's index is complete if the header is indexed while the store is
+// empty, or once the index is rebuilt. It becomes incomplete if the store is
+// modified while the header is not indexed.
+
+// IsHeaderIndexed returns true if the header with the given name is indexed,
+// and every document in the store has been added to its index.
+func (s *Store) IsHeaderIndexed(name string) bool {
+	return s.isHeaderConfigured(name) &&
+		s.CompleteHeaders.Get([]byte(name)) != nil
+}
+
+// IndexedHeaders returns the subset of the given headers that are indexed when
+// a document is written, regardless of whether their indexes are complete.
+func (s *Store) IndexedHeaders(headers map[string]string) map[string]string {
+	var indexed map[string]string
+
+	for n, v := range headers {
+		if s.isHeaderConfigured(n) {
+			if indexed == nil {
+				indexed = map[string]string{}
+			}
+
+			indexed[n] = v
+		}
+	}
+
+	return indexed
+}
+
+// MarkHeaderIndexesComplete records that every document in the store has been
+// added to the index of each of the indexed headers.
+func (s *Store) MarkHeaderIndexesComplete() error {
+	for n := range s.indexedHeaders {
+		k := []byte(n)
+
+		if s.CompleteHeaders.Get(k) != nil {
+			continue
+		}
+
+		if err := s.CompleteHeaders.Put(k, []byte{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isHeaderConfigured returns true if the header with the given name is added
+// to the index when a document is written.
+func (s *Store) isHeaderConfigured(name string) bool {
+	_, ok := s.indexedHeaders[name]
+	return ok
+}
+
+// updateCompleteHeaders updates the set of complete header indexes before the
+// store is modified.
+//
+// Headers that are no longer indexed are removed from the set, as documents
+// written from now on are not added to their indexes. If the store is empty,
+// the indexes of the current headers are complete.
+func (s *Store) updateCompleteHeaders() error {
+	var stale [][]byte
+
+	if err := s.CompleteHeaders.ForEach(func(k, _ []byte) error {
+		if !s.isHeaderConfigured(string(k)) {
+			stale = append(stale, append([]byte(nil), k...))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, k := range stale {
+		if err := s.CompleteHeaders.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	if s.CountDocuments() == 0 {
+		return s.MarkHeaderIndexesComplete()
+	}
+
+	return nil
+}
+
+// UpdateHeaders updates the indexed headers for a specific document.
+func (s *Store) UpdateHeaders(
+	id string,
+	before, after map[string]string,
+) error {
+	// remove the document from any values that are not present after the update
+	for n, v := range before {
+		if a, ok := after[n]; ok && a == v {
+			continue
+		}
+
+		if err := s.removeHeaderDocument(n, v, id); err != nil {
+			return err
+		}
+	}
+
+	// add the document to any values that are new after the update
+	for n, v := range after {
+		if b, ok := before[n]; ok && b == v {
+			continue
+		}
+
+		if err := s.addHeaderDocument(n, v, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetHeaderDocumentIDs returns the IDs of the documents that have the given
+// header with the given value.
+func (s *Store) GetHeaderDocumentIDs(name, value string) []string {
+	nb := s.Headers.Bucket([]byte(name))
+	if nb == nil {
+		return nil
+	}
+
+	vb := nb.Bucket([]byte(value))
+	if vb == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, s.CountHeaderDocuments(name, value))
+	cur := vb.Cursor()
+
+	for k, _ := cur.First(); k != nil; k, _ = cur.Next() {
+		ids = append(ids, string(k))
+	}
+
+	return ids
+}
+
+// GetHeaderValues returns the values of the given header, across all
+// documents.
+func (s *Store) GetHeaderValues(name string) []string {
+	b := s.HeaderCounts.Bucket([]byte(name))
+	if b == nil {
+		return nil
+	}
+
+	var values []string
+	cur := b.Cursor()
+
+	for k, _ := cur.First(); k != nil; k, _ = cur.Next() {
+		values = append(values, string(k))
+	}
+
+	return values
+}
+
+// CountHeaderDocuments returns the number of documents that have the given
+// header with the given value.
+func (s *Store) CountHeaderDocuments(name, value string) int {
+	b := s.HeaderCounts.Bucket([]byte(name))
+	if b == nil {
+		return 0
+	}
+
+	return getCount(b, []byte(value))
+}
+
+// addHeaderDocument adds a document to a header value.
+func (s *Store) addHeaderDocument(name, value, id string) error {
+	nb, err := s.Headers.CreateBucketIfNotExists([]byte(name))
+	if err != nil {
+		return err
+	}
+
+	vb, err := nb.CreateBucketIfNotExists([]byte(value))
+	if err != nil {
+		return err
+	}
+
+	if vb.Get([]byte(id)) != nil {
+		return nil
+	}
+
+	if err := vb.Put([]byte(id), []byte{}); err != nil {
+		return err
+	}
+
+	cb, err := s.HeaderCounts.CreateBucketIfNotExists([]byte(name))
+	if err != nil {
+		return err
+	}
+
+	return addCount(cb, []byte(value), +1)
+}
+
+// removeHeaderDocument removes a document from a header value, deleting the
+// value, and then the header, if they contain no other documents.
+func (s *Store) removeHeaderDocument(name, value, id string) error {
+	nb := s.Headers.Bucket([]byte(name))
+	if nb == nil {
+		return nil
+	}
+
+	vb := nb.Bucket([]byte(value))
+	if vb == nil || vb.Get([]byte(id)) == nil {
+		return nil
+	}
+
+	cb := s.HeaderCounts.Bucket([]byte(name))
+	n := getCount(cb, []byte(value)) - 1
+
+	if err := putCount(cb, []byte(value), n); err != nil {
+		return err
+	}
+
+	if n > 0 {
+		return vb.Delete([]byte(id))
+	}
+
+	if err := nb.DeleteBucket([]byte(value)); err != nil {
+		return err
+	}
+
+	if k, _ := cb.Cursor().First(); k != nil {
+		return nil
+	}
+
+	if err := s.HeaderCounts.DeleteBucket([]byte(name)); err != nil {
+		return err
+	}
+
+	return s.Headers.DeleteBucket([]byte(name))
+}
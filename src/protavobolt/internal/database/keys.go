@@ -1,7 +1,9 @@
 package database
 
 import (
-	"github.com/golang/protobuf/proto"
+	"bytes"
+	"encoding/binary"
+
 	"github.com/jmalloc/protavo/src/protavo"
 	"github.com/jmalloc/protavo/src/protavo/document"
 )
@@ -16,6 +18,14 @@ const (
 	UniqueKeyType = uint32(document.UniqueKey)
 )
 
+// The keys bucket contains a nested bucket for each key. Each nested bucket
+// contains one entry per document that has the key, such that adding or
+// removing a document from a key does not require reading or writing the other
+// documents in that key.
+//
+// The entry's value is the key type, which is the same for every document in
+// the key.
+
 // UpdateKeys updates the unique keys for a specific document.
 func (s *Store) UpdateKeys(
 	id string,
@@ -27,14 +37,7 @@ func (s *Store) UpdateKeys(
 			continue
 		}
 
-		k, err := s.GetKey(key)
-		if err != nil {
-			return err
-		}
-
-		delete(k.Documents, id)
-
-		if err := s.putKey(key, k); err != nil {
+		if err := s.removeKeyDocument(key, id); err != nil {
 			return err
 		}
 	}
@@ -48,83 +51,191 @@ func (s *Store) UpdateKeys(
 			}
 		}
 
-		k, err := s.GetKey(key)
-		if err != nil {
+		if err := s.addKeyDocument(key, id, afterType); err != nil {
 			return err
 		}
+	}
 
-		// count how many other docs are in this key
-		otherDocs := len(k.Documents)
-		if k.Documents[id] {
-			otherDocs--
+	return nil
+}
+
+// addKeyDocument adds a document to a key, or changes the type of a key that
+// the document already has.
+func (s *Store) addKeyDocument(key, id string, t uint32) error {
+	// a unique key of a soft-deleted document can not be used by any other
+	// document until it is released
+	if other, ok := s.getReservedKeyDocumentID(key); ok && other != id {
+		return &protavo.DuplicateKeyError{
+			DocumentID:            id,
+			ConflictingDocumentID: other,
+			UniqueKey:             key,
 		}
+	}
 
-		if otherDocs == 0 {
-			// there are no other documents, update the key however neceessary
-			k.Type = afterType
-			k.Documents = map[string]bool{id: true}
-		} else if k.Type == SharedKeyType && afterType == SharedKeyType {
-			// there are other documents, but the key is shared AND the document wants a
-			// shared key, so simply add the document to the key
-			k.Documents[id] = true
-		} else {
-			// otherwise, either the key is already unique, or the document is requesting
-			// a unique key, hence there is a conflict
-			for conflictID := range k.Documents {
-				return &protavo.DuplicateKeyError{
-					DocumentID:            id,
-					ConflictingDocumentID: conflictID,
-					UniqueKey:             key,
-				}
-			}
+	b, err := s.Keys.CreateBucketIfNotExists([]byte(key))
+	if err != nil {
+		return err
+	}
 
-			panic("impossible condition: len(k.Documents) > 0 but iterating it produces no values")
+	exists := b.Get([]byte(id)) != nil
+
+	// count how many other docs are in this key
+	otherDocs := s.CountKeyDocuments(key)
+	if exists {
+		otherDocs--
+	}
+
+	if otherDocs > 0 {
+		k, v := b.Cursor().First()
+		if string(k) == id {
+			_, v = b.Cursor().Last()
 		}
 
-		if err := s.putKey(key, k); err != nil {
-			return err
+		// there are other documents, the document can only be added if the key is
+		// shared AND the document wants a shared key
+		if t != SharedKeyType || unmarshalKeyType(v) != SharedKeyType {
+			return s.duplicateKeyError(key, id)
 		}
 	}
 
-	return nil
+	if err := b.Put([]byte(id), marshalKeyType(t)); err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	return addCount(s.KeyCounts, []byte(key), +1)
 }
 
-// GetKey loads a key by its name.
-// If the key does not exist, a pointer to a zero-value Key is returned.
-func (s *Store) GetKey(key string) (*Key, error) {
-	var k Key
+// removeKeyDocument removes a document from a key, deleting the key if it
+// contains no other documents.
+func (s *Store) removeKeyDocument(key, id string) error {
+	b := s.Keys.Bucket([]byte(key))
+	if b == nil || b.Get([]byte(id)) == nil {
+		return nil
+	}
 
-	if buf := s.Keys.Get([]byte(key)); buf != nil {
-		if err := proto.Unmarshal(buf, &k); err != nil {
-			return nil, err
+	n := s.CountKeyDocuments(key) - 1
+
+	if err := putCount(s.KeyCounts, []byte(key), n); err != nil {
+		return err
+	}
+
+	if n <= 0 {
+		return s.Keys.DeleteBucket([]byte(key))
+	}
+
+	return b.Delete([]byte(id))
+}
+
+// duplicateKeyError returns an error describing a conflict between the
+// document with the given ID and another document that already has the key.
+func (s *Store) duplicateKeyError(key, id string) error {
+	cur := s.Keys.Bucket([]byte(key)).Cursor()
+
+	for k, _ := cur.First(); k != nil; k, _ = cur.Next() {
+		if string(k) != id {
+			return &protavo.DuplicateKeyError{
+				DocumentID:            id,
+				ConflictingDocumentID: string(k),
+				UniqueKey:             key,
+			}
 		}
 	}
 
-	return &k, nil
+	panic("impossible condition: the key has other documents but iterating it produces no values")
 }
 
-// putKey saves a key, deleting it if it contains no documents.
-func (s *Store) putKey(key string, k *Key) error {
-	if len(k.Documents) == 0 {
-		return s.Keys.Delete([]byte(key))
+// GetUniqueKeyDocumentID returns the ID of the document that has the given
+// unique key.
+//
+// It returns false if the key does not exist, or it is not a unique key.
+func (s *Store) GetUniqueKeyDocumentID(key string) (string, bool) {
+	b := s.Keys.Bucket([]byte(key))
+	if b == nil {
+		return "", false
 	}
 
-	buf, err := proto.Marshal(k)
-	if err != nil {
-		return err
+	k, v := b.Cursor().First()
+	if k == nil || unmarshalKeyType(v) != UniqueKeyType {
+		return "", false
+	}
+
+	return string(k), true
+}
+
+// GetKeyDocumentIDs returns the IDs of the documents that have the given key.
+func (s *Store) GetKeyDocumentIDs(key string) []string {
+	b := s.Keys.Bucket([]byte(key))
+	if b == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, s.CountKeyDocuments(key))
+	cur := b.Cursor()
+
+	for k, _ := cur.First(); k != nil; k, _ = cur.Next() {
+		ids = append(ids, string(k))
 	}
 
-	return s.Keys.Put([]byte(key), buf)
+	return ids
 }
 
-// GetUniqueDocumentID returns the document ID that this unique key refers to.
-// It returns false if k is not a unique key, or it is empty.
-func (k *Key) GetUniqueDocumentID() (string, bool) {
-	if k.Type == UniqueKeyType {
-		for id := range k.Documents {
-			return id, true
+// ScanKeys calls fn for each key that begins with the given prefix, in order,
+// along with the key's type and the number of documents that have it.
+//
+// It stops iterating if fn returns false.
+func (s *Store) ScanKeys(prefix string, fn func(key string, t uint32, n int) bool) {
+	p := []byte(prefix)
+	cur := s.Keys.Cursor()
+
+	for k, _ := cur.Seek(p); k != nil && bytes.HasPrefix(k, p); k, _ = cur.Next() {
+		// every document in a key has the same key type
+		_, v := s.Keys.Bucket(k).Cursor().First()
+
+		if !fn(string(k), unmarshalKeyType(v), s.CountKeyDocuments(string(k))) {
+			return
 		}
 	}
+}
+
+// GetKeyPrefixDocumentIDs returns the IDs of the documents that have at least
+// one key that begins with the given prefix.
+func (s *Store) GetKeyPrefixDocumentIDs(prefix string) []string {
+	var ids []string
+	seen := map[string]struct{}{}
+
+	s.ScanKeys(prefix, func(key string, _ uint32, _ int) bool {
+		for _, id := range s.GetKeyDocumentIDs(key) {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+
+		return true
+	})
+
+	return ids
+}
+
+// HasKey returns true if the document with the given ID has the given key.
+func (s *Store) HasKey(key, id string) bool {
+	b := s.Keys.Bucket([]byte(key))
+	return b != nil && b.Get([]byte(id)) != nil
+}
+
+// marshalKeyType returns the binary representation of a key type.
+func marshalKeyType(t uint32) []byte {
+	buf := make([]byte, binary.MaxVarintLen32)
+	n := binary.PutUvarint(buf, uint64(t))
+	return buf[:n]
+}
 
-	return "", false
+// unmarshalKeyType parses the binary representation of a key type.
+func unmarshalKeyType(buf []byte) uint32 {
+	t, _ := binary.Uvarint(buf)
+	return uint32(t)
 }
@@ -3,7 +3,9 @@ package database
 import (
 	"fmt"
 
+	bolt "github.com/coreos/bbolt"
 	"github.com/golang/protobuf/proto"
+	"github.com/jmalloc/protavo/src/protavo"
 )
 
 // TryGetRecord loads the record for the document with the given ID.
@@ -29,10 +31,12 @@ func (s *Store) GetRecord(id string) (*Record, error) {
 	if err != nil {
 		return nil, err
 	} else if !ok {
-		return nil, fmt.Errorf(
-			"data integrity error: record for '%s' is missing",
-			id,
-		)
+		return nil, &protavo.DataIntegrityError{
+			Namespace:   s.ns,
+			Bucket:      string(recordsBucket),
+			DocumentID:  id,
+			Description: fmt.Sprintf("record for '%s' is missing", id),
+		}
 	}
 
 	return rec, nil
@@ -45,17 +49,43 @@ func (s *Store) PutRecord(id string, rec *Record) error {
 		return err
 	}
 
-	return s.Records.Put(
-		[]byte(id),
-		buf,
-	)
+	k := []byte(id)
+
+	if s.Records.Get(k) == nil {
+		if err := addCount(s.Stats, documentCountKey, +1); err != nil {
+			return err
+		}
+	}
+
+	return s.Records.Put(k, buf)
 }
 
 // DeleteRecord deletes a document record.
 func (s *Store) DeleteRecord(id string) error {
-	return s.Records.Delete(
-		[]byte(id),
-	)
+	k := []byte(id)
+
+	if s.Records.Get(k) == nil {
+		return nil
+	}
+
+	if err := addCount(s.Stats, documentCountKey, -1); err != nil {
+		return err
+	}
+
+	return s.Records.Delete(k)
+}
+
+// DeleteRecordAt deletes the record for the document with the given ID, which
+// must be at the current position of cur, a cursor over s.Records.
+//
+// It allows records to be deleted while iterating without invalidating the
+// cursor.
+func (s *Store) DeleteRecordAt(cur *bolt.Cursor, id string) error {
+	if err := s.Records.DeleteAt(cur, []byte(id)); err != nil {
+		return err
+	}
+
+	return addCount(s.Stats, documentCountKey, -1)
 }
 
 // UnmarshalRecord unmarshals document record.
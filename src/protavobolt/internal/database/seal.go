@@ -0,0 +1,59 @@
+package database
+
+// Sealer encrypts and decrypts document content.
+type Sealer interface {
+	// Seal encrypts plaintext. aad is additional data that is authenticated,
+	// but not encrypted, it must be passed to Open() to decrypt the message.
+	Seal(plaintext, aad []byte) (*Sealed, error)
+
+	// Open decrypts a message that was encrypted by Seal().
+	Open(m *Sealed, aad []byte) ([]byte, error)
+
+	// IsCurrent returns true if m was encrypted using the key that Seal()
+	// currently uses.
+	IsCurrent(m *Sealed) bool
+}
+
+// ResealContent re-encrypts the content and attachments of every document in
+// every store using tx.Sealer. Content that is already encrypted with the
+// current key is left unchanged, content that is not encrypted is encrypted.
+//
+// It returns the number of documents whose content was re-encrypted.
+func ResealContent(tx *Tx) (int, error) {
+	n := 0
+	err := ForEachStore(tx, func(s *Store) error {
+		var ids []string
+
+		if err := s.Content.ForEach(func(k, v []byte) error {
+			c, err := unmarshalContent(v)
+			if err != nil {
+				return err
+			}
+
+			if c.Sealed == nil || !tx.Sealer.IsCurrent(c.Sealed) {
+				ids = append(ids, string(k))
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			c, err := s.GetContent(id)
+			if err != nil {
+				return err
+			}
+
+			if err := s.PutContent(id, c); err != nil {
+				return err
+			}
+		}
+
+		n += len(ids)
+
+		return s.resealAttachments()
+	})
+
+	return n, err
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"encoding/binary"
+)
+
+var (
+	documentCountKey = []byte("documents")
+	contentBytesKey  = []byte("content-bytes")
+	storedBytesKey   = []byte("stored-bytes")
+)
+
+// counters is the interface used to read and write counters. It is
+// implemented by both *Bucket and *bolt.Bucket, such that counters can be
+// manipulated by migrations.
+type counters interface {
+	Get([]byte) []byte
+	Put([]byte, []byte) error
+	Delete([]byte) error
+}
+
+// CountDocuments returns the number of documents in the store.
+func (s *Store) CountDocuments() int {
+	return getCount(s.Stats, documentCountKey)
+}
+
+// CountKeyDocuments returns the number of documents that have the given key.
+func (s *Store) CountKeyDocuments(key string) int {
+	return getCount(s.KeyCounts, []byte(key))
+}
+
+// ContentSize returns the total size of the content of the documents in the
+// store, both before and after compression and encryption.
+func (s *Store) ContentSize() (original, stored int) {
+	return getCount(s.Stats, contentBytesKey), getCount(s.Stats, storedBytesKey)
+}
+
+// getCount returns the counter stored under k in b.
+// It returns 0 if the counter does not exist.
+func getCount(b counters, k []byte) int {
+	buf := b.Get(k)
+	if buf == nil {
+		return 0
+	}
+
+	return int(binary.BigEndian.Uint64(buf))
+}
+
+// putCount stores a counter under k in b, deleting it if n is zero.
+func putCount(b counters, k []byte, n int) error {
+	if n <= 0 {
+		return b.Delete(k)
+	}
+
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(n))
+
+	return b.Put(k, buf)
+}
+
+// addCount adds delta to the counter stored under k in b.
+func addCount(b counters, k []byte, delta int) error {
+	return putCount(b, k, getCount(b, k)+delta)
+}
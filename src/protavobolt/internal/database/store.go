@@ -4,27 +4,129 @@ import (
 	"bytes"
 	"fmt"
 
-	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
 )
 
 var (
-	rootBucket    = []byte("protavo")
+	rootBucket = []byte("protavo")
+
+	// storeBucket is the bucket within a namespace's bucket that contains all
+	// of the buckets that make up the namespace's store. Every other bucket
+	// within the namespace's bucket is a sub-namespace.
+	//
+	// Namespace names are split into their components at each dot, so no
+	// sub-namespace can have the same name as this bucket.
+	storeBucket = []byte(".store")
+
 	recordsBucket = []byte("records")
 	contentBucket = []byte("content")
 	keysBucket    = []byte("keys")
+	statsBucket   = []byte("stats")
+
+	attachmentsBucket = []byte("attachments")
+
+	textBucket      = []byte("text")
+	termsBucket     = []byte("terms")
+	documentsBucket = []byte("documents")
+
+	headersBucket        = []byte("headers")
+	indexedHeadersBucket = []byte("indexed-headers")
+
+	timesBucket   = []byte("times")
+	createdBucket = []byte("created")
+	updatedBucket = []byte("updated")
+
+	typesBucket = []byte("types")
+
+	changesBucket    = []byte("changes")
+	logBucket        = []byte("log")
+	tombstonesBucket = []byte("tombstones")
+
+	deletedBucket = []byte("deleted")
 )
 
 // Store is the data store for a single namespace.
 type Store struct {
-	Records *bolt.Bucket
-	Content *bolt.Bucket
-	Keys    *bolt.Bucket
+	Records *Bucket
+	Content *Bucket
+	Keys    *Bucket
+
+	// Stats holds counters that describe the contents of the store. They are
+	// used to estimate the cost of a query.
+	Stats *Bucket
+
+	// KeyCounts holds the number of documents that have each key. It is nested
+	// within the Stats bucket.
+	KeyCounts *Bucket
+
+	// Attachments holds a nested bucket for each document that has binary
+	// attachments, which in turn holds a nested bucket for each attachment.
+	Attachments *Bucket
+
+	// Terms and TextDocuments form the full-text index. They are nested within
+	// the text bucket. TermCounts holds the number of documents that have each
+	// term, it is nested within the Stats bucket.
+	Terms         *Bucket
+	TextDocuments *Bucket
+	TermCounts    *Bucket
+
+	// Headers is the index of the documents' indexed headers. HeaderCounts holds
+	// the number of documents that have each header value, it is nested within
+	// the Stats bucket.
+	Headers      *Bucket
+	HeaderCounts *Bucket
+
+	// CompleteHeaders holds the names of the headers for which every document in
+	// the store has been added to the header index.
+	CompleteHeaders *Bucket
+
+	// Created and Updated order the documents by the time at which they were
+	// created and last updated. They are nested within the times bucket.
+	Created *Bucket
+	Updated *Bucket
+
+	// Types is the index of the documents' content types. TypeCounts holds the
+	// number of documents with each type, it is nested within the Stats bucket.
+	Types      *Bucket
+	TypeCounts *Bucket
+
+	// ChangeLog and Tombstones form the index of changes to the documents,
+	// ordered by sequence number. They are nested within the changes bucket.
+	ChangeLog  *Bucket
+	Tombstones *Bucket
+
+	// DeletedRecords holds the records of soft-deleted documents, and
+	// DeletedKeys holds the unique keys that remain reserved by them. They are
+	// nested within the deleted bucket.
+	DeletedRecords *Bucket
+	DeletedKeys    *Bucket
+
+	// Sequences and Resets hold the sequence number of the most recent write to
+	// each namespace, and the sequence number at which each namespace was most
+	// recently deleted, respectively. They are nested within the top-level
+	// sequences bucket, and are shared by the stores of all namespaces.
+	Sequences *Bucket
+	Resets    *Bucket
+
+	ns         string
+	sealer     Sealer
+	compressor Compressor
+
+	indexedHeaders    map[string]struct{}
+	textIndex         *document.TextIndex
+	softDelete        bool
+	releaseUniqueKeys bool
 }
 
 // OpenStore returns the store for the given namespace.
 //
 // It returns false if the store does not exist.
-func OpenStore(tx *bolt.Tx, ns string) (*Store, bool, error) {
+func OpenStore(tx *Tx, ns string) (*Store, bool, error) {
+	if tx.IsClosed() {
+		return nil, false, protavo.ErrTxClosed
+	}
+
 	parent := tx.Bucket(rootBucket)
 	if parent == nil {
 		return nil, false, nil
@@ -39,41 +141,193 @@ func OpenStore(tx *bolt.Tx, ns string) (*Store, bool, error) {
 		}
 	}
 
-	s := &Store{}
+	// the namespace's bucket may exist only as the parent of a sub-namespace
+	parent = parent.Bucket(storeBucket)
+	if parent == nil {
+		return nil, false, nil
+	}
+
+	s, err := openStore(tx, parent, ns)
+	return s, err == nil, err
+}
+
+// openStore returns the store that is made up of the buckets within parent,
+// the store bucket of the ns namespace.
+func openStore(tx *Tx, parent *Bucket, ns string) (*Store, error) {
+	s := &Store{
+		ns:         ns,
+		sealer:     tx.Sealer,
+		compressor: tx.Compressor,
+
+		indexedHeaders:    tx.IndexedHeaders,
+		textIndex:         tx.TextIndex,
+		softDelete:        tx.SoftDelete,
+		releaseUniqueKeys: tx.ReleaseUniqueKeys,
+	}
 
 	s.Records = parent.Bucket(recordsBucket)
 	if s.Records == nil {
-		return nil, false, fmt.Errorf(
-			"data integrity error: missing '%s' bucket within '%s' namespace",
-			recordsBucket,
-			ns,
-		)
+		return nil, missingBucket(ns, recordsBucket)
 	}
 
 	s.Content = parent.Bucket(contentBucket)
 	if s.Content == nil {
-		return nil, false, fmt.Errorf(
-			"data integrity error: missing '%s' bucket within '%s' namespace",
-			contentBucket,
-			ns,
-		)
+		return nil, missingBucket(ns, contentBucket)
 	}
 
 	s.Keys = parent.Bucket(keysBucket)
 	if s.Keys == nil {
-		return nil, false, fmt.Errorf(
-			"data integrity error: missing '%s' bucket within '%s' namespace",
-			keysBucket,
-			ns,
-		)
+		return nil, missingBucket(ns, keysBucket)
+	}
+
+	s.Stats = parent.Bucket(statsBucket)
+	if s.Stats == nil {
+		return nil, missingBucket(ns, statsBucket)
+	}
+
+	s.KeyCounts = s.Stats.Bucket(keysBucket)
+	if s.KeyCounts == nil {
+		return nil, missingBucket(ns, statsBucket, keysBucket)
+	}
+
+	s.Attachments = parent.Bucket(attachmentsBucket)
+	if s.Attachments == nil {
+		return nil, missingBucket(ns, attachmentsBucket)
+	}
+
+	text := parent.Bucket(textBucket)
+	if text == nil {
+		return nil, missingBucket(ns, textBucket)
+	}
+
+	s.Terms = text.Bucket(termsBucket)
+	if s.Terms == nil {
+		return nil, missingBucket(ns, textBucket, termsBucket)
+	}
+
+	s.TextDocuments = text.Bucket(documentsBucket)
+	if s.TextDocuments == nil {
+		return nil, missingBucket(ns, textBucket, documentsBucket)
+	}
+
+	s.TermCounts = s.Stats.Bucket(textBucket)
+	if s.TermCounts == nil {
+		return nil, missingBucket(ns, statsBucket, textBucket)
+	}
+
+	s.Headers = parent.Bucket(headersBucket)
+	if s.Headers == nil {
+		return nil, missingBucket(ns, headersBucket)
+	}
+
+	s.HeaderCounts = s.Stats.Bucket(headersBucket)
+	if s.HeaderCounts == nil {
+		return nil, missingBucket(ns, statsBucket, headersBucket)
+	}
+
+	s.CompleteHeaders = parent.Bucket(indexedHeadersBucket)
+	if s.CompleteHeaders == nil {
+		return nil, missingBucket(ns, indexedHeadersBucket)
+	}
+
+	times := parent.Bucket(timesBucket)
+	if times == nil {
+		return nil, missingBucket(ns, timesBucket)
+	}
+
+	s.Created = times.Bucket(createdBucket)
+	if s.Created == nil {
+		return nil, missingBucket(ns, timesBucket, createdBucket)
 	}
 
-	return s, true, nil
+	s.Updated = times.Bucket(updatedBucket)
+	if s.Updated == nil {
+		return nil, missingBucket(ns, timesBucket, updatedBucket)
+	}
+
+	s.Types = parent.Bucket(typesBucket)
+	if s.Types == nil {
+		return nil, missingBucket(ns, typesBucket)
+	}
+
+	s.TypeCounts = s.Stats.Bucket(typesBucket)
+	if s.TypeCounts == nil {
+		return nil, missingBucket(ns, statsBucket, typesBucket)
+	}
+
+	changes := parent.Bucket(changesBucket)
+	if changes == nil {
+		return nil, missingBucket(ns, changesBucket)
+	}
+
+	s.ChangeLog = changes.Bucket(logBucket)
+	if s.ChangeLog == nil {
+		return nil, missingBucket(ns, changesBucket, logBucket)
+	}
+
+	s.Tombstones = changes.Bucket(tombstonesBucket)
+	if s.Tombstones == nil {
+		return nil, missingBucket(ns, changesBucket, tombstonesBucket)
+	}
+
+	deleted := parent.Bucket(deletedBucket)
+	if deleted == nil {
+		return nil, missingBucket(ns, deletedBucket)
+	}
+
+	s.DeletedRecords = deleted.Bucket(recordsBucket)
+	if s.DeletedRecords == nil {
+		return nil, missingBucket(ns, deletedBucket, recordsBucket)
+	}
+
+	s.DeletedKeys = deleted.Bucket(keysBucket)
+	if s.DeletedKeys == nil {
+		return nil, missingBucket(ns, deletedBucket, keysBucket)
+	}
+
+	sequences := tx.Bucket(sequencesBucket)
+	if sequences == nil {
+		return nil, missingBucket(ns, sequencesBucket)
+	}
+
+	s.Sequences = sequences.Bucket(currentBucket)
+	if s.Sequences == nil {
+		return nil, missingBucket(ns, sequencesBucket, currentBucket)
+	}
+
+	s.Resets = sequences.Bucket(resetBucket)
+	if s.Resets == nil {
+		return nil, missingBucket(ns, sequencesBucket, resetBucket)
+	}
+
+	if tx.Bolt.Writable() {
+		if err := s.updateCompleteHeaders(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// missingBucket returns an error indicating that the bucket at the given path
+// is missing from the store for the ns namespace.
+func missingBucket(ns string, path ...[]byte) error {
+	b := joinPath(path)
+
+	return &protavo.DataIntegrityError{
+		Namespace:   ns,
+		Bucket:      b,
+		Description: fmt.Sprintf("the '%s' bucket is missing", b),
+	}
 }
 
 // CreateStore returns the store for a single namespace, creating it if it does
 // not exist.
-func CreateStore(tx *bolt.Tx, ns string) (*Store, error) {
+func CreateStore(tx *Tx, ns string) (*Store, error) {
+	if tx.IsClosed() {
+		return nil, protavo.ErrTxClosed
+	}
+
 	parent, err := tx.CreateBucketIfNotExists(rootBucket)
 	if err != nil {
 		return nil, err
@@ -88,7 +342,27 @@ func CreateStore(tx *bolt.Tx, ns string) (*Store, error) {
 		}
 	}
 
-	s := &Store{}
+	return createStore(tx, parent, ns)
+}
+
+// createStore returns the store within parent, the bucket of the ns namespace,
+// creating it if it does not exist.
+func createStore(tx *Tx, parent *Bucket, ns string) (*Store, error) {
+	parent, err := parent.CreateBucketIfNotExists(storeBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		ns:         ns,
+		sealer:     tx.Sealer,
+		compressor: tx.Compressor,
+
+		indexedHeaders:    tx.IndexedHeaders,
+		textIndex:         tx.TextIndex,
+		softDelete:        tx.SoftDelete,
+		releaseUniqueKeys: tx.ReleaseUniqueKeys,
+	}
 
 	s.Records, err = parent.CreateBucketIfNotExists(recordsBucket)
 	if err != nil {
@@ -101,17 +375,145 @@ func CreateStore(tx *bolt.Tx, ns string) (*Store, error) {
 	}
 
 	s.Keys, err = parent.CreateBucketIfNotExists(keysBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Stats, err = parent.CreateBucketIfNotExists(statsBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.KeyCounts, err = s.Stats.CreateBucketIfNotExists(keysBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Attachments, err = parent.CreateBucketIfNotExists(attachmentsBucket)
+	if err != nil {
+		return nil, err
+	}
 
-	return s, err
+	text, err := parent.CreateBucketIfNotExists(textBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Terms, err = text.CreateBucketIfNotExists(termsBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.TextDocuments, err = text.CreateBucketIfNotExists(documentsBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.TermCounts, err = s.Stats.CreateBucketIfNotExists(textBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Headers, err = parent.CreateBucketIfNotExists(headersBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.HeaderCounts, err = s.Stats.CreateBucketIfNotExists(headersBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.CompleteHeaders, err = parent.CreateBucketIfNotExists(indexedHeadersBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	times, err := parent.CreateBucketIfNotExists(timesBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Created, err = times.CreateBucketIfNotExists(createdBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Updated, err = times.CreateBucketIfNotExists(updatedBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Types, err = parent.CreateBucketIfNotExists(typesBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.TypeCounts, err = s.Stats.CreateBucketIfNotExists(typesBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := parent.CreateBucketIfNotExists(changesBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.ChangeLog, err = changes.CreateBucketIfNotExists(logBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Tombstones, err = changes.CreateBucketIfNotExists(tombstonesBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := parent.CreateBucketIfNotExists(deletedBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.DeletedRecords, err = deleted.CreateBucketIfNotExists(recordsBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.DeletedKeys, err = deleted.CreateBucketIfNotExists(keysBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	sequences, err := tx.CreateBucketIfNotExists(sequencesBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Sequences, err = sequences.CreateBucketIfNotExists(currentBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Resets, err = sequences.CreateBucketIfNotExists(resetBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, s.updateCompleteHeaders()
 }
 
-// DeleteStore deletes the store for a given namespace.
+// DeleteStore deletes the store for a given namespace, and the stores of its
+// sub-namespaces.
+//
+// The deletion of each store is recorded with a new sequence number, such that
+// the namespace's sequence number continues to increase if it is re-created.
 // It is not an error to delete a non-existent store.
-func DeleteStore(tx *bolt.Tx, ns string) error {
-	var parent interface {
-		Bucket([]byte) *bolt.Bucket
-		DeleteBucket([]byte) error
-	} = tx
+func DeleteStore(tx *Tx, ns string) error {
+	if tx.IsClosed() {
+		return protavo.ErrTxClosed
+	}
+
+	parent := tx.root()
 
 	name := rootBucket
 
@@ -132,13 +534,66 @@ func DeleteStore(tx *bolt.Tx, ns string) error {
 		}
 	}
 
-	err := parent.DeleteBucket(name)
+	b := parent.Bucket(name)
+	if b == nil {
+		return nil
+	}
+
+	if err := walkStores(tx, b, ns, (*Store).recordReset); err != nil {
+		return err
+	}
+
+	return parent.DeleteBucket(name)
+}
 
-	if err == bolt.ErrBucketNotFound {
+// ForEachStore calls fn for the store of every namespace.
+func ForEachStore(tx *Tx, fn func(*Store) error) error {
+	root := tx.Bucket(rootBucket)
+	if root == nil {
 		return nil
 	}
 
-	return err
+	return walkStores(tx, root, "", fn)
+}
+
+// walkStores calls fn for the store of the namespace with the given bucket, if
+// any, and for the stores of all of its sub-namespaces.
+func walkStores(tx *Tx, parent *Bucket, ns string, fn func(*Store) error) error {
+	if b := parent.Bucket(storeBucket); b != nil {
+		s, err := openStore(tx, b, ns)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+
+	var names []string
+
+	if err := parent.ForEach(func(k, v []byte) error {
+		if v == nil && !bytes.Equal(k, storeBucket) {
+			names = append(names, string(k))
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		sub := name
+		if ns != "" {
+			sub = ns + "." + name
+		}
+
+		if err := walkStores(tx, parent.Bucket([]byte(name)), sub, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func splitNamespace(ns string) [][]byte {
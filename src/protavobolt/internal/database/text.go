@@ -0,0 +1,141 @@
+package database
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/jmalloc/protavo/src/protavo/document"
+)
+
+// The text bucket contains two nested buckets that form an inverted index of
+// the terms in each document's searchable text.
+//
+// The terms bucket contains a nested bucket for each term, which in turn
+// contains an empty entry for each document with that term. The documents
+// bucket contains a TextTerms message for each document, which lists its terms
+// so that they can be removed from the index when the document changes.
+
+// TextTerms returns the terms in the searchable text of doc, according to the
+// store's text index configuration.
+func (s *Store) TextTerms(doc *document.Document) []string {
+	return s.textIndex.Terms(doc)
+}
+
+// UpdateText replaces the terms of the document with the given ID.
+func (s *Store) UpdateText(id string, terms []string) error {
+	before, err := s.getDocumentTerms(id)
+	if err != nil {
+		return err
+	}
+
+	after := make(map[string]struct{}, len(terms))
+	for _, t := range terms {
+		after[t] = struct{}{}
+	}
+
+	for _, t := range before {
+		if _, ok := after[t]; ok {
+			delete(after, t)
+		} else if err := s.removeTermDocument(t, id); err != nil {
+			return err
+		}
+	}
+
+	for t := range after {
+		if err := s.addTermDocument(t, id); err != nil {
+			return err
+		}
+	}
+
+	if len(terms) == 0 {
+		return s.TextDocuments.Delete([]byte(id))
+	}
+
+	buf, err := proto.Marshal(&TextTerms{Terms: terms})
+	if err != nil {
+		return err
+	}
+
+	return s.TextDocuments.Put([]byte(id), buf)
+}
+
+// DeleteText removes the document with the given ID from the text index.
+func (s *Store) DeleteText(id string) error {
+	return s.UpdateText(id, nil)
+}
+
+// HasTerm returns true if the document with the given ID has the given term.
+func (s *Store) HasTerm(term, id string) bool {
+	b := s.Terms.Bucket([]byte(term))
+	return b != nil && b.Get([]byte(id)) != nil
+}
+
+// GetTermDocumentIDs returns the IDs of the documents that have the given term.
+func (s *Store) GetTermDocumentIDs(term string) []string {
+	b := s.Terms.Bucket([]byte(term))
+	if b == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, s.CountTermDocuments(term))
+	cur := b.Cursor()
+
+	for k, _ := cur.First(); k != nil; k, _ = cur.Next() {
+		ids = append(ids, string(k))
+	}
+
+	return ids
+}
+
+// CountTermDocuments returns the number of documents that have the given term.
+func (s *Store) CountTermDocuments(term string) int {
+	return getCount(s.TermCounts, []byte(term))
+}
+
+// getDocumentTerms returns the terms of the document with the given ID.
+func (s *Store) getDocumentTerms(id string) ([]string, error) {
+	buf := s.TextDocuments.Get([]byte(id))
+	if buf == nil {
+		return nil, nil
+	}
+
+	var t TextTerms
+	if err := proto.Unmarshal(buf, &t); err != nil {
+		return nil, err
+	}
+
+	return t.Terms, nil
+}
+
+// addTermDocument adds a document to a term.
+func (s *Store) addTermDocument(term, id string) error {
+	b, err := s.Terms.CreateBucketIfNotExists([]byte(term))
+	if err != nil {
+		return err
+	}
+
+	if err := b.Put([]byte(id), []byte{}); err != nil {
+		return err
+	}
+
+	return addCount(s.TermCounts, []byte(term), +1)
+}
+
+// removeTermDocument removes a document from a term, deleting the term if it
+// contains no other documents.
+func (s *Store) removeTermDocument(term, id string) error {
+	b := s.Terms.Bucket([]byte(term))
+	if b == nil || b.Get([]byte(id)) == nil {
+		return nil
+	}
+
+	n := s.CountTermDocuments(term) - 1
+
+	if err := putCount(s.TermCounts, []byte(term), n); err != nil {
+		return err
+	}
+
+	if n <= 0 {
+		return s.Terms.DeleteBucket([]byte(term))
+	}
+
+	return b.Delete([]byte(id))
+}
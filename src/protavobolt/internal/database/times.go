@@ -0,0 +1,136 @@
+package database
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// The times bucket contains two nested buckets that order documents by the
+// time at which they were created and last updated, respectively.
+//
+// Each entry's key is the time, followed by the document ID. The time is
+// encoded such that the keys sort chronologically, allowing a range of times
+// to be found by seeking with a cursor. The values are empty.
+
+// timePrefixSize is the size of the time portion of the keys in the times
+// buckets.
+const timePrefixSize = 12
+
+// UpdateTimes updates the time-ordered indexes for a specific document.
+//
+// before is the document's record prior to the update, or nil if it is being
+// created. after is the record after the update, or nil if it is being deleted.
+func (s *Store) UpdateTimes(id string, before, after *Record) error {
+	if err := updateTimeKey(
+		s.Created,
+		id,
+		before.GetCreatedAt(),
+		after.GetCreatedAt(),
+	); err != nil {
+		return err
+	}
+
+	return updateTimeKey(
+		s.Updated,
+		id,
+		before.GetUpdatedAt(),
+		after.GetUpdatedAt(),
+	)
+}
+
+// ScanCreatedBetween calls fn with the ID of each document that was created
+// at or after the time after, and before the time before, in chronological
+// order. A zero time leaves that end of the range unbounded.
+//
+// Iteration stops if fn returns false.
+func (s *Store) ScanCreatedBetween(after, before time.Time, fn func(string) bool) {
+	scanTimes(s.Created, after, before, fn)
+}
+
+// ScanUpdatedBetween calls fn with the ID of each document that was last
+// updated at or after the time after, and before the time before, in
+// chronological order. A zero time leaves that end of the range unbounded.
+//
+// Iteration stops if fn returns false.
+func (s *Store) ScanUpdatedBetween(after, before time.Time, fn func(string) bool) {
+	scanTimes(s.Updated, after, before, fn)
+}
+
+// scanTimes calls fn with the ID of each document in b with a time within the
+// given range.
+func scanTimes(b *Bucket, after, before time.Time, fn func(string) bool) {
+	cur := b.Cursor()
+
+	var k []byte
+	if after.IsZero() {
+		k, _ = cur.First()
+	} else {
+		k, _ = cur.Seek(timePrefix(after.Unix(), int32(after.Nanosecond())))
+	}
+
+	var end []byte
+	if !before.IsZero() {
+		end = timePrefix(before.Unix(), int32(before.Nanosecond()))
+	}
+
+	for ; k != nil; k, _ = cur.Next() {
+		if end != nil && bytes.Compare(k[:timePrefixSize], end) >= 0 {
+			return
+		}
+
+		if !fn(string(k[timePrefixSize:])) {
+			return
+		}
+	}
+}
+
+// updateTimeKey replaces the entry for a document in one of the times
+// buckets. Either of the times may be nil, in which case there is no entry.
+func updateTimeKey(b *Bucket, id string, before, after *timestamp.Timestamp) error {
+	var bk, ak []byte
+
+	if before != nil {
+		bk = timeKey(before, id)
+	}
+
+	if after != nil {
+		ak = timeKey(after, id)
+	}
+
+	if bytes.Equal(bk, ak) {
+		return nil
+	}
+
+	if bk != nil {
+		if err := b.Delete(bk); err != nil {
+			return err
+		}
+	}
+
+	if ak != nil {
+		return b.Put(ak, []byte{})
+	}
+
+	return nil
+}
+
+// timeKey returns the key used for the document with the given ID within one
+// of the times buckets.
+func timeKey(t *timestamp.Timestamp, id string) []byte {
+	return append(timePrefix(t.Seconds, t.Nanos), id...)
+}
+
+// timePrefix returns the time portion of a key within one of the times
+// buckets.
+//
+// The sign bit of the seconds is flipped so that times before the Unix epoch
+// sort before those after it.
+func timePrefix(sec int64, nsec int32) []byte {
+	buf := make([]byte, timePrefixSize)
+	binary.BigEndian.PutUint64(buf, uint64(sec)^(1<<63))
+	binary.BigEndian.PutUint32(buf[8:], uint32(nsec))
+	return buf
+}
@@ -0,0 +1,178 @@
+package database
+
+import (
+	"fmt"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+)
+
+// Tx is a BoltDB transaction.
+//
+// While the transaction has savepoints, every change made via the buckets
+// obtained from the transaction is recorded in a journal, allowing the changes
+// to be undone using RollbackTo().
+type Tx struct {
+	Bolt *bolt.Tx
+
+	// Sealer is used to encrypt and decrypt document content. If it is nil,
+	// content is stored unencrypted.
+	Sealer Sealer
+
+	// Compressor is used to compress and decompress document content. If it is
+	// nil, content is stored uncompressed.
+	Compressor Compressor
+
+	// IndexedHeaders is the set of header names that are indexed. Conditions on
+	// any other header are checked by loading the document's content.
+	IndexedHeaders map[string]struct{}
+
+	// TextIndex determines which content fields and headers are added to the
+	// full-text index. If it is nil, no text is indexed.
+	TextIndex *document.TextIndex
+
+	// SoftDelete, if true, causes deleted documents to be retained, such that
+	// they can be restored. ReleaseUniqueKeys, if true, allows the unique keys
+	// of soft-deleted documents to be used by other documents.
+	SoftDelete        bool
+	ReleaseUniqueKeys bool
+
+	journal    []undo
+	savepoints []int
+}
+
+// undo is a function that reverses a single change to a BoltDB transaction.
+type undo func(*bolt.Tx) error
+
+// NewTx returns a Tx that wraps the given BoltDB transaction.
+func NewTx(tx *bolt.Tx) *Tx {
+	return &Tx{Bolt: tx}
+}
+
+// IsClosed returns true if the transaction has been committed or rolled back.
+func (tx *Tx) IsClosed() bool {
+	return tx.Bolt.DB() == nil
+}
+
+// Bucket returns the top-level bucket with the given name.
+// It returns nil if the bucket does not exist.
+func (tx *Tx) Bucket(name []byte) *Bucket {
+	return tx.root().Bucket(name)
+}
+
+// CreateBucketIfNotExists returns the top-level bucket with the given name,
+// creating it if it does not exist.
+func (tx *Tx) CreateBucketIfNotExists(name []byte) (*Bucket, error) {
+	return tx.root().CreateBucketIfNotExists(name)
+}
+
+// DeleteBucket deletes the top-level bucket with the given name.
+func (tx *Tx) DeleteBucket(name []byte) error {
+	return tx.root().DeleteBucket(name)
+}
+
+// Savepoint records the current state of the transaction and returns an
+// identifier that can be passed to RollbackTo().
+func (tx *Tx) Savepoint() int {
+	tx.savepoints = append(tx.savepoints, len(tx.journal))
+	return len(tx.savepoints)
+}
+
+// RollbackTo undoes all of the changes made since the savepoint sp was
+// created.
+//
+// Any savepoints created after sp are discarded. sp itself remains valid, and
+// may be rolled back to again.
+func (tx *Tx) RollbackTo(sp int) error {
+	if sp < 1 || sp > len(tx.savepoints) {
+		return fmt.Errorf("can not roll back to unknown savepoint (%d)", sp)
+	}
+
+	n := tx.savepoints[sp-1]
+
+	for i := len(tx.journal) - 1; i >= n; i-- {
+		if err := tx.journal[i](tx.Bolt); err != nil {
+			return err
+		}
+	}
+
+	tx.journal = tx.journal[:n]
+	tx.savepoints = tx.savepoints[:sp]
+
+	return nil
+}
+
+// Release discards the savepoint sp, and any savepoints created after it,
+// keeping the changes made since sp was created.
+//
+// Once there are no remaining savepoints, the journal is discarded, as there is
+// no savepoint that its entries could be used to roll back to.
+func (tx *Tx) Release(sp int) error {
+	if sp < 1 || sp > len(tx.savepoints) {
+		return fmt.Errorf("can not release unknown savepoint (%d)", sp)
+	}
+
+	tx.savepoints = tx.savepoints[:sp-1]
+
+	if len(tx.savepoints) == 0 {
+		tx.journal = nil
+	}
+
+	return nil
+}
+
+// isJournaling returns true if changes need to be recorded in the journal.
+func (tx *Tx) isJournaling() bool {
+	return len(tx.savepoints) > 0
+}
+
+// record adds an entry to the journal.
+func (tx *Tx) record(u undo) {
+	tx.journal = append(tx.journal, u)
+}
+
+// root returns a pseudo-bucket that represents the transaction itself, such
+// that top-level buckets can be manipulated in the same way as nested buckets.
+func (tx *Tx) root() *Bucket {
+	return &Bucket{tx: tx}
+}
+
+// resolve returns the bucket at the given path.
+func resolve(tx *bolt.Tx, path [][]byte) (*bolt.Bucket, error) {
+	var b *bolt.Bucket
+
+	for i, name := range path {
+		if i == 0 {
+			b = tx.Bucket(name)
+		} else {
+			b = b.Bucket(name)
+		}
+
+		if b == nil {
+			p := joinPath(path[:i+1])
+
+			return nil, &protavo.DataIntegrityError{
+				Bucket:      p,
+				Description: fmt.Sprintf("can not undo changes to '%s', bucket is missing", p),
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// joinPath returns a human-readable representation of a bucket path.
+func joinPath(path [][]byte) string {
+	s := ""
+
+	for i, name := range path {
+		if i > 0 {
+			s += "/"
+		}
+
+		s += string(name)
+	}
+
+	return s
+}
@@ -0,0 +1,107 @@
+package database
+
+// The types bucket contains a nested bucket for each content type URL, which
+// in turn contains an empty entry for each document with content of that type.
+//
+// Documents that were saved before content types were indexed have an empty
+// content type in their record, and are not present in the index. The number
+// of such documents is stored in the stats bucket, as the index can only be
+// used to find documents once there are none left.
+
+var untypedDocumentCountKey = []byte("untyped-documents")
+
+// UpdateContentType updates the content type index for a specific document.
+//
+// before is the document's record prior to the update, or nil if it is being
+// created. after is the record after the update, or nil if it is being deleted.
+func (s *Store) UpdateContentType(id string, before, after *Record) error {
+	if before != nil && before.ContentType == "" {
+		if err := addCount(s.Stats, untypedDocumentCountKey, -1); err != nil {
+			return err
+		}
+	}
+
+	b := before.GetContentType()
+	a := after.GetContentType()
+
+	if a == b {
+		return nil
+	}
+
+	if b != "" {
+		if err := s.removeContentTypeDocument(b, id); err != nil {
+			return err
+		}
+	}
+
+	if a != "" {
+		return s.addContentTypeDocument(a, id)
+	}
+
+	return nil
+}
+
+// HasUntypedDocuments returns true if the store contains documents that are
+// not present in the content type index.
+func (s *Store) HasUntypedDocuments() bool {
+	return getCount(s.Stats, untypedDocumentCountKey) > 0
+}
+
+// GetContentTypeDocumentIDs returns the IDs of the documents with content of
+// the type identified by the given URL.
+func (s *Store) GetContentTypeDocumentIDs(url string) []string {
+	b := s.Types.Bucket([]byte(url))
+	if b == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, s.CountContentTypeDocuments(url))
+	cur := b.Cursor()
+
+	for k, _ := cur.First(); k != nil; k, _ = cur.Next() {
+		ids = append(ids, string(k))
+	}
+
+	return ids
+}
+
+// CountContentTypeDocuments returns the number of documents with content of
+// the type identified by the given URL.
+func (s *Store) CountContentTypeDocuments(url string) int {
+	return getCount(s.TypeCounts, []byte(url))
+}
+
+// addContentTypeDocument adds a document to a content type.
+func (s *Store) addContentTypeDocument(url, id string) error {
+	b, err := s.Types.CreateBucketIfNotExists([]byte(url))
+	if err != nil {
+		return err
+	}
+
+	if err := b.Put([]byte(id), []byte{}); err != nil {
+		return err
+	}
+
+	return addCount(s.TypeCounts, []byte(url), +1)
+}
+
+// removeContentTypeDocument removes a document from a content type, deleting
+// the content type if it contains no other documents.
+func (s *Store) removeContentTypeDocument(url, id string) error {
+	b := s.Types.Bucket([]byte(url))
+	if b == nil || b.Get([]byte(id)) == nil {
+		return nil
+	}
+
+	n := s.CountContentTypeDocuments(url) - 1
+
+	if err := putCount(s.TypeCounts, []byte(url), n); err != nil {
+		return err
+	}
+
+	if n <= 0 {
+		return s.Types.DeleteBucket([]byte(url))
+	}
+
+	return b.Delete([]byte(id))
+}
@@ -0,0 +1,258 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+)
+
+var (
+	metaBucket = []byte("protavo.meta")
+	versionKey = []byte("version")
+)
+
+// Version is the version of the on-disk format produced by this package.
+//
+// Version 1 is the original format, which did not record its version. It
+// stored each namespace's records, content and keys buckets directly within
+// the namespace's bucket, and each entry in the keys bucket was a Key message
+// listing all of the documents with that key.
+const Version = 2
+
+// Upgrade upgrades all of the stores in the database to the current on-disk
+// format.
+//
+// ctx is checked before each document is upgraded. If it is canceled or its
+// deadline is exceeded, Upgrade() returns ctx.Err() and the transaction must
+// be rolled back.
+func Upgrade(ctx context.Context, tx *bolt.Tx) error {
+	v, err := readVersion(tx)
+	if err != nil {
+		return err
+	}
+
+	if v > Version {
+		return fmt.Errorf(
+			"the database uses version %d of the on-disk format, which is newer than the supported version (%d)",
+			v,
+			Version,
+		)
+	}
+
+	if v < Version {
+		dtx := NewTx(tx)
+
+		for _, ls := range findLegacyStores(dtx.Bucket(rootBucket), "", nil) {
+			if err := upgradeStore(ctx, dtx, ls.bucket, ls.ns); err != nil {
+				return err
+			}
+		}
+	} else if tx.Bucket(metaBucket) != nil {
+		return nil
+	}
+
+	// a new database still needs its version to be recorded, otherwise it is
+	// mistaken for a version 1 database once data has been written to it
+	return writeVersion(tx, Version)
+}
+
+// CheckVersion returns an error if the database does not use the current
+// on-disk format.
+func CheckVersion(tx *bolt.Tx) error {
+	v, err := readVersion(tx)
+	if err != nil {
+		return err
+	}
+
+	if v != Version {
+		return fmt.Errorf(
+			"the database uses version %d of the on-disk format, it must be upgraded to version %d",
+			v,
+			Version,
+		)
+	}
+
+	return nil
+}
+
+// readVersion returns the version of the on-disk format used by the database.
+func readVersion(tx *bolt.Tx) (int, error) {
+	if b := tx.Bucket(metaBucket); b != nil {
+		if buf := b.Get(versionKey); buf != nil {
+			return int(binary.BigEndian.Uint32(buf)), nil
+		}
+	}
+
+	// the first version of the on-disk format did not record its version, so
+	// any existing data must have been written in that format. if there's no
+	// data at all, there's nothing to upgrade.
+	if tx.Bucket(rootBucket) != nil {
+		return 1, nil
+	}
+
+	return Version, nil
+}
+
+// writeVersion records the version of the on-disk format used by the database.
+func writeVersion(tx *bolt.Tx, v int) error {
+	b, err := tx.CreateBucketIfNotExists(metaBucket)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, uint32(v))
+
+	return b.Put(versionKey, buf)
+}
+
+// legacyStore is a store that uses version 1 of the on-disk format.
+type legacyStore struct {
+	bucket *Bucket
+	ns     string
+}
+
+// findLegacyStores returns the version 1 stores within parent, the bucket of
+// the ns namespace, including the stores of its sub-namespaces.
+func findLegacyStores(parent *Bucket, ns string, stores []legacyStore) []legacyStore {
+	if parent == nil {
+		return stores
+	}
+
+	if parent.Bucket(recordsBucket) != nil {
+		stores = append(stores, legacyStore{parent, ns})
+	}
+
+	var names []string
+
+	_ = parent.ForEach(func(k, v []byte) error {
+		if v == nil && !isLegacyStoreBucket(k) {
+			names = append(names, string(k))
+		}
+
+		return nil
+	})
+
+	for _, name := range names {
+		sub := name
+		if ns != "" {
+			sub = ns + "." + name
+		}
+
+		stores = findLegacyStores(parent.Bucket([]byte(name)), sub, stores)
+	}
+
+	return stores
+}
+
+// isLegacyStoreBucket returns true if k is the name of one of the buckets that
+// make up a version 1 store, as opposed to a sub-namespace.
+func isLegacyStoreBucket(k []byte) bool {
+	return bytes.Equal(k, recordsBucket) ||
+		bytes.Equal(k, contentBucket) ||
+		bytes.Equal(k, keysBucket)
+}
+
+// upgradeStore converts a version 1 store to the current on-disk format.
+//
+// b is the bucket of the ns namespace. Each document is copied to a new store
+// within b, which maintains the indexes and counters exactly as it does when a
+// document is saved, before the version 1 buckets are deleted. The key index is
+// rebuilt from the keys in each document's record.
+//
+// Each document is assigned a sequence number, in order of document ID, so that
+// they are all reported as changes since sequence number 0. The full-text and
+// header indexes are left empty, as they depend on the driver's configuration.
+func upgradeStore(ctx context.Context, tx *Tx, b *Bucket, ns string) error {
+	s, err := createStore(tx, b, ns)
+	if err != nil {
+		return err
+	}
+
+	records := b.Bucket(recordsBucket)
+	content := b.Bucket(contentBucket)
+	cur := records.Cursor()
+
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		id := string(k)
+
+		rec, err := UnmarshalRecord(v)
+		if err != nil {
+			return err
+		}
+
+		buf := content.Get(k)
+		if buf == nil {
+			return &protavo.DataIntegrityError{
+				Namespace:   ns,
+				Bucket:      string(contentBucket),
+				DocumentID:  id,
+				Description: fmt.Sprintf("content for '%s' is missing", id),
+			}
+		}
+
+		c, err := unmarshalContent(buf)
+		if err != nil {
+			return err
+		}
+
+		if err := upgradeDocument(s, id, rec, c); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range [][]byte{recordsBucket, contentBucket, keysBucket} {
+		if err := b.DeleteBucket(name); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upgradeDocument adds a document from a version 1 store to s.
+func upgradeDocument(s *Store, id string, rec *Record, c *Content) error {
+	seq, err := s.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	rec.Sequence = seq
+	rec.ContentType = c.Content.GetTypeUrl()
+
+	if rec.ContentType == "" {
+		if err := addCount(s.Stats, untypedDocumentCountKey, +1); err != nil {
+			return err
+		}
+	}
+
+	if err := s.UpdateKeys(id, nil, rec.Keys); err != nil {
+		return err
+	}
+
+	if err := s.UpdateTimes(id, nil, rec); err != nil {
+		return err
+	}
+
+	if err := s.UpdateContentType(id, nil, rec); err != nil {
+		return err
+	}
+
+	if err := s.RecordChange(id, nil, rec); err != nil {
+		return err
+	}
+
+	if err := s.PutRecord(id, rec); err != nil {
+		return err
+	}
+
+	return s.PutContent(id, c)
+}
@@ -0,0 +1,47 @@
+package protavobolt
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// executeListKeys returns the keys that begin with prefix. If t is non-zero,
+// only keys of that type are returned.
+func executeListKeys(
+	ctx context.Context,
+	tx *database.Tx,
+	ns string,
+	prefix string,
+	t document.KeyType,
+) ([]driver.Key, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s, ok, err := database.OpenStore(tx, ns)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	var keys []driver.Key
+
+	s.ScanKeys(prefix, func(key string, kt uint32, n int) bool {
+		if t == 0 || uint32(t) == kt {
+			keys = append(
+				keys,
+				driver.Key{
+					Name:  key,
+					Type:  document.KeyType(kt),
+					Count: n,
+				},
+			)
+		}
+
+		return true
+	})
+
+	return keys, nil
+}
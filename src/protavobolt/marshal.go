@@ -1,6 +1,8 @@
 package protavobolt
 
 import (
+	"sort"
+
 	"github.com/golang/protobuf/ptypes"
 	"github.com/jmalloc/protavo/src/protavo/document"
 	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
@@ -13,12 +15,13 @@ func newDocument(
 	c *database.Content,
 ) (*document.Document, error) {
 	doc := &document.Document{
-		ID:   id,
-		Keys: unmarshalKeys(rec.Keys),
+		ID: id,
 	}
 
+	doc.Keys, _ = unmarshalRecordKeys(rec)
+
 	if err := unmarshalContent(c, doc); err != nil {
-		return nil, nil
+		return nil, err
 	}
 
 	return doc, unmarshalRecordManagedFields(rec, doc)
@@ -41,6 +44,7 @@ func unmarshalRecordManagedFields(rec *database.Record, doc *document.Document)
 	doc.Revision = rec.Revision
 	doc.CreatedAt = createdAt
 	doc.UpdatedAt = updatedAt
+	_, doc.DerivedKeys = unmarshalRecordKeys(rec)
 
 	return nil
 }
@@ -80,6 +84,50 @@ func marshalKeys(keys map[string]document.KeyType) map[string]uint32 {
 	return r
 }
 
+// marshalRecordKeys converts a document's keys, and the keys derived from its
+// content, from the public API to database format.
+//
+// It returns all of the keys, and the names of the derived keys. A derived key
+// that is also applied explicitly is not considered derived.
+func marshalRecordKeys(keys, derived document.Keys) (map[string]uint32, []string) {
+	r := marshalKeys(keys)
+
+	var names []string
+	for k, v := range derived {
+		if _, ok := r[k]; !ok {
+			r[k] = uint32(v)
+			names = append(names, k)
+		}
+	}
+
+	sort.Strings(names)
+
+	return r, names
+}
+
+// unmarshalRecordKeys converts the keys in a record from the database to public
+// API format.
+//
+// It returns the keys that were applied explicitly, and the keys that were
+// derived from the document's content. derived is nil if there are no derived
+// keys.
+func unmarshalRecordKeys(rec *database.Record) (keys, derived document.Keys) {
+	keys = unmarshalKeys(rec.Keys)
+
+	for _, k := range rec.DerivedKeys {
+		if t, ok := keys[k]; ok {
+			if derived == nil {
+				derived = document.Keys{}
+			}
+
+			derived[k] = t
+			delete(keys, k)
+		}
+	}
+
+	return keys, derived
+}
+
 // unmarshalKeys converts a key map from the database to public API format.
 func unmarshalKeys(keys map[string]uint32) map[string]document.KeyType {
 	r := make(map[string]document.KeyType, len(keys))
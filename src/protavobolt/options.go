@@ -0,0 +1,85 @@
+package protavobolt
+
+import (
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+)
+
+// Option is a function that configures a database opened by OpenExclusive()
+// or OpenTemp().
+//
+// Each of the options sets the ExclusiveDriver field of the same name, see
+// ExclusiveDriver for details.
+type Option func(*openOptions)
+
+// openOptions is the configuration built by a set of options.
+type openOptions struct {
+	driver *ExclusiveDriver
+	db     []protavo.Option
+}
+
+// WithDBOptions returns an option that applies opts to the protavo.DB.
+func WithDBOptions(opts ...protavo.Option) Option {
+	return func(o *openOptions) {
+		o.db = append(o.db, opts...)
+	}
+}
+
+// WithBatchWrites returns an option that enables group-commit of concurrent
+// writes.
+func WithBatchWrites() Option {
+	return func(o *openOptions) {
+		o.driver.BatchWrites = true
+	}
+}
+
+// WithKeyProvider returns an option that encrypts document content and headers
+// using the keys supplied by p.
+func WithKeyProvider(p KeyProvider) Option {
+	return func(o *openOptions) {
+		o.driver.KeyProvider = p
+	}
+}
+
+// WithIndexedHeaders returns an option that indexes the headers with the given
+// names.
+func WithIndexedHeaders(names ...string) Option {
+	return func(o *openOptions) {
+		o.driver.IndexedHeaders = append(o.driver.IndexedHeaders, names...)
+	}
+}
+
+// WithTextIndex returns an option that makes the content fields and headers in
+// ti searchable.
+func WithTextIndex(ti *document.TextIndex) Option {
+	return func(o *openOptions) {
+		o.driver.TextIndex = ti
+	}
+}
+
+// WithCompression returns an option that compresses the content of documents
+// using the settings returned by fn for each namespace.
+func WithCompression(fn func(ns string) *Compression) Option {
+	return func(o *openOptions) {
+		o.driver.Compression = fn
+	}
+}
+
+// WithSoftDelete returns an option that retains deleted documents, such that
+// they can be restored.
+func WithSoftDelete(sd *SoftDelete) Option {
+	return func(o *openOptions) {
+		o.driver.SoftDelete = sd
+	}
+}
+
+// newDB returns a DB that uses d, configured by opts.
+func newDB(d *ExclusiveDriver, opts []Option) (*protavo.DB, error) {
+	o := &openOptions{driver: d}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return protavo.NewDB(d, o.db...)
+}
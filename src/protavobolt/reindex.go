@@ -0,0 +1,80 @@
+package protavobolt
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// reindex calls fn for every document, in all namespaces, in order to rebuild
+// an index. If done is non-nil, it is called for each store once all of its
+// documents have been re-indexed.
+//
+// It is performed within a single transaction. It returns the number of
+// documents that were indexed.
+func (d *ExclusiveDriver) reindex(
+	ctx context.Context,
+	fn func(*database.Store, *database.Record, *document.Document) error,
+	done func(*database.Store) error,
+) (int, error) {
+	if err := d.init(ctx); err != nil {
+		return 0, err
+	}
+
+	btx, err := d.begin(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+	defer btx.Rollback()
+
+	n := 0
+	err = database.ForEachStore(d.newTx(btx), func(s *database.Store) error {
+		var ids []string
+
+		if err := s.Records.ForEach(func(k, _ []byte) error {
+			ids = append(ids, string(k))
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			rec, err := s.GetRecord(id)
+			if err != nil {
+				return err
+			}
+
+			c, err := s.GetContent(id)
+			if err != nil {
+				return err
+			}
+
+			doc, err := newDocument(id, rec, c)
+			if err != nil {
+				return err
+			}
+
+			if err := fn(s, rec, doc); err != nil {
+				return err
+			}
+		}
+
+		n += len(ids)
+
+		if done != nil {
+			return done(s)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, btx.Commit()
+}
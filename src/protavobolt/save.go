@@ -1,7 +1,8 @@
 package protavobolt
 
 import (
-	bolt "github.com/coreos/bbolt"
+	"context"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/jmalloc/protavo/src/protavo"
@@ -11,11 +12,17 @@ import (
 
 // executeSave creates or updates a set of documents.
 func executeSave(
-	tx *bolt.Tx,
+	ctx context.Context,
+	tx *database.Tx,
 	ns string,
 	doc *document.Document,
+	derived document.Keys,
 	force bool,
 ) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	s, err := database.CreateStore(tx, ns)
 	if err != nil {
 		return err
@@ -37,20 +44,15 @@ func executeSave(
 
 	var new *database.Record
 	if exists {
-		new, err = updateRecord(s, doc, rec)
-	} else {
-		new, err = createRecord(s, doc)
-	}
-	if err != nil {
-		return err
+		new, err = updateRecord(s, doc, derived, rec)
+	} else if err = checkDeletedID(s, doc); err == nil {
+		new, err = createRecord(s, doc, derived)
 	}
-
-	c, err := marshalContent(doc)
 	if err != nil {
 		return err
 	}
 
-	if err := s.PutContent(doc.ID, c); err != nil {
+	if err := putContent(s, doc); err != nil {
 		return err
 	}
 
@@ -58,23 +60,31 @@ func executeSave(
 }
 
 // createRecord creates a new document record.
+//
+// derived is the set of keys derived from the document's content.
 func createRecord(
 	s *database.Store,
 	doc *document.Document,
+	derived document.Keys,
 ) (*database.Record, error) {
+	seq, err := s.NextSequence()
+	if err != nil {
+		return nil, err
+	}
+
 	now := ptypes.TimestampNow()
 	new := &database.Record{
-		Revision:  1,
-		Keys:      marshalKeys(doc.Keys),
-		CreatedAt: now,
-		UpdatedAt: now,
+		Revision:    1,
+		Headers:     s.IndexedHeaders(doc.Headers),
+		ContentType: document.TypeURL(doc.Content),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Sequence:    seq,
 	}
 
-	if err := s.PutRecord(doc.ID, new); err != nil {
-		return nil, err
-	}
+	new.Keys, new.DerivedKeys = marshalRecordKeys(doc.Keys, derived)
 
-	if err := s.UpdateKeys(doc.ID, nil, new.Keys); err != nil {
+	if err := putRecord(s, doc.ID, nil, new); err != nil {
 		return nil, err
 	}
 
@@ -82,23 +92,79 @@ func createRecord(
 }
 
 // updateRecord updates an existing document record.
+//
+// derived is the set of keys derived from the document's content. It replaces
+// the keys that were derived when the document was last saved.
 func updateRecord(
 	s *database.Store,
 	doc *document.Document,
+	derived document.Keys,
 	rec *database.Record,
 ) (*database.Record, error) {
 	new := proto.Clone(rec).(*database.Record)
 	new.Revision++
-	new.Keys = marshalKeys(doc.Keys)
+	new.Keys, new.DerivedKeys = marshalRecordKeys(doc.Keys, derived)
+	new.Headers = s.IndexedHeaders(doc.Headers)
+	new.ContentType = document.TypeURL(doc.Content)
 	new.UpdatedAt = ptypes.TimestampNow()
 
-	if err := s.PutRecord(doc.ID, new); err != nil {
+	seq, err := s.NextSequence()
+	if err != nil {
 		return nil, err
 	}
 
-	if err := s.UpdateKeys(doc.ID, rec.Keys, new.Keys); err != nil {
+	new.Sequence = seq
+
+	if err := putRecord(s, doc.ID, rec, new); err != nil {
 		return nil, err
 	}
 
 	return new, nil
 }
+
+// putRecord stores a document record and updates the indexes that are derived
+// from it.
+//
+// before is the document's existing record, or nil if it is being created.
+func putRecord(
+	s *database.Store,
+	id string,
+	before, after *database.Record,
+) error {
+	if err := s.PutRecord(id, after); err != nil {
+		return err
+	}
+
+	if err := s.UpdateKeys(id, before.GetKeys(), after.Keys); err != nil {
+		return err
+	}
+
+	if err := s.UpdateHeaders(id, before.GetHeaders(), after.Headers); err != nil {
+		return err
+	}
+
+	if err := s.UpdateTimes(id, before, after); err != nil {
+		return err
+	}
+
+	if err := s.UpdateContentType(id, before, after); err != nil {
+		return err
+	}
+
+	return s.RecordChange(id, before, after)
+}
+
+// putContent stores a document's content and updates the indexes that are
+// derived from it.
+func putContent(s *database.Store, doc *document.Document) error {
+	c, err := marshalContent(doc)
+	if err != nil {
+		return err
+	}
+
+	if err := s.PutContent(doc.ID, c); err != nil {
+		return err
+	}
+
+	return s.UpdateText(doc.ID, s.TextTerms(doc))
+}
@@ -0,0 +1,188 @@
+package protavobolt
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// SoftDelete is the configuration of a driver that retains deleted documents,
+// such that they can be restored.
+type SoftDelete struct {
+	// ReleaseUniqueKeys, if true, allows the unique keys of a soft-deleted
+	// document to be used by other documents. Otherwise, they remain reserved
+	// until the deleted document is purged.
+	//
+	// If another document has taken one of its unique keys, a released document
+	// can not be restored.
+	ReleaseUniqueKeys bool
+}
+
+// executeRestore restores a soft-deleted document.
+//
+// It returns false if there is no soft-deleted document with the given ID.
+func executeRestore(
+	ctx context.Context,
+	tx *database.Tx,
+	ns string,
+	id string,
+) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	s, ok, err := database.OpenStore(tx, ns)
+	if !ok || err != nil {
+		return false, err
+	}
+
+	rec, ok, err := s.TryGetDeletedRecord(id)
+	if !ok || err != nil {
+		return false, err
+	}
+
+	if err := s.DeleteDeletedRecord(id, rec); err != nil {
+		return false, err
+	}
+
+	c, err := s.GetContent(id)
+	if err != nil {
+		return false, err
+	}
+
+	doc, err := newDocument(id, rec, c)
+	if err != nil {
+		return false, err
+	}
+
+	seq, err := s.NextSequence()
+	if err != nil {
+		return false, err
+	}
+
+	new := proto.Clone(rec).(*database.Record)
+	new.Revision++
+	new.UpdatedAt = ptypes.TimestampNow()
+	new.DeletedAt = nil
+	new.Sequence = seq
+
+	// the indexed headers may have changed since the document was deleted
+	new.Headers = s.IndexedHeaders(doc.Headers)
+
+	if err := putRecord(s, id, nil, new); err != nil {
+		return false, err
+	}
+
+	return true, s.UpdateText(id, s.TextTerms(doc))
+}
+
+// executePurgeDeleted permanently removes the documents that were soft-deleted
+// at or before the given time.
+func executePurgeDeleted(
+	ctx context.Context,
+	tx *database.Tx,
+	ns string,
+	before time.Time,
+	fn driver.DeleteWhereFunc,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s, ok, err := database.OpenStore(tx, ns)
+	if !ok || err != nil {
+		return err
+	}
+
+	var ids []string
+
+	// the IDs are collected before purging, as BoltDB does not support
+	// modifying a bucket while iterating over it
+	if err := s.ScanDeletedRecords(
+		func(id string, rec *database.Record) (bool, error) {
+			t, err := ptypes.Timestamp(rec.DeletedAt)
+			if err != nil {
+				return false, err
+			}
+
+			if !t.After(before) {
+				ids = append(ids, id)
+			}
+
+			return true, nil
+		},
+	); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if _, err := purgeDeletedDocument(s, id); err != nil {
+			return err
+		}
+
+		if fn != nil {
+			if err := fn(id); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkDeletedID returns an error if the ID of doc belongs to a soft-deleted
+// document. The ID can not be reused until the deleted document is restored or
+// purged.
+func checkDeletedID(s *database.Store, doc *document.Document) error {
+	rec, ok, err := s.TryGetDeletedRecord(doc.ID)
+	if !ok || err != nil {
+		return err
+	}
+
+	return &protavo.OptimisticLockError{
+		DocumentID: doc.ID,
+		GivenRev:   doc.Revision,
+		ActualRev:  rec.Revision,
+		Operation:  "save",
+	}
+}
+
+// putDeletedRecord retains the record of a document that has been removed from
+// the indexes by applyDelete().
+func putDeletedRecord(s *database.Store, id string, rec *database.Record) error {
+	rec = proto.Clone(rec).(*database.Record)
+	rec.DeletedAt = ptypes.TimestampNow()
+
+	return s.PutDeletedRecord(id, rec)
+}
+
+// purgeDeletedDocument permanently removes a soft-deleted document, including
+// its content and attachments.
+//
+// It returns false if there is no soft-deleted document with the given ID.
+func purgeDeletedDocument(s *database.Store, id string) (bool, error) {
+	rec, ok, err := s.TryGetDeletedRecord(id)
+	if !ok || err != nil {
+		return false, err
+	}
+
+	if err := s.DeleteDeletedRecord(id, rec); err != nil {
+		return false, err
+	}
+
+	if err := s.DeleteContent(id); err != nil {
+		return false, err
+	}
+
+	return true, s.DeleteAttachments(id)
+}
@@ -0,0 +1,278 @@
+package protavobolt_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver/drivertest"
+	. "github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+func init() {
+	var dir string
+
+	drivertest.Describe(
+		"protavobolt.ExclusiveDriver (soft delete)",
+		func() (*protavo.DB, error) {
+			var err error
+			dir, err = ioutil.TempDir("", "protavobolt-")
+			if err != nil {
+				return nil, err
+			}
+
+			return openSoftDelete(
+				path.Join(dir, "bolt.db"),
+				&SoftDelete{ReleaseUniqueKeys: true},
+			)
+		},
+		func() {
+			_ = os.RemoveAll(dir)
+		},
+	)
+}
+
+var _ = g.Describe("ExclusiveDriver (soft delete)", func() {
+	var (
+		ctx  = context.Background()
+		dir  string
+		file string
+		db   *protavo.DB
+		doc  *document.Document
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "protavobolt-")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		file = path.Join(dir, "bolt.db")
+
+		db, err = openSoftDelete(file, &SoftDelete{})
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		doc = &document.Document{
+			ID:      "doc-1",
+			Keys:    document.UniqueKeys("uniq"),
+			Content: document.StringContent("<content>"),
+		}
+
+		err = db.Save(ctx, doc)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = db.PutAttachment(ctx, "doc-1", "a", strings.NewReader("<attachment>"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = db.Delete(ctx, doc)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.AfterEach(func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	g.It("hides deleted documents and their attachments", func() {
+		_, ok, err := db.Load(ctx, "doc-1")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(ok).To(m.BeFalse())
+
+		_, ok, err = db.LoadByUniqueKey(ctx, "uniq")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(ok).To(m.BeFalse())
+
+		attachments, err := db.ListAttachments(ctx, "doc-1")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(attachments).To(m.BeEmpty())
+	})
+
+	g.Describe("Restore", func() {
+		g.It("restores the document with a new revision", func() {
+			ok, err := db.Restore(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+
+			restored, ok, err := db.LoadByUniqueKey(ctx, "uniq")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+			m.Expect(restored.ID).To(m.Equal("doc-1"))
+			m.Expect(restored.Revision).To(m.BeNumerically("==", 2))
+			m.Expect(restored.Content).To(m.Equal(document.StringContent("<content>")))
+		})
+
+		g.It("restores the document's attachments", func() {
+			_, err := db.Restore(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			var buf bytes.Buffer
+			ok, err := db.GetAttachment(ctx, "doc-1", "a", &buf)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+			m.Expect(buf.String()).To(m.Equal("<attachment>"))
+		})
+
+		g.It("returns false if the document has not been deleted", func() {
+			ok, err := db.Restore(ctx, "doc-x")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeFalse())
+		})
+
+		g.It("returns an error if a released unique key has been taken", func() {
+			_ = db.Close()
+
+			var err error
+			db, err = openSoftDelete(file, &SoftDelete{ReleaseUniqueKeys: true})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			doc := &document.Document{
+				ID:      "doc-2",
+				Keys:    document.UniqueKeys("released"),
+				Content: document.StringContent("<content>"),
+			}
+
+			err = db.Save(ctx, doc)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			err = db.Delete(ctx, doc)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			err = db.Save(
+				ctx,
+				&document.Document{
+					ID:      "doc-3",
+					Keys:    document.UniqueKeys("released"),
+					Content: document.StringContent("<content>"),
+				},
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			_, err = db.Restore(ctx, "doc-2")
+			m.Expect(protavo.IsDuplicateKeyError(err)).To(m.BeTrue())
+		})
+	})
+
+	g.It("reserves the unique keys of deleted documents", func() {
+		err := db.Save(
+			ctx,
+			&document.Document{
+				ID:      "doc-2",
+				Keys:    document.UniqueKeys("uniq"),
+				Content: document.StringContent("<content>"),
+			},
+		)
+		m.Expect(protavo.IsDuplicateKeyError(err)).To(m.BeTrue())
+	})
+
+	g.Describe("Save", func() {
+		g.It("does not reuse the ID of a deleted document", func() {
+			err := db.Save(
+				ctx,
+				&document.Document{
+					ID:      "doc-1",
+					Content: document.StringContent("<new>"),
+				},
+			)
+			m.Expect(err).To(m.Equal(
+				&protavo.OptimisticLockError{
+					DocumentID: "doc-1",
+					GivenRev:   0,
+					ActualRev:  1,
+					Operation:  "save",
+				},
+			))
+
+			ok, err := db.Restore(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+		})
+
+		g.It("does not reuse the ID of a deleted document when forced", func() {
+			err := db.ForceSave(
+				ctx,
+				&document.Document{
+					ID:      "doc-1",
+					Content: document.StringContent("<new>"),
+				},
+			)
+			m.Expect(protavo.IsOptimisticLockError(err)).To(m.BeTrue())
+		})
+
+		g.It("reuses the ID of a deleted document after it is purged", func() {
+			_, err := db.PurgeDeleted(ctx, 0)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			err = db.Save(
+				ctx,
+				&document.Document{
+					ID:      "doc-1",
+					Content: document.StringContent("<new>"),
+				},
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			attachments, err := db.ListAttachments(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(attachments).To(m.BeEmpty())
+
+			ok, err := db.Restore(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeFalse())
+		})
+	})
+
+	g.Describe("PurgeDeleted", func() {
+		g.It("permanently removes documents that were deleted before the cut-off", func() {
+			ids, err := db.PurgeDeleted(ctx, 0)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ids).To(m.ConsistOf("doc-1"))
+
+			ok, err := db.Restore(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeFalse())
+
+			err = db.Save(
+				ctx,
+				&document.Document{
+					ID:      "doc-2",
+					Keys:    document.UniqueKeys("uniq"),
+					Content: document.StringContent("<content>"),
+				},
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+		})
+
+		g.It("retains documents that were deleted after the cut-off", func() {
+			ids, err := db.PurgeDeleted(ctx, time.Hour)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ids).To(m.BeEmpty())
+
+			ok, err := db.Restore(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+		})
+	})
+})
+
+func openSoftDelete(file string, sd *SoftDelete) (*protavo.DB, error) {
+	bdb, err := bolt.Open(file, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return protavo.NewDB(
+		&ExclusiveDriver{
+			DB:         bdb,
+			SoftDelete: sd,
+			TextIndex:  drivertest.TextIndex,
+		},
+	)
+}
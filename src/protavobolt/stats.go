@@ -0,0 +1,60 @@
+package protavobolt
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// Stats contains statistics about the documents within a single namespace. It
+// does not include documents in sub-namespaces.
+type Stats struct {
+	// Documents is the number of documents in the namespace.
+	Documents int
+
+	// ContentBytes is the total size of the documents' content, before it is
+	// compressed or encrypted.
+	ContentBytes int
+
+	// StoredBytes is the total size of the documents' content as it is stored,
+	// after it is compressed and/or encrypted.
+	StoredBytes int
+}
+
+// CompressionRatio returns the ratio of the original size of the content to
+// its stored size. Values greater than 1 indicate that compression is saving
+// space.
+//
+// Encryption adds a small overhead to the stored size of each document, which
+// is reflected in the ratio. It returns 1 if there is no content.
+func (s Stats) CompressionRatio() float64 {
+	if s.StoredBytes == 0 {
+		return 1
+	}
+
+	return float64(s.ContentBytes) / float64(s.StoredBytes)
+}
+
+// Stats returns statistics about the documents within the namespace ns.
+func (d *ExclusiveDriver) Stats(ctx context.Context, ns string) (Stats, error) {
+	if err := d.init(ctx); err != nil {
+		return Stats{}, err
+	}
+
+	btx, err := d.begin(ctx, false)
+	if err != nil {
+		return Stats{}, err
+	}
+	defer btx.Rollback()
+
+	s, ok, err := database.OpenStore(d.newTx(btx), ns)
+	if !ok || err != nil {
+		return Stats{}, err
+	}
+
+	var st Stats
+	st.Documents = s.CountDocuments()
+	st.ContentBytes, st.StoredBytes = s.ContentSize()
+
+	return st, nil
+}
@@ -1,8 +1,10 @@
 package protavobolt
 
 import (
+	"context"
 	"errors"
 	"math"
+	"sort"
 
 	"github.com/jmalloc/protavo/src/protavo/driver"
 	"github.com/jmalloc/protavo/src/protavo/filter"
@@ -12,8 +14,9 @@ import (
 // A strategy encapsulates the "strategy" used to implement operations that
 // locate documents using a filter.
 type strategy interface {
-	Fetch(fn driver.FetchFunc) error
-	DeleteWhere(fn driver.DeleteWhereFunc) error
+	Fetch(ctx context.Context, fn driver.FetchFunc) error
+	DeleteWhere(ctx context.Context, fn driver.DeleteWhereFunc) error
+	Cursor() driver.Cursor
 }
 
 // noop is a query strategy that does nothing.
@@ -41,54 +44,247 @@ type useUniqueKeyFirst struct {
 	conds *conditions
 }
 
-// useKeysFirst is a query strategy that finds the intersection of documents
-// that have all of a specific set of keys, then applies the remaining set of
-// filters in-memory.
-type useKeysFirst struct {
+// useIndexFirst is a query strategy that uses an index to find a set of
+// candidate documents, then applies the remaining set of filters in-memory.
+type useIndexFirst struct {
 	store *database.Store
+	index index
+}
+
+// index is the part of a useIndexFirst strategy that finds the candidate
+// documents.
+type index interface {
+	// candidates returns the IDs of the candidate documents in s, along with
+	// the conditions that remain to be checked against each of them.
+	candidates(s *database.Store) ([]string, *conditions)
+}
+
+// useKeysFirst is an index that finds the intersection of documents that have
+// all of a specific set of keys.
+type useKeysFirst struct {
 	conds *conditions
 }
 
-// findDocumentIDs returns the IDs of the documents that have all of the
-// required keys
-func (qs *useKeysFirst) findDocumentIDs() (map[string]bool, error) {
-	var ids map[string]bool
+// useKeyPrefixFirst is an index that finds the documents that have any key
+// with a specific prefix.
+type useKeyPrefixFirst struct {
+	conds  *conditions
+	prefix string
+}
+
+// useTextFirst is an index that finds the documents that have the least-used
+// of a specific set of text terms.
+type useTextFirst struct {
+	conds *conditions
+}
+
+// useHeaderFirst is an index that finds the documents that have a specific
+// indexed header.
+type useHeaderFirst struct {
+	conds *conditions
+	name  string
+}
+
+// useTimeRangeFirst is an index that finds the documents that were created, or
+// last updated, within a specific time range by seeking within a time-ordered
+// index.
+type useTimeRangeFirst struct {
+	conds   *conditions
+	updated bool
+}
 
-	for key := range qs.conds.ExtractHasKeys().Values {
-		k, err := qs.store.GetKey(key)
-		if err != nil {
-			return nil, err
+// useContentTypeFirst is an index that finds the documents with content of a
+// specific set of types.
+type useContentTypeFirst struct {
+	conds *conditions
+}
+
+// candidates returns the IDs of the documents that have the least-used of
+// the required keys, along with the conditions that remain to be checked.
+//
+// The remaining keys are left in the returned conditions, such that they are
+// checked against the record of each candidate document, which needs to be
+// loaded regardless.
+func (qs *useKeysFirst) candidates(s *database.Store) ([]string, *conditions) {
+	keys, conds := qs.keysByCardinality(s)
+
+	if len(keys) > 1 {
+		conds.HasKeysCondition = &filter.HasKeys{
+			Values: filter.NewSet(keys[1:]...),
 		}
+	}
 
-		if ids == nil {
-			ids = k.Documents
-		} else {
-			for id := range ids {
-				if _, ok := k.Documents[id]; !ok {
-					delete(ids, id)
-				}
-			}
+	return s.GetKeyDocumentIDs(keys[0]), conds
+}
+
+// keysByCardinality extracts the keys from the 'HasKeys' condition, sorted by
+// the number of documents that have each key, smallest first.
+func (qs *useKeysFirst) keysByCardinality(s *database.Store) ([]string, *conditions) {
+	c, conds := qs.conds.ExtractHasKeys()
+	keys := make([]string, 0, len(c.Values))
+	counts := make(map[string]int, len(c.Values))
+
+	for k := range c.Values {
+		keys = append(keys, k)
+		counts[k] = s.CountKeyDocuments(k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return counts[keys[i]] < counts[keys[j]]
+	})
+
+	return keys, conds
+}
+
+// candidates returns the IDs of the documents that have any key with the
+// required prefix, along with the conditions that remain to be checked.
+func (qs *useKeyPrefixFirst) candidates(s *database.Store) ([]string, *conditions) {
+	_, conds := qs.conds.ExtractHasKeyWithPrefix(qs.prefix)
+	return s.GetKeyPrefixDocumentIDs(qs.prefix), conds
+}
+
+// candidates returns the IDs of the documents that have the least-used of
+// the required text terms, along with the conditions that remain to be
+// checked.
+//
+// The remaining terms are left in the returned conditions, such that they are
+// checked against the index for each candidate document.
+func (qs *useTextFirst) candidates(s *database.Store) ([]string, *conditions) {
+	terms, conds := qs.termsByCardinality(s)
+
+	if len(terms) > 1 {
+		conds.MatchesTextCondition = &filter.MatchesText{
+			Terms: filter.NewSet(terms[1:]...),
 		}
+	}
+
+	return s.GetTermDocumentIDs(terms[0]), conds
+}
+
+// termsByCardinality extracts the terms from the 'MatchesText' condition,
+// sorted by the number of documents that have each term, smallest first.
+func (qs *useTextFirst) termsByCardinality(s *database.Store) ([]string, *conditions) {
+	c, conds := qs.conds.ExtractMatchesText()
+	terms := make([]string, 0, len(c.Terms))
+	counts := make(map[string]int, len(c.Terms))
+
+	for t := range c.Terms {
+		terms = append(terms, t)
+		counts[t] = s.CountTermDocuments(t)
+	}
+
+	sort.Slice(terms, func(i, j int) bool {
+		return counts[terms[i]] < counts[terms[j]]
+	})
+
+	return terms, conds
+}
+
+// candidates returns the IDs of the documents that have the header with
+// any of the permitted values, along with the conditions that remain to be
+// checked.
+//
+// The condition on the header is not among the remaining conditions, as the
+// index is authoritative for indexed headers.
+func (qs *useHeaderFirst) candidates(s *database.Store) ([]string, *conditions) {
+	values, conds := qs.conds.ExtractHeader(qs.name)
+	if values == nil {
+		values = filter.NewSet(s.GetHeaderValues(qs.name)...)
+	}
+
+	var ids []string
+	for v := range values {
+		ids = append(ids, s.GetHeaderDocumentIDs(qs.name, v)...)
+	}
+
+	return ids, conds
+}
+
+// candidates returns the IDs of the documents within the time range, in
+// chronological order, along with the conditions that remain to be checked.
+func (qs *useTimeRangeFirst) candidates(s *database.Store) ([]string, *conditions) {
+	var (
+		ids   []string
+		conds *conditions
+	)
+
+	collect := func(id string) bool {
+		ids = append(ids, id)
+		return true
+	}
 
-		if len(ids) == 0 {
-			return nil, nil
+	if qs.updated {
+		var c *filter.UpdatedBetween
+		c, conds = qs.conds.ExtractUpdatedBetween()
+		s.ScanUpdatedBetween(c.After, c.Before, collect)
+	} else {
+		var c *filter.CreatedBetween
+		c, conds = qs.conds.ExtractCreatedBetween()
+		s.ScanCreatedBetween(c.After, c.Before, collect)
+	}
+
+	return ids, conds
+}
+
+// candidates returns the IDs of the documents with content of any of the
+// required types, along with the conditions that remain to be checked.
+func (qs *useContentTypeFirst) candidates(s *database.Store) ([]string, *conditions) {
+	var ids []string
+
+	c, conds := qs.conds.ExtractIsContentType()
+
+	for url := range c.TypeURLs {
+		ids = append(ids, s.GetContentTypeDocumentIDs(url)...)
+	}
+
+	return ids, conds
+}
+
+// countHeaderDocuments returns the number of documents that have the header
+// with the given name with any of the given values. If values is nil, any value
+// is permitted.
+func countHeaderDocuments(s *database.Store, name string, values filter.Set) int {
+	n := 0
+
+	if values == nil {
+		for _, v := range s.GetHeaderValues(name) {
+			n += s.CountHeaderDocuments(name, v)
+		}
+	} else {
+		for v := range values {
+			n += s.CountHeaderDocuments(name, v)
 		}
 	}
 
-	return ids, nil
+	return n
 }
 
 // selectStrategy returns the plan used to execute an operation that applies to documents
 // matching f.
 func selectStrategy(s *database.Store, f *filter.Filter) strategy {
+	qs, _ := planStrategy(s, f)
+	return qs
+}
+
+// planStrategy returns the strategy used to execute an operation that applies
+// to documents matching f, along with a description of the query plan.
+//
+// The cost of each strategy is estimated as the number of records that it
+// needs to examine.
+func planStrategy(s *database.Store, f *filter.Filter) (strategy, *driver.QueryPlan) {
 	f = filter.Optimize(f)
+	plan := &driver.QueryPlan{Filter: f}
 
 	if f == nil {
 		// if there's no filter, scan everything
-		return &scanRecords{s, nil}
+		plan.Strategy = StrategyScanRecords
+		plan.Cost = s.CountDocuments()
+		plan.IsFullScan = true
+		return &scanRecords{s, nil}, plan
 	} else if len(f.Conditions) == 0 {
 		// or if the filter matches nothing, perform a noop
-		return &noop{}
+		plan.Strategy = StrategyNoop
+		return &noop{}, plan
 	}
 
 	conds := &conditions{}
@@ -96,47 +292,168 @@ func selectStrategy(s *database.Store, f *filter.Filter) strategy {
 		panic(err)
 	}
 
-	// find which condition type is the MOST constrained
-	//
-	// TODO(jmalloc): the initial cost should probably be derived from the number
-	// of documents in the store, likewise the number of keys for key-related
-	// strategies, however I don't think this should get any more complex until
-	// there are benchmarks in place.
-	cheapest := math.MaxUint32
-	var qs strategy = &scanRecords{s, f}
+	// find which of the index-based strategies is the MOST constrained
+	var qs strategy
+	cheapest := math.MaxInt32
 
 	if conds.IsOneOfCondition != nil {
 		cheapest = len(conds.IsOneOfCondition.Values)
 		qs = &useIDFirst{s, conds}
+		plan.Strategy = StrategyUseIDFirst
 	}
 
 	if conds.HasUniqueKeyInCondition != nil {
+		// each unique key refers to at most one document
 		cost := len(conds.HasUniqueKeyInCondition.Values)
 		if cost < cheapest {
 			cheapest = cost
 			qs = &useUniqueKeyFirst{s, conds}
+			plan.Strategy = StrategyUseUniqueKeyFirst
 		}
 	}
 
 	if conds.HasKeysCondition != nil {
-		cost := len(conds.HasKeysCondition.Values)
+		// the documents that match are a subset of those that have the
+		// least-used key
+		cost := math.MaxInt32
+		for k := range conds.HasKeysCondition.Values {
+			if n := s.CountKeyDocuments(k); n < cost {
+				cost = n
+			}
+		}
+
 		if cost < cheapest {
-			qs = &useKeysFirst{s, conds}
+			cheapest = cost
+			qs = &useIndexFirst{s, &useKeysFirst{conds}}
+			plan.Strategy = StrategyUseKeysFirst
 		}
 	}
 
-	return qs
+	// the documents that match are a subset of those that have any of the keys
+	// with the prefix, which are counted by seeking within the index, stopping
+	// once they are known to be more expensive than the cheapest strategy so far
+	for _, c := range conds.HasKeyWithPrefixConditions {
+		cost := 0
+		s.ScanKeys(c.Prefix, func(_ string, _ uint32, n int) bool {
+			cost += n
+			return cost < cheapest
+		})
+
+		if cost < cheapest {
+			cheapest = cost
+			qs = &useIndexFirst{s, &useKeyPrefixFirst{conds, c.Prefix}}
+			plan.Strategy = StrategyUseKeyPrefixFirst
+		}
+	}
+
+	if conds.MatchesTextCondition != nil {
+		// the documents that match are a subset of those that have the
+		// least-used term
+		cost := math.MaxInt32
+		for t := range conds.MatchesTextCondition.Terms {
+			if n := s.CountTermDocuments(t); n < cost {
+				cost = n
+			}
+		}
+
+		if cost < cheapest {
+			cheapest = cost
+			qs = &useIndexFirst{s, &useTextFirst{conds}}
+			plan.Strategy = StrategyUseTextFirst
+		}
+	}
+
+	// the documents that match are a subset of those that have any indexed
+	// header with any of its permitted values
+	for name, values := range conds.headers() {
+		if !s.IsHeaderIndexed(name) {
+			continue
+		}
+
+		if cost := countHeaderDocuments(s, name, values); cost < cheapest {
+			cheapest = cost
+			qs = &useIndexFirst{s, &useHeaderFirst{conds, name}}
+			plan.Strategy = StrategyUseHeaderFirst
+		}
+	}
+
+	// the documents that match are exactly those within the time range, which
+	// are counted by seeking within the index, stopping once they are known to
+	// be more expensive than the cheapest strategy so far
+	if c := conds.CreatedBetweenCondition; c != nil {
+		cost := 0
+		s.ScanCreatedBetween(c.After, c.Before, func(string) bool {
+			cost++
+			return cost < cheapest
+		})
+
+		if cost < cheapest {
+			cheapest = cost
+			qs = &useIndexFirst{s, &useTimeRangeFirst{conds, false}}
+			plan.Strategy = StrategyUseTimeRangeFirst
+		}
+	}
+
+	if c := conds.UpdatedBetweenCondition; c != nil {
+		cost := 0
+		s.ScanUpdatedBetween(c.After, c.Before, func(string) bool {
+			cost++
+			return cost < cheapest
+		})
+
+		if cost < cheapest {
+			cheapest = cost
+			qs = &useIndexFirst{s, &useTimeRangeFirst{conds, true}}
+			plan.Strategy = StrategyUseTimeRangeFirst
+		}
+	}
+
+	// the content type index can only be used once every document has been
+	// added to it
+	if conds.IsContentTypeCondition != nil && !s.HasUntypedDocuments() {
+		cost := 0
+		for url := range conds.IsContentTypeCondition.TypeURLs {
+			cost += s.CountContentTypeDocuments(url)
+		}
+
+		if cost < cheapest {
+			cheapest = cost
+			qs = &useIndexFirst{s, &useContentTypeFirst{conds}}
+			plan.Strategy = StrategyUseContentTypeFirst
+		}
+	}
+
+	// only fall back to scanning every record if it's strictly cheaper than
+	// using an index, as scanning requires every record to be unmarshaled
+	if n := s.CountDocuments(); qs == nil || n < cheapest {
+		cheapest = n
+		qs = &scanRecords{s, f}
+		plan.Strategy = StrategyScanRecords
+		plan.IsFullScan = true
+	}
+
+	plan.Cost = cheapest
+
+	return qs, plan
 }
 
 // conditions is a filter.Visitor that extracts the individual constraint types
 // from a filter.
 //
 // It relies on the fact that filter.Optimize() currently ensures there will be
-// at most one of each condition type, but this is not guaranteed going forward.
+// at most one of each condition type, or in the case of header conditions, at
+// most one per header, but this is not guaranteed going forward.
 type conditions struct {
-	IsOneOfCondition        *filter.IsOneOf
-	HasUniqueKeyInCondition *filter.HasUniqueKeyIn
-	HasKeysCondition        *filter.HasKeys
+	IsOneOfCondition           *filter.IsOneOf
+	HasUniqueKeyInCondition    *filter.HasUniqueKeyIn
+	HasKeysCondition           *filter.HasKeys
+	HasKeyWithPrefixConditions []*filter.HasKeyWithPrefix
+	MatchesTextCondition       *filter.MatchesText
+	HasHeaderConditions        []*filter.HasHeader
+	HeaderInConditions         []*filter.HeaderIn
+	CreatedBetweenCondition    *filter.CreatedBetween
+	UpdatedBetweenCondition    *filter.UpdatedBetween
+	IsContentTypeCondition     *filter.IsContentType
 }
 
 func (x *conditions) IsOneOf(c *filter.IsOneOf) (bool, error) {
@@ -172,65 +489,302 @@ func (x *conditions) HasKeys(c *filter.HasKeys) (bool, error) {
 	return true, nil
 }
 
-// ExtractIsOneOf extracts the 'IsOneOf', clearing it from x such that future
-// calls to x.AreSatisfiedBy() do not check this condition.
-func (x *conditions) ExtractIsOneOf() *filter.IsOneOf {
+func (x *conditions) HasKeyWithPrefix(c *filter.HasKeyWithPrefix) (bool, error) {
+	x.HasKeyWithPrefixConditions = append(x.HasKeyWithPrefixConditions, c)
+	return true, nil
+}
+
+func (x *conditions) MatchesText(c *filter.MatchesText) (bool, error) {
+	if x.MatchesTextCondition != nil {
+		return false, errors.New(
+			"conditions are expected to be flattened by filter.Optimize()",
+		)
+	}
+
+	x.MatchesTextCondition = c
+	return true, nil
+}
+
+func (x *conditions) HasHeader(c *filter.HasHeader) (bool, error) {
+	x.HasHeaderConditions = append(x.HasHeaderConditions, c)
+	return true, nil
+}
+
+func (x *conditions) HeaderEquals(c *filter.HeaderEquals) (bool, error) {
+	return x.HeaderIn(&filter.HeaderIn{
+		Name:   c.Name,
+		Values: filter.NewSet(c.Value),
+	})
+}
+
+func (x *conditions) HeaderIn(c *filter.HeaderIn) (bool, error) {
+	x.HeaderInConditions = append(x.HeaderInConditions, c)
+	return true, nil
+}
+
+func (x *conditions) CreatedBetween(c *filter.CreatedBetween) (bool, error) {
+	if x.CreatedBetweenCondition != nil {
+		return false, errors.New(
+			"conditions are expected to be flattened by filter.Optimize()",
+		)
+	}
+
+	x.CreatedBetweenCondition = c
+	return true, nil
+}
+
+func (x *conditions) UpdatedBetween(c *filter.UpdatedBetween) (bool, error) {
+	if x.UpdatedBetweenCondition != nil {
+		return false, errors.New(
+			"conditions are expected to be flattened by filter.Optimize()",
+		)
+	}
+
+	x.UpdatedBetweenCondition = c
+	return true, nil
+}
+
+func (x *conditions) IsContentType(c *filter.IsContentType) (bool, error) {
+	if x.IsContentTypeCondition != nil {
+		return false, errors.New(
+			"conditions are expected to be flattened by filter.Optimize()",
+		)
+	}
+
+	x.IsContentTypeCondition = c
+	return true, nil
+}
+
+// headers returns the names of the headers that are subject to a condition,
+// mapped to the values permitted by that condition. The set of values is nil
+// for a 'HasHeader' condition, as any value is permitted.
+func (x *conditions) headers() map[string]filter.Set {
+	headers := map[string]filter.Set{}
+
+	for _, c := range x.HasHeaderConditions {
+		headers[c.Name] = nil
+	}
+
+	for _, c := range x.HeaderInConditions {
+		headers[c.Name] = c.Values
+	}
+
+	return headers
+}
+
+// clone returns a shallow copy of x, such that conditions can be removed from
+// the copy without affecting x.
+func (x *conditions) clone() *conditions {
+	r := *x
+
+	r.HasKeyWithPrefixConditions = append([]*filter.HasKeyWithPrefix(nil), x.HasKeyWithPrefixConditions...)
+	r.HasHeaderConditions = append([]*filter.HasHeader(nil), x.HasHeaderConditions...)
+	r.HeaderInConditions = append([]*filter.HeaderIn(nil), x.HeaderInConditions...)
+
+	return &r
+}
+
+// ExtractIsOneOf returns the 'IsOneOf' condition, along with a copy of x
+// that does not check this condition.
+func (x *conditions) ExtractIsOneOf() (*filter.IsOneOf, *conditions) {
 	if x.IsOneOfCondition == nil {
 		panic("x.IsOneOfCondition is nil")
 	}
 
-	c := x.IsOneOfCondition
-	x.IsOneOfCondition = nil
+	r := x.clone()
+	r.IsOneOfCondition = nil
 
-	return c
+	return x.IsOneOfCondition, r
 }
 
-// ExtractHasUniqueKeyIn extracts the 'HasUniqueKeyIn', clearing it from x such
-// that future calls to x.AreSatisfiedBy() do not check this condition.
-func (x *conditions) ExtractHasUniqueKeyIn() *filter.HasUniqueKeyIn {
+// ExtractHasUniqueKeyIn returns the 'HasUniqueKeyIn' condition, along with a copy of x
+// that does not check this condition.
+func (x *conditions) ExtractHasUniqueKeyIn() (*filter.HasUniqueKeyIn, *conditions) {
 	if x.HasUniqueKeyInCondition == nil {
 		panic("x.HasUniqueKeyInCondition is nil")
 	}
 
-	c := x.HasUniqueKeyInCondition
-	x.HasUniqueKeyInCondition = nil
+	r := x.clone()
+	r.HasUniqueKeyInCondition = nil
 
-	return c
+	return x.HasUniqueKeyInCondition, r
 }
 
-// ExtractHasKeys extracts the 'HasKeys', clearing it from x such that future
-// calls to x.AreSatisfiedBy() do not check this condition.
-func (x *conditions) ExtractHasKeys() *filter.HasKeys {
+// ExtractHasKeys returns the 'HasKeys' condition, along with a copy of x
+// that does not check this condition.
+func (x *conditions) ExtractHasKeys() (*filter.HasKeys, *conditions) {
 	if x.HasKeysCondition == nil {
 		panic("x.HasKeysCondition is nil")
 	}
 
-	c := x.HasKeysCondition
-	x.HasKeysCondition = nil
+	r := x.clone()
+	r.HasKeysCondition = nil
+
+	return x.HasKeysCondition, r
+}
+
+// ExtractHasKeyWithPrefix returns the 'HasKeyWithPrefix' condition with the
+// given prefix, along with a copy of x that does not check this condition.
+func (x *conditions) ExtractHasKeyWithPrefix(prefix string) (*filter.HasKeyWithPrefix, *conditions) {
+	for i, c := range x.HasKeyWithPrefixConditions {
+		if c.Prefix == prefix {
+			r := x.clone()
+			r.HasKeyWithPrefixConditions = append(
+				r.HasKeyWithPrefixConditions[:i:i],
+				r.HasKeyWithPrefixConditions[i+1:]...,
+			)
+
+			return c, r
+		}
+	}
+
+	panic("x has no condition for the '" + prefix + "' key prefix")
+}
+
+// ExtractMatchesText returns the 'MatchesText' condition, along with a copy of x
+// that does not check this condition.
+func (x *conditions) ExtractMatchesText() (*filter.MatchesText, *conditions) {
+	if x.MatchesTextCondition == nil {
+		panic("x.MatchesTextCondition is nil")
+	}
+
+	r := x.clone()
+	r.MatchesTextCondition = nil
+
+	return x.MatchesTextCondition, r
+}
+
+// ExtractHeader returns the values permitted by the 'HasHeader' or 'HeaderIn'
+// condition for the header with the given name, along with a copy of x that
+// does not check this condition.
+//
+// The values are nil if any value is permitted.
+func (x *conditions) ExtractHeader(name string) (filter.Set, *conditions) {
+	for i, c := range x.HeaderInConditions {
+		if c.Name == name {
+			r := x.clone()
+			r.HeaderInConditions = append(
+				r.HeaderInConditions[:i:i],
+				r.HeaderInConditions[i+1:]...,
+			)
+
+			return c.Values, r
+		}
+	}
+
+	for i, c := range x.HasHeaderConditions {
+		if c.Name == name {
+			r := x.clone()
+			r.HasHeaderConditions = append(
+				r.HasHeaderConditions[:i:i],
+				r.HasHeaderConditions[i+1:]...,
+			)
+
+			return nil, r
+		}
+	}
+
+	panic("x has no condition for the '" + name + "' header")
+}
+
+// ExtractCreatedBetween returns the 'CreatedBetween' condition, along with a copy of x
+// that does not check this condition.
+func (x *conditions) ExtractCreatedBetween() (*filter.CreatedBetween, *conditions) {
+	if x.CreatedBetweenCondition == nil {
+		panic("x.CreatedBetweenCondition is nil")
+	}
+
+	r := x.clone()
+	r.CreatedBetweenCondition = nil
+
+	return x.CreatedBetweenCondition, r
+}
+
+// ExtractUpdatedBetween returns the 'UpdatedBetween' condition, along with a copy of x
+// that does not check this condition.
+func (x *conditions) ExtractUpdatedBetween() (*filter.UpdatedBetween, *conditions) {
+	if x.UpdatedBetweenCondition == nil {
+		panic("x.UpdatedBetweenCondition is nil")
+	}
+
+	r := x.clone()
+	r.UpdatedBetweenCondition = nil
 
-	return c
+	return x.UpdatedBetweenCondition, r
+}
+
+// ExtractIsContentType returns the 'IsContentType' condition, along with a copy of x
+// that does not check this condition.
+func (x *conditions) ExtractIsContentType() (*filter.IsContentType, *conditions) {
+	if x.IsContentTypeCondition == nil {
+		panic("x.IsContentTypeCondition is nil")
+	}
+
+	r := x.clone()
+	r.IsContentTypeCondition = nil
+
+	return x.IsContentTypeCondition, r
 }
 
 // AreSatisfiedBy verifies that any of the remaining non-nil conditions on
 // x are met by the given record.
 func (x *conditions) AreSatisfiedBy(
+	s *database.Store,
 	id string,
 	rec *database.Record,
-) bool {
-	if x.IsOneOfCondition != nil &&
-		!isFilterSatisfiedByRecord(x.IsOneOfCondition, id, rec) {
-		return false
+) (bool, error) {
+	var conds []filter.Condition
+
+	if x.IsOneOfCondition != nil {
+		conds = append(conds, x.IsOneOfCondition)
+	}
+
+	if x.HasUniqueKeyInCondition != nil {
+		conds = append(conds, x.HasUniqueKeyInCondition)
+	}
+
+	if x.HasKeysCondition != nil {
+		conds = append(conds, x.HasKeysCondition)
+	}
+
+	if x.MatchesTextCondition != nil {
+		conds = append(conds, x.MatchesTextCondition)
+	}
+
+	if x.CreatedBetweenCondition != nil {
+		conds = append(conds, x.CreatedBetweenCondition)
+	}
+
+	if x.UpdatedBetweenCondition != nil {
+		conds = append(conds, x.UpdatedBetweenCondition)
+	}
+
+	if x.IsContentTypeCondition != nil {
+		conds = append(conds, x.IsContentTypeCondition)
 	}
 
-	if x.HasUniqueKeyInCondition != nil &&
-		!isFilterSatisfiedByRecord(x.HasUniqueKeyInCondition, id, rec) {
-		return false
+	for _, c := range x.HasKeyWithPrefixConditions {
+		conds = append(conds, c)
 	}
 
-	if x.HasKeysCondition != nil &&
-		!isFilterSatisfiedByRecord(x.HasKeysCondition, id, rec) {
-		return false
+	for _, c := range x.HasHeaderConditions {
+		conds = append(conds, c)
 	}
 
-	return true
+	for _, c := range x.HeaderInConditions {
+		conds = append(conds, c)
+	}
+
+	// share a single matcher between all of the conditions, so that the
+	// document's content is loaded at most once
+	m := &recordMatcher{store: s, id: id, rec: rec}
+
+	for _, c := range conds {
+		ok, err := c.Accept(m)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
 }
@@ -0,0 +1,29 @@
+package protavobolt
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+)
+
+// RebuildTextIndex re-indexes the searchable text of every document, in all
+// namespaces.
+//
+// The full-text index is maintained automatically as documents are saved and
+// deleted. It only needs to be rebuilt after d.TextIndex changes, or to index
+// documents that were saved before full-text search was supported.
+//
+// Note that the index itself is not encrypted, even if d.KeyProvider is set.
+//
+// It is performed within a single transaction. It returns the number of
+// documents that were indexed.
+func (d *ExclusiveDriver) RebuildTextIndex(ctx context.Context) (int, error) {
+	return d.reindex(
+		ctx,
+		func(s *database.Store, _ *database.Record, doc *document.Document) error {
+			return s.UpdateText(doc.ID, s.TextTerms(doc))
+		},
+		nil,
+	)
+}
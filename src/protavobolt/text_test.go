@@ -0,0 +1,70 @@
+package protavobolt_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	. "github.com/jmalloc/protavo/src/protavobolt"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+var _ = g.Describe("ExclusiveDriver.RebuildTextIndex", func() {
+	var (
+		ctx = context.Background()
+		dir string
+		db  *protavo.DB
+		d   *ExclusiveDriver
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "protavobolt-")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		bdb, err := bolt.Open(path.Join(dir, "bolt.db"), 0600, nil)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		d = &ExclusiveDriver{DB: bdb}
+		db, err = protavo.NewDB(d)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.AfterEach(func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	g.It("indexes fields that were added to the index after the documents were saved", func() {
+		err := db.Namespace("ns").Save(
+			ctx,
+			&document.Document{
+				ID:      "doc-1",
+				Headers: document.Headers{"rebuild-test": "needle"},
+				Content: document.StringContent("haystack"),
+			},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		docs, err := db.Namespace("ns").LoadManyWhere(ctx, protavo.MatchesText("needle"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(docs).To(m.BeEmpty())
+
+		d.TextIndex = (&document.TextIndex{}).
+			AddFields(&document.StringContentType{}, "value").
+			AddHeaders("rebuild-test")
+
+		n, err := d.RebuildTextIndex(ctx)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(n).To(m.Equal(1))
+
+		docs, err = db.Namespace("ns").LoadManyWhere(ctx, protavo.MatchesText("needle haystack"))
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+		m.Expect(docs).To(m.HaveLen(1))
+	})
+})
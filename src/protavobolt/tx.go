@@ -4,19 +4,22 @@ import (
 	"context"
 
 	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
 	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavo/filter"
 	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
 )
 
 // readTx is a BoltDB implementation of protavo.ReadTx.
 type readTx struct {
 	ns string
-	tx *bolt.Tx
+	tx *database.Tx
 }
 
-func (tx *readTx) Fetch(_ context.Context, op *driver.Fetch) {
+func (tx *readTx) Fetch(ctx context.Context, op *driver.Fetch) {
 	op.MarkExecuted(
 		executeFetch(
+			ctx,
 			tx.tx,
 			tx.ns,
 			op.Filter,
@@ -25,8 +28,74 @@ func (tx *readTx) Fetch(_ context.Context, op *driver.Fetch) {
 	)
 }
 
+func (tx *readTx) Cursor(ctx context.Context, f *filter.Filter) (driver.Cursor, error) {
+	return executeCursor(ctx, tx.tx, tx.ns, f)
+}
+
+func (tx *readTx) Explain(_ context.Context, op *driver.Explain) {
+	plan, err := executeExplain(
+		tx.tx,
+		tx.ns,
+		op.Filter,
+	)
+
+	op.Plan = plan
+	op.MarkExecuted(err)
+}
+
+func (tx *readTx) GetAttachment(ctx context.Context, op *driver.GetAttachment) {
+	found, err := executeGetAttachment(
+		ctx,
+		tx.tx,
+		tx.ns,
+		op.DocumentID,
+		op.Name,
+		op.Content,
+	)
+
+	op.Found = found
+	op.MarkExecuted(err)
+}
+
+func (tx *readTx) ListAttachments(ctx context.Context, op *driver.ListAttachments) {
+	attachments, err := executeListAttachments(
+		ctx,
+		tx.tx,
+		tx.ns,
+		op.DocumentID,
+	)
+
+	op.Attachments = attachments
+	op.MarkExecuted(err)
+}
+
+func (tx *readTx) ListKeys(ctx context.Context, op *driver.ListKeys) {
+	keys, err := executeListKeys(
+		ctx,
+		tx.tx,
+		tx.ns,
+		op.Prefix,
+		op.Type,
+	)
+
+	op.Keys = keys
+	op.MarkExecuted(err)
+}
+
+func (tx *readTx) ChangesSince(ctx context.Context, op *driver.ChangesSince) {
+	op.MarkExecuted(
+		executeChangesSince(
+			ctx,
+			tx.tx,
+			tx.ns,
+			op.Since,
+			op.Each,
+		),
+	)
+}
+
 func (tx *readTx) Close() error {
-	return tx.tx.Rollback()
+	return closeErr(tx.tx.Bolt.Rollback())
 }
 
 // readTx is a BoltDB implementation of protavo.WriteTx.
@@ -34,20 +103,23 @@ type writeTx struct {
 	readTx
 }
 
-func (tx *writeTx) Save(_ context.Context, op *driver.Save) {
+func (tx *writeTx) Save(ctx context.Context, op *driver.Save) {
 	op.MarkExecuted(
 		executeSave(
+			ctx,
 			tx.tx,
 			tx.ns,
 			op.Document,
+			op.DerivedKeys,
 			op.Force,
 		),
 	)
 }
 
-func (tx *writeTx) Delete(_ context.Context, op *driver.Delete) {
+func (tx *writeTx) Delete(ctx context.Context, op *driver.Delete) {
 	op.MarkExecuted(
 		executeDelete(
+			ctx,
 			tx.tx,
 			tx.ns,
 			op.Document,
@@ -55,9 +127,10 @@ func (tx *writeTx) Delete(_ context.Context, op *driver.Delete) {
 	)
 }
 
-func (tx *writeTx) DeleteWhere(_ context.Context, op *driver.DeleteWhere) {
+func (tx *writeTx) DeleteWhere(ctx context.Context, op *driver.DeleteWhere) {
 	op.MarkExecuted(
 		executeDeleteWhere(
+			ctx,
 			tx.tx,
 			tx.ns,
 			op.Filter,
@@ -66,7 +139,12 @@ func (tx *writeTx) DeleteWhere(_ context.Context, op *driver.DeleteWhere) {
 	)
 }
 
-func (tx *writeTx) DeleteNamespace(_ context.Context, op *driver.DeleteNamespace) {
+func (tx *writeTx) DeleteNamespace(ctx context.Context, op *driver.DeleteNamespace) {
+	if err := ctx.Err(); err != nil {
+		op.MarkExecuted(err)
+		return
+	}
+
 	op.MarkExecuted(
 		database.DeleteStore(
 			tx.tx,
@@ -75,6 +153,104 @@ func (tx *writeTx) DeleteNamespace(_ context.Context, op *driver.DeleteNamespace
 	)
 }
 
+func (tx *writeTx) Restore(ctx context.Context, op *driver.Restore) {
+	found, err := executeRestore(
+		ctx,
+		tx.tx,
+		tx.ns,
+		op.DocumentID,
+	)
+
+	op.Found = found
+	op.MarkExecuted(err)
+}
+
+func (tx *writeTx) PurgeDeleted(ctx context.Context, op *driver.PurgeDeleted) {
+	op.MarkExecuted(
+		executePurgeDeleted(
+			ctx,
+			tx.tx,
+			tx.ns,
+			op.Before,
+			op.Each,
+		),
+	)
+}
+
+func (tx *writeTx) PutAttachment(ctx context.Context, op *driver.PutAttachment) {
+	op.MarkExecuted(
+		executePutAttachment(
+			ctx,
+			tx.tx,
+			tx.ns,
+			op.DocumentID,
+			op.Name,
+			op.Content,
+		),
+	)
+}
+
+func (tx *writeTx) DeleteAttachment(ctx context.Context, op *driver.DeleteAttachment) {
+	op.MarkExecuted(
+		executeDeleteAttachment(
+			ctx,
+			tx.tx,
+			tx.ns,
+			op.DocumentID,
+			op.Name,
+		),
+	)
+}
+
+func (tx *writeTx) ApplyChange(ctx context.Context, op *driver.ApplyChange) {
+	op.MarkExecuted(
+		executeApplyChange(
+			ctx,
+			tx.tx,
+			tx.ns,
+			op.Change,
+		),
+	)
+}
+
+func (tx *writeTx) Savepoint(ctx context.Context) (driver.Savepoint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if tx.tx.IsClosed() {
+		return 0, protavo.ErrTxClosed
+	}
+
+	return driver.Savepoint(tx.tx.Savepoint()), nil
+}
+
+func (tx *writeTx) RollbackTo(_ context.Context, sp driver.Savepoint) error {
+	if tx.tx.IsClosed() {
+		return protavo.ErrTxClosed
+	}
+
+	return tx.tx.RollbackTo(int(sp))
+}
+
+func (tx *writeTx) Release(_ context.Context, sp driver.Savepoint) error {
+	if tx.tx.IsClosed() {
+		return protavo.ErrTxClosed
+	}
+
+	return tx.tx.Release(int(sp))
+}
+
 func (tx *writeTx) Commit() error {
-	return tx.tx.Commit()
+	return closeErr(tx.tx.Bolt.Commit())
+}
+
+// closeErr returns protavo.ErrTxClosed in place of BoltDB's equivalent error,
+// such that it is consistent across drivers.
+func closeErr(err error) error {
+	if err == bolt.ErrTxClosed {
+		return protavo.ErrTxClosed
+	}
+
+	return err
 }
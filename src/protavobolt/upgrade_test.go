@@ -0,0 +1,338 @@
+package protavobolt_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	. "github.com/jmalloc/protavo/src/protavobolt"
+	"github.com/jmalloc/protavo/src/protavobolt/internal/database"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+)
+
+var _ = g.Describe("on-disk format upgrades", func() {
+	ctx := context.Background()
+	var (
+		dir  string
+		file string
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "protavobolt-")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		file = path.Join(dir, "bolt.db")
+	})
+
+	g.AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	g.When("the database is new", func() {
+		g.It("can be reopened after data has been written", func() {
+			db, err := OpenExclusive(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			err = db.Save(ctx, &document.Document{
+				ID:      "doc-1",
+				Keys:    document.SharedKeys("shar-a"),
+				Content: document.StringContent("content"),
+			})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			db.Close()
+
+			db, err = OpenExclusive(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			docs, err := db.LoadManyWhere(ctx, protavo.HasKeys("shar-a"))
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(docs).To(m.HaveLen(1))
+		})
+	})
+
+	g.When("the database uses the version 1 format", func() {
+		g.BeforeEach(func() {
+			b, err := bolt.Open(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer b.Close()
+
+			err = b.Update(func(tx *bolt.Tx) error {
+				if err := writeV1Document(tx, "", "doc-1", "shar-a"); err != nil {
+					return err
+				}
+
+				return writeV1Document(tx, "sub.ns", "doc-2", "shar-b")
+			})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+		})
+
+		g.It("can load documents", func() {
+			db, err := OpenExclusive(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			doc, ok, err := db.Load(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+			m.Expect(document.GetStringContent(doc.Content)).To(m.Equal("content"))
+		})
+
+		g.It("can load documents in sub-namespaces", func() {
+			db, err := OpenExclusive(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			docs, err := db.Namespace("sub.ns").LoadManyWhere(ctx, protavo.HasKeys("shar-b"))
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(docs).To(m.HaveLen(1))
+			m.Expect(docs[0].ID).To(m.Equal("doc-2"))
+
+			_, ok, err := db.Namespace("sub").Load(ctx, "doc-2")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeFalse())
+		})
+
+		g.It("abandons the upgrade if the context is canceled", func() {
+			db, err := OpenExclusive(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+
+			_, _, err = db.Load(canceled, "doc-1")
+			m.Expect(err).To(m.Equal(context.Canceled))
+
+			// the upgrade is attempted again by the next caller
+			_, ok, err := db.Load(ctx, "doc-1")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+		})
+
+		g.It("can find documents by key", func() {
+			db, err := OpenExclusive(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			err = db.Save(ctx, &document.Document{
+				ID:      "doc-2",
+				Keys:    document.SharedKeys("shar-a"),
+				Content: document.StringContent("content"),
+			})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			docs, err := db.LoadManyWhere(ctx, protavo.HasKeys("shar-a"))
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			ids := make([]string, len(docs))
+			for i, doc := range docs {
+				ids[i] = doc.ID
+			}
+
+			m.Expect(ids).To(m.ConsistOf("doc-1", "doc-2"))
+		})
+
+		g.It("populates the document and key counts", func() {
+			db, err := OpenExclusive(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			op := protavo.Explain()
+			op.Filter = nil
+
+			err = db.Read(ctx, op)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(op.Plan.Cost).To(m.Equal(1))
+
+			plan, err := db.Explain(ctx, protavo.HasKeys("shar-a"))
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(plan.Cost).To(m.Equal(1))
+		})
+
+		g.It("populates the content size counters", func() {
+			bdb, err := bolt.Open(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			d := &ExclusiveDriver{DB: bdb}
+			defer d.Close()
+
+			s, err := d.Stats(ctx, "")
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(s.Documents).To(m.Equal(1))
+			m.Expect(s.ContentBytes).To(m.BeNumerically(">", 0))
+			m.Expect(s.StoredBytes).To(m.Equal(s.ContentBytes))
+		})
+
+		g.It("populates the time-ordered indexes", func() {
+			db, err := OpenExclusive(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			plan, err := db.Explain(
+				ctx,
+				protavo.CreatedBetween(time.Time{}, time.Now().Add(time.Hour)),
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(plan.Strategy).To(m.Equal(StrategyUseTimeRangeFirst))
+			m.Expect(plan.Cost).To(m.Equal(1))
+
+			docs, err := db.LoadManyWhere(
+				ctx,
+				protavo.UpdatedBetween(time.Time{}, time.Now().Add(time.Hour)),
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(docs).To(m.HaveLen(1))
+		})
+
+		g.It("populates the content type index", func() {
+			db, err := OpenExclusive(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			plan, err := db.Explain(
+				ctx,
+				protavo.IsContentType(&document.StringContentType{}),
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(plan.Strategy).To(m.Equal(StrategyUseContentTypeFirst))
+			m.Expect(plan.Cost).To(m.Equal(1))
+		})
+
+		g.It("assigns sequence numbers to existing documents", func() {
+			db, err := OpenExclusive(file, 0600, nil)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			var changes []*driver.Change
+			err = db.ChangesSince(
+				ctx,
+				0,
+				func(c *driver.Change) (bool, error) {
+					changes = append(changes, c)
+					return true, nil
+				},
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(changes).To(m.HaveLen(1))
+			m.Expect(changes[0].Sequence).To(m.BeEquivalentTo(1))
+			m.Expect(changes[0].DocumentID).To(m.Equal("doc-1"))
+
+			err = db.Save(ctx, changes[0].Document)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			changes = nil
+			err = db.ChangesSince(
+				ctx,
+				1,
+				func(c *driver.Change) (bool, error) {
+					changes = append(changes, c)
+					return true, nil
+				},
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(changes).To(m.HaveLen(1))
+			m.Expect(changes[0].Sequence).To(m.BeEquivalentTo(2))
+		})
+
+		g.It("returns an error if the database is opened in read-only mode", func() {
+			db, err := OpenExclusive(file, 0600, &bolt.Options{ReadOnly: true})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer db.Close()
+
+			_, _, err = db.Load(ctx, "doc-1")
+			m.Expect(err).Should(m.HaveOccurred())
+		})
+	})
+})
+
+// writeV1Document writes a document in the ns namespace to tx using the
+// version 1 on-disk format, which places the store's buckets directly within
+// the namespace's bucket, and stores all of the documents in a key as a single
+// entry.
+func writeV1Document(tx *bolt.Tx, ns string, id string, keys ...string) error {
+	root, err := tx.CreateBucketIfNotExists([]byte("protavo"))
+	if err != nil {
+		return err
+	}
+
+	if ns != "" {
+		for _, p := range strings.Split(ns, ".") {
+			root, err = root.CreateBucketIfNotExists([]byte(p))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	records, err := root.CreateBucketIfNotExists([]byte("records"))
+	if err != nil {
+		return err
+	}
+
+	content, err := root.CreateBucketIfNotExists([]byte("content"))
+	if err != nil {
+		return err
+	}
+
+	keyIndex, err := root.CreateBucketIfNotExists([]byte("keys"))
+	if err != nil {
+		return err
+	}
+
+	now := ptypes.TimestampNow()
+	rec := &database.Record{
+		Revision:  1,
+		Keys:      map[string]uint32{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	for _, k := range keys {
+		rec.Keys[k] = database.SharedKeyType
+
+		buf, err := proto.Marshal(&database.Key{
+			Type:      database.SharedKeyType,
+			Documents: map[string]bool{id: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := keyIndex.Put([]byte(k), buf); err != nil {
+			return err
+		}
+	}
+
+	buf, err := proto.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	if err := records.Put([]byte(id), buf); err != nil {
+		return err
+	}
+
+	any, err := ptypes.MarshalAny(document.StringContent("content"))
+	if err != nil {
+		return err
+	}
+
+	buf, err = proto.Marshal(&database.Content{Content: any})
+	if err != nil {
+		return err
+	}
+
+	return content.Put([]byte(id), buf)
+}
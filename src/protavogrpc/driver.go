@@ -0,0 +1,243 @@
+package protavogrpc
+
+import (
+	"context"
+
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavogrpc/internal/rpc"
+	"google.golang.org/grpc"
+)
+
+// Driver is an implementation of protavo.Driver that performs operations on a
+// remote store that is exposed by a gRPC server using Register().
+type Driver struct {
+	client rpc.ProtavoClient
+	conn   *grpc.ClientConn
+	owned  bool
+}
+
+// NewDriver returns a driver that uses the given gRPC connection.
+//
+// The connection is not closed when the driver is closed.
+func NewDriver(conn *grpc.ClientConn) *Driver {
+	return &Driver{
+		client: rpc.NewProtavoClient(conn),
+		conn:   conn,
+	}
+}
+
+// Dial connects to the gRPC server at the given target and returns a database
+// that uses it.
+//
+// The connection is closed when the database is closed.
+func Dial(target string, opts ...grpc.DialOption) (*protavo.DB, error) {
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	d := NewDriver(conn)
+	d.owned = true
+
+	return protavo.NewDB(d)
+}
+
+// BeginRead starts a new read-only transaction.
+func (d *Driver) BeginRead(ctx context.Context, ns string) (driver.ReadTx, error) {
+	tx, err := d.begin(ctx, ns, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &readTx{tx}, nil
+}
+
+// BeginWrite starts a new read/write transaction.
+func (d *Driver) BeginWrite(ctx context.Context, ns string) (driver.WriteTx, error) {
+	tx, err := d.begin(ctx, ns, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &writeTx{readTx{tx}}, nil
+}
+
+// Close closes the driver.
+//
+// If the driver was created by Dial(), the gRPC connection is also closed.
+func (d *Driver) Close() error {
+	if d.owned {
+		return d.conn.Close()
+	}
+
+	return nil
+}
+
+// begin starts a new transaction on the server.
+func (d *Driver) begin(ctx context.Context, ns string, write bool) (*stream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// the stream outlives ctx, which is only used to start the transaction
+	sctx, cancel := context.WithCancel(context.Background())
+
+	s, err := d.client.Transaction(sctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	tx := &stream{
+		Protavo_TransactionClient: s,
+		cancel:                    cancel,
+	}
+
+	if _, err := tx.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_Begin{
+				Begin: &rpc.BeginRequest{
+					Namespace: ns,
+					Write:     write,
+				},
+			},
+		},
+	); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// stream is a transaction stream between the client and the server.
+type stream struct {
+	rpc.Protavo_TransactionClient
+
+	cancel context.CancelFunc
+	closed bool
+}
+
+// call sends a request to the server and returns its response.
+//
+// If the response describes an error, that error is returned along with the
+// response.
+func (s *stream) call(ctx context.Context, req *rpc.Request) (*rpc.Response, error) {
+	var res *rpc.Response
+
+	if err := s.do(ctx, func() error {
+		if err := s.Send(req); err != nil {
+			return err
+		}
+
+		var err error
+		res, err = s.Recv()
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, unmarshalError(res.Error)
+}
+
+// send sends a request to the server that is not answered by a response.
+func (s *stream) send(ctx context.Context, req *rpc.Request) error {
+	return s.do(ctx, func() error {
+		return s.Send(req)
+	})
+}
+
+// each sends a request to the server and calls fn for each response in the
+// sequence of responses that answers it.
+//
+// If fn returns false or a non-nil error, the remaining responses are
+// canceled.
+func (s *stream) each(
+	ctx context.Context,
+	req *rpc.Request,
+	fn func(*rpc.Response) (bool, error),
+) error {
+	res, err := s.call(ctx, req)
+
+	for {
+		if err != nil {
+			return err
+		}
+
+		ok, ferr := fn(res)
+		if !res.More {
+			return ferr
+		}
+
+		if !ok || ferr != nil {
+			// the server sends a final response once the results are
+			// canceled, which is discarded if fn failed
+			_, err := s.call(ctx, continueRequest(true))
+			if ferr != nil {
+				return ferr
+			}
+
+			return err
+		}
+
+		res, err = s.call(ctx, continueRequest(false))
+	}
+}
+
+// do calls fn, which communicates with the server.
+//
+// If ctx is canceled or its deadline is exceeded, the stream is closed, which
+// rolls back the transaction. The stream can not be used after a request has
+// been abandoned, as the server's response to that request would be mistaken
+// for the response to the next. Likewise, the server may be part way through
+// a sequence of responses or attachment chunks, even if fn has not yet been
+// called.
+func (s *stream) do(ctx context.Context, fn func() error) error {
+	if s.closed {
+		return protavo.ErrTxClosed
+	}
+
+	if err := ctx.Err(); err != nil {
+		_ = s.close()
+		return err
+	}
+
+	result := make(chan error, 1)
+
+	go func() {
+		result <- fn()
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		_ = s.close()
+
+		// wait for fn to observe the cancelation of the stream, so that it
+		// does not outlive the call
+		<-result
+
+		return ctx.Err()
+	}
+}
+
+// close ends the stream, rolling back the transaction if it has not been
+// committed.
+func (s *stream) close() error {
+	s.closed = true
+	s.cancel()
+	return nil
+}
+
+// continueRequest returns a request that answers a response that has its
+// "more" field set.
+func continueRequest(cancel bool) *rpc.Request {
+	return &rpc.Request{
+		Request: &rpc.Request_Continue{
+			Continue: &rpc.ContinueRequest{Cancel: cancel},
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package protavogrpc_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/driver/drivertest"
+	"github.com/jmalloc/protavo/src/protavobolt"
+	. "github.com/jmalloc/protavo/src/protavogrpc"
+	"google.golang.org/grpc"
+)
+
+func init() {
+	var (
+		dir    string
+		local  *protavobolt.ExclusiveDriver
+		server *grpc.Server
+	)
+
+	drivertest.Describe(
+		"protavogrpc.Driver",
+		func() (*protavo.DB, error) {
+			var err error
+			dir, err = ioutil.TempDir("", "protavogrpc-")
+			if err != nil {
+				return nil, err
+			}
+
+			bdb, err := bolt.Open(path.Join(dir, "bolt.db"), 0600, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			local = &protavobolt.ExclusiveDriver{
+				DB:        bdb,
+				TextIndex: drivertest.TextIndex,
+			}
+
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				return nil, err
+			}
+
+			server = grpc.NewServer()
+			Register(server, local)
+			go server.Serve(lis)
+
+			return Dial(lis.Addr().String(), grpc.WithInsecure())
+		},
+		func() {
+			server.Stop()
+			_ = local.Close()
+			_ = os.RemoveAll(dir)
+		},
+	)
+}
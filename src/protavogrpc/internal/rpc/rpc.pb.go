@@ -0,0 +1,3671 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: src/protavogrpc/internal/rpc/rpc.proto
+
+package rpc
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import any "github.com/golang/protobuf/ptypes/any"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Request is a request to perform an operation within a transaction.
+type Request struct {
+	// Types that are valid to be assigned to Request:
+	//	*Request_Begin
+	//	*Request_Commit
+	//	*Request_Savepoint
+	//	*Request_RollbackTo
+	//	*Request_Release
+	//	*Request_Continue
+	//	*Request_AttachmentChunk
+	//	*Request_Fetch
+	//	*Request_Explain
+	//	*Request_GetAttachment
+	//	*Request_ListAttachments
+	//	*Request_ChangesSince
+	//	*Request_ListKeys
+	//	*Request_Save
+	//	*Request_Delete
+	//	*Request_DeleteWhere
+	//	*Request_DeleteNamespace
+	//	*Request_PutAttachment
+	//	*Request_DeleteAttachment
+	//	*Request_ApplyChange
+	//	*Request_Restore
+	//	*Request_PurgeDeleted
+	Request              isRequest_Request `protobuf_oneof:"request"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Request) Reset()         { *m = Request{} }
+func (m *Request) String() string { return proto.CompactTextString(m) }
+func (*Request) ProtoMessage()    {}
+func (*Request) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{0}
+}
+func (m *Request) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Request.Unmarshal(m, b)
+}
+func (m *Request) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Request.Marshal(b, m, deterministic)
+}
+func (dst *Request) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Request.Merge(dst, src)
+}
+func (m *Request) XXX_Size() int {
+	return xxx_messageInfo_Request.Size(m)
+}
+func (m *Request) XXX_DiscardUnknown() {
+	xxx_messageInfo_Request.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Request proto.InternalMessageInfo
+
+type isRequest_Request interface {
+	isRequest_Request()
+}
+
+type Request_Begin struct {
+	Begin *BeginRequest `protobuf:"bytes,1,opt,name=begin,proto3,oneof"`
+}
+
+type Request_Commit struct {
+	Commit *CommitRequest `protobuf:"bytes,2,opt,name=commit,proto3,oneof"`
+}
+
+type Request_Savepoint struct {
+	Savepoint *SavepointRequest `protobuf:"bytes,3,opt,name=savepoint,proto3,oneof"`
+}
+
+type Request_RollbackTo struct {
+	RollbackTo *RollbackToRequest `protobuf:"bytes,4,opt,name=rollback_to,json=rollbackTo,proto3,oneof"`
+}
+
+type Request_Release struct {
+	Release *ReleaseRequest `protobuf:"bytes,5,opt,name=release,proto3,oneof"`
+}
+
+type Request_Continue struct {
+	Continue *ContinueRequest `protobuf:"bytes,6,opt,name=continue,proto3,oneof"`
+}
+
+type Request_AttachmentChunk struct {
+	AttachmentChunk *AttachmentChunkRequest `protobuf:"bytes,7,opt,name=attachment_chunk,json=attachmentChunk,proto3,oneof"`
+}
+
+type Request_Fetch struct {
+	Fetch *FetchRequest `protobuf:"bytes,10,opt,name=fetch,proto3,oneof"`
+}
+
+type Request_Explain struct {
+	Explain *ExplainRequest `protobuf:"bytes,11,opt,name=explain,proto3,oneof"`
+}
+
+type Request_GetAttachment struct {
+	GetAttachment *GetAttachmentRequest `protobuf:"bytes,12,opt,name=get_attachment,json=getAttachment,proto3,oneof"`
+}
+
+type Request_ListAttachments struct {
+	ListAttachments *ListAttachmentsRequest `protobuf:"bytes,13,opt,name=list_attachments,json=listAttachments,proto3,oneof"`
+}
+
+type Request_ChangesSince struct {
+	ChangesSince *ChangesSinceRequest `protobuf:"bytes,14,opt,name=changes_since,json=changesSince,proto3,oneof"`
+}
+
+type Request_ListKeys struct {
+	ListKeys *ListKeysRequest `protobuf:"bytes,15,opt,name=list_keys,json=listKeys,proto3,oneof"`
+}
+
+type Request_Save struct {
+	Save *SaveRequest `protobuf:"bytes,20,opt,name=save,proto3,oneof"`
+}
+
+type Request_Delete struct {
+	Delete *DeleteRequest `protobuf:"bytes,21,opt,name=delete,proto3,oneof"`
+}
+
+type Request_DeleteWhere struct {
+	DeleteWhere *DeleteWhereRequest `protobuf:"bytes,22,opt,name=delete_where,json=deleteWhere,proto3,oneof"`
+}
+
+type Request_DeleteNamespace struct {
+	DeleteNamespace *DeleteNamespaceRequest `protobuf:"bytes,23,opt,name=delete_namespace,json=deleteNamespace,proto3,oneof"`
+}
+
+type Request_PutAttachment struct {
+	PutAttachment *PutAttachmentRequest `protobuf:"bytes,24,opt,name=put_attachment,json=putAttachment,proto3,oneof"`
+}
+
+type Request_DeleteAttachment struct {
+	DeleteAttachment *DeleteAttachmentRequest `protobuf:"bytes,25,opt,name=delete_attachment,json=deleteAttachment,proto3,oneof"`
+}
+
+type Request_ApplyChange struct {
+	ApplyChange *ApplyChangeRequest `protobuf:"bytes,26,opt,name=apply_change,json=applyChange,proto3,oneof"`
+}
+
+type Request_Restore struct {
+	Restore *RestoreRequest `protobuf:"bytes,27,opt,name=restore,proto3,oneof"`
+}
+
+type Request_PurgeDeleted struct {
+	PurgeDeleted *PurgeDeletedRequest `protobuf:"bytes,28,opt,name=purge_deleted,json=purgeDeleted,proto3,oneof"`
+}
+
+func (*Request_Begin) isRequest_Request() {}
+
+func (*Request_Commit) isRequest_Request() {}
+
+func (*Request_Savepoint) isRequest_Request() {}
+
+func (*Request_RollbackTo) isRequest_Request() {}
+
+func (*Request_Release) isRequest_Request() {}
+
+func (*Request_Continue) isRequest_Request() {}
+
+func (*Request_AttachmentChunk) isRequest_Request() {}
+
+func (*Request_Fetch) isRequest_Request() {}
+
+func (*Request_Explain) isRequest_Request() {}
+
+func (*Request_GetAttachment) isRequest_Request() {}
+
+func (*Request_ListAttachments) isRequest_Request() {}
+
+func (*Request_ChangesSince) isRequest_Request() {}
+
+func (*Request_ListKeys) isRequest_Request() {}
+
+func (*Request_Save) isRequest_Request() {}
+
+func (*Request_Delete) isRequest_Request() {}
+
+func (*Request_DeleteWhere) isRequest_Request() {}
+
+func (*Request_DeleteNamespace) isRequest_Request() {}
+
+func (*Request_PutAttachment) isRequest_Request() {}
+
+func (*Request_DeleteAttachment) isRequest_Request() {}
+
+func (*Request_ApplyChange) isRequest_Request() {}
+
+func (*Request_Restore) isRequest_Request() {}
+
+func (*Request_PurgeDeleted) isRequest_Request() {}
+
+func (m *Request) GetRequest() isRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *Request) GetBegin() *BeginRequest {
+	if x, ok := m.GetRequest().(*Request_Begin); ok {
+		return x.Begin
+	}
+	return nil
+}
+
+func (m *Request) GetCommit() *CommitRequest {
+	if x, ok := m.GetRequest().(*Request_Commit); ok {
+		return x.Commit
+	}
+	return nil
+}
+
+func (m *Request) GetSavepoint() *SavepointRequest {
+	if x, ok := m.GetRequest().(*Request_Savepoint); ok {
+		return x.Savepoint
+	}
+	return nil
+}
+
+func (m *Request) GetRollbackTo() *RollbackToRequest {
+	if x, ok := m.GetRequest().(*Request_RollbackTo); ok {
+		return x.RollbackTo
+	}
+	return nil
+}
+
+func (m *Request) GetRelease() *ReleaseRequest {
+	if x, ok := m.GetRequest().(*Request_Release); ok {
+		return x.Release
+	}
+	return nil
+}
+
+func (m *Request) GetContinue() *ContinueRequest {
+	if x, ok := m.GetRequest().(*Request_Continue); ok {
+		return x.Continue
+	}
+	return nil
+}
+
+func (m *Request) GetAttachmentChunk() *AttachmentChunkRequest {
+	if x, ok := m.GetRequest().(*Request_AttachmentChunk); ok {
+		return x.AttachmentChunk
+	}
+	return nil
+}
+
+func (m *Request) GetFetch() *FetchRequest {
+	if x, ok := m.GetRequest().(*Request_Fetch); ok {
+		return x.Fetch
+	}
+	return nil
+}
+
+func (m *Request) GetExplain() *ExplainRequest {
+	if x, ok := m.GetRequest().(*Request_Explain); ok {
+		return x.Explain
+	}
+	return nil
+}
+
+func (m *Request) GetGetAttachment() *GetAttachmentRequest {
+	if x, ok := m.GetRequest().(*Request_GetAttachment); ok {
+		return x.GetAttachment
+	}
+	return nil
+}
+
+func (m *Request) GetListAttachments() *ListAttachmentsRequest {
+	if x, ok := m.GetRequest().(*Request_ListAttachments); ok {
+		return x.ListAttachments
+	}
+	return nil
+}
+
+func (m *Request) GetChangesSince() *ChangesSinceRequest {
+	if x, ok := m.GetRequest().(*Request_ChangesSince); ok {
+		return x.ChangesSince
+	}
+	return nil
+}
+
+func (m *Request) GetListKeys() *ListKeysRequest {
+	if x, ok := m.GetRequest().(*Request_ListKeys); ok {
+		return x.ListKeys
+	}
+	return nil
+}
+
+func (m *Request) GetSave() *SaveRequest {
+	if x, ok := m.GetRequest().(*Request_Save); ok {
+		return x.Save
+	}
+	return nil
+}
+
+func (m *Request) GetDelete() *DeleteRequest {
+	if x, ok := m.GetRequest().(*Request_Delete); ok {
+		return x.Delete
+	}
+	return nil
+}
+
+func (m *Request) GetDeleteWhere() *DeleteWhereRequest {
+	if x, ok := m.GetRequest().(*Request_DeleteWhere); ok {
+		return x.DeleteWhere
+	}
+	return nil
+}
+
+func (m *Request) GetDeleteNamespace() *DeleteNamespaceRequest {
+	if x, ok := m.GetRequest().(*Request_DeleteNamespace); ok {
+		return x.DeleteNamespace
+	}
+	return nil
+}
+
+func (m *Request) GetPutAttachment() *PutAttachmentRequest {
+	if x, ok := m.GetRequest().(*Request_PutAttachment); ok {
+		return x.PutAttachment
+	}
+	return nil
+}
+
+func (m *Request) GetDeleteAttachment() *DeleteAttachmentRequest {
+	if x, ok := m.GetRequest().(*Request_DeleteAttachment); ok {
+		return x.DeleteAttachment
+	}
+	return nil
+}
+
+func (m *Request) GetApplyChange() *ApplyChangeRequest {
+	if x, ok := m.GetRequest().(*Request_ApplyChange); ok {
+		return x.ApplyChange
+	}
+	return nil
+}
+
+func (m *Request) GetRestore() *RestoreRequest {
+	if x, ok := m.GetRequest().(*Request_Restore); ok {
+		return x.Restore
+	}
+	return nil
+}
+
+func (m *Request) GetPurgeDeleted() *PurgeDeletedRequest {
+	if x, ok := m.GetRequest().(*Request_PurgeDeleted); ok {
+		return x.PurgeDeleted
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Request) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Request_OneofMarshaler, _Request_OneofUnmarshaler, _Request_OneofSizer, []interface{}{
+		(*Request_Begin)(nil),
+		(*Request_Commit)(nil),
+		(*Request_Savepoint)(nil),
+		(*Request_RollbackTo)(nil),
+		(*Request_Release)(nil),
+		(*Request_Continue)(nil),
+		(*Request_AttachmentChunk)(nil),
+		(*Request_Fetch)(nil),
+		(*Request_Explain)(nil),
+		(*Request_GetAttachment)(nil),
+		(*Request_ListAttachments)(nil),
+		(*Request_ChangesSince)(nil),
+		(*Request_ListKeys)(nil),
+		(*Request_Save)(nil),
+		(*Request_Delete)(nil),
+		(*Request_DeleteWhere)(nil),
+		(*Request_DeleteNamespace)(nil),
+		(*Request_PutAttachment)(nil),
+		(*Request_DeleteAttachment)(nil),
+		(*Request_ApplyChange)(nil),
+		(*Request_Restore)(nil),
+		(*Request_PurgeDeleted)(nil),
+	}
+}
+
+func _Request_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Request)
+	// request
+	switch x := m.Request.(type) {
+	case *Request_Begin:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Begin); err != nil {
+			return err
+		}
+	case *Request_Commit:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Commit); err != nil {
+			return err
+		}
+	case *Request_Savepoint:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Savepoint); err != nil {
+			return err
+		}
+	case *Request_RollbackTo:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RollbackTo); err != nil {
+			return err
+		}
+	case *Request_Release:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Release); err != nil {
+			return err
+		}
+	case *Request_Continue:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Continue); err != nil {
+			return err
+		}
+	case *Request_AttachmentChunk:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.AttachmentChunk); err != nil {
+			return err
+		}
+	case *Request_Fetch:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Fetch); err != nil {
+			return err
+		}
+	case *Request_Explain:
+		b.EncodeVarint(11<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Explain); err != nil {
+			return err
+		}
+	case *Request_GetAttachment:
+		b.EncodeVarint(12<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.GetAttachment); err != nil {
+			return err
+		}
+	case *Request_ListAttachments:
+		b.EncodeVarint(13<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ListAttachments); err != nil {
+			return err
+		}
+	case *Request_ChangesSince:
+		b.EncodeVarint(14<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChangesSince); err != nil {
+			return err
+		}
+	case *Request_ListKeys:
+		b.EncodeVarint(15<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ListKeys); err != nil {
+			return err
+		}
+	case *Request_Save:
+		b.EncodeVarint(20<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Save); err != nil {
+			return err
+		}
+	case *Request_Delete:
+		b.EncodeVarint(21<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Delete); err != nil {
+			return err
+		}
+	case *Request_DeleteWhere:
+		b.EncodeVarint(22<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DeleteWhere); err != nil {
+			return err
+		}
+	case *Request_DeleteNamespace:
+		b.EncodeVarint(23<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DeleteNamespace); err != nil {
+			return err
+		}
+	case *Request_PutAttachment:
+		b.EncodeVarint(24<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PutAttachment); err != nil {
+			return err
+		}
+	case *Request_DeleteAttachment:
+		b.EncodeVarint(25<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DeleteAttachment); err != nil {
+			return err
+		}
+	case *Request_ApplyChange:
+		b.EncodeVarint(26<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ApplyChange); err != nil {
+			return err
+		}
+	case *Request_Restore:
+		b.EncodeVarint(27<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Restore); err != nil {
+			return err
+		}
+	case *Request_PurgeDeleted:
+		b.EncodeVarint(28<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PurgeDeleted); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Request.Request has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Request_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Request)
+	switch tag {
+	case 1: // request.begin
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BeginRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_Begin{msg}
+		return true, err
+	case 2: // request.commit
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CommitRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_Commit{msg}
+		return true, err
+	case 3: // request.savepoint
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SavepointRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_Savepoint{msg}
+		return true, err
+	case 4: // request.rollback_to
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RollbackToRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_RollbackTo{msg}
+		return true, err
+	case 5: // request.release
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ReleaseRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_Release{msg}
+		return true, err
+	case 6: // request.continue
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ContinueRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_Continue{msg}
+		return true, err
+	case 7: // request.attachment_chunk
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(AttachmentChunkRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_AttachmentChunk{msg}
+		return true, err
+	case 10: // request.fetch
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FetchRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_Fetch{msg}
+		return true, err
+	case 11: // request.explain
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ExplainRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_Explain{msg}
+		return true, err
+	case 12: // request.get_attachment
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(GetAttachmentRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_GetAttachment{msg}
+		return true, err
+	case 13: // request.list_attachments
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ListAttachmentsRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_ListAttachments{msg}
+		return true, err
+	case 14: // request.changes_since
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChangesSinceRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_ChangesSince{msg}
+		return true, err
+	case 15: // request.list_keys
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ListKeysRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_ListKeys{msg}
+		return true, err
+	case 20: // request.save
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SaveRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_Save{msg}
+		return true, err
+	case 21: // request.delete
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DeleteRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_Delete{msg}
+		return true, err
+	case 22: // request.delete_where
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DeleteWhereRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_DeleteWhere{msg}
+		return true, err
+	case 23: // request.delete_namespace
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DeleteNamespaceRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_DeleteNamespace{msg}
+		return true, err
+	case 24: // request.put_attachment
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PutAttachmentRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_PutAttachment{msg}
+		return true, err
+	case 25: // request.delete_attachment
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DeleteAttachmentRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_DeleteAttachment{msg}
+		return true, err
+	case 26: // request.apply_change
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ApplyChangeRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_ApplyChange{msg}
+		return true, err
+	case 27: // request.restore
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RestoreRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_Restore{msg}
+		return true, err
+	case 28: // request.purge_deleted
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PurgeDeletedRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &Request_PurgeDeleted{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Request_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Request)
+	// request
+	switch x := m.Request.(type) {
+	case *Request_Begin:
+		s := proto.Size(x.Begin)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_Commit:
+		s := proto.Size(x.Commit)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_Savepoint:
+		s := proto.Size(x.Savepoint)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_RollbackTo:
+		s := proto.Size(x.RollbackTo)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_Release:
+		s := proto.Size(x.Release)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_Continue:
+		s := proto.Size(x.Continue)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_AttachmentChunk:
+		s := proto.Size(x.AttachmentChunk)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_Fetch:
+		s := proto.Size(x.Fetch)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_Explain:
+		s := proto.Size(x.Explain)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_GetAttachment:
+		s := proto.Size(x.GetAttachment)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_ListAttachments:
+		s := proto.Size(x.ListAttachments)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_ChangesSince:
+		s := proto.Size(x.ChangesSince)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_ListKeys:
+		s := proto.Size(x.ListKeys)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_Save:
+		s := proto.Size(x.Save)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_Delete:
+		s := proto.Size(x.Delete)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_DeleteWhere:
+		s := proto.Size(x.DeleteWhere)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_DeleteNamespace:
+		s := proto.Size(x.DeleteNamespace)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_PutAttachment:
+		s := proto.Size(x.PutAttachment)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_DeleteAttachment:
+		s := proto.Size(x.DeleteAttachment)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_ApplyChange:
+		s := proto.Size(x.ApplyChange)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_Restore:
+		s := proto.Size(x.Restore)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_PurgeDeleted:
+		s := proto.Size(x.PurgeDeleted)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// Response is the result of a single request.
+//
+// Only the fields that are relevant to the type of request are populated.
+type Response struct {
+	// error describes the failure of the request. It is absent if the request
+	// succeeded.
+	Error       *Error        `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Documents   []*Document   `protobuf:"bytes,2,rep,name=documents,proto3" json:"documents,omitempty"`
+	Plan        *QueryPlan    `protobuf:"bytes,3,opt,name=plan,proto3" json:"plan,omitempty"`
+	DocumentIds []string      `protobuf:"bytes,4,rep,name=document_ids,json=documentIds,proto3" json:"document_ids,omitempty"`
+	Found       bool          `protobuf:"varint,5,opt,name=found,proto3" json:"found,omitempty"`
+	Content     []byte        `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	Attachments []*Attachment `protobuf:"bytes,7,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	Changes     []*Change     `protobuf:"bytes,8,rep,name=changes,proto3" json:"changes,omitempty"`
+	Savepoint   uint64        `protobuf:"varint,9,opt,name=savepoint,proto3" json:"savepoint,omitempty"`
+	Keys        []*Key        `protobuf:"bytes,10,rep,name=keys,proto3" json:"keys,omitempty"`
+	// more is true if the response is one of a sequence of responses, and is
+	// not the last.
+	More                 bool     `protobuf:"varint,11,opt,name=more,proto3" json:"more,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Response) Reset()         { *m = Response{} }
+func (m *Response) String() string { return proto.CompactTextString(m) }
+func (*Response) ProtoMessage()    {}
+func (*Response) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{1}
+}
+func (m *Response) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Response.Unmarshal(m, b)
+}
+func (m *Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Response.Marshal(b, m, deterministic)
+}
+func (dst *Response) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Response.Merge(dst, src)
+}
+func (m *Response) XXX_Size() int {
+	return xxx_messageInfo_Response.Size(m)
+}
+func (m *Response) XXX_DiscardUnknown() {
+	xxx_messageInfo_Response.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Response proto.InternalMessageInfo
+
+func (m *Response) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *Response) GetDocuments() []*Document {
+	if m != nil {
+		return m.Documents
+	}
+	return nil
+}
+
+func (m *Response) GetPlan() *QueryPlan {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
+func (m *Response) GetDocumentIds() []string {
+	if m != nil {
+		return m.DocumentIds
+	}
+	return nil
+}
+
+func (m *Response) GetFound() bool {
+	if m != nil {
+		return m.Found
+	}
+	return false
+}
+
+func (m *Response) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *Response) GetAttachments() []*Attachment {
+	if m != nil {
+		return m.Attachments
+	}
+	return nil
+}
+
+func (m *Response) GetChanges() []*Change {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *Response) GetSavepoint() uint64 {
+	if m != nil {
+		return m.Savepoint
+	}
+	return 0
+}
+
+func (m *Response) GetKeys() []*Key {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *Response) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+type BeginRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Write                bool     `protobuf:"varint,2,opt,name=write,proto3" json:"write,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BeginRequest) Reset()         { *m = BeginRequest{} }
+func (m *BeginRequest) String() string { return proto.CompactTextString(m) }
+func (*BeginRequest) ProtoMessage()    {}
+func (*BeginRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{2}
+}
+func (m *BeginRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BeginRequest.Unmarshal(m, b)
+}
+func (m *BeginRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BeginRequest.Marshal(b, m, deterministic)
+}
+func (dst *BeginRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BeginRequest.Merge(dst, src)
+}
+func (m *BeginRequest) XXX_Size() int {
+	return xxx_messageInfo_BeginRequest.Size(m)
+}
+func (m *BeginRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BeginRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BeginRequest proto.InternalMessageInfo
+
+func (m *BeginRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *BeginRequest) GetWrite() bool {
+	if m != nil {
+		return m.Write
+	}
+	return false
+}
+
+type CommitRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitRequest) Reset()         { *m = CommitRequest{} }
+func (m *CommitRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitRequest) ProtoMessage()    {}
+func (*CommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{3}
+}
+func (m *CommitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitRequest.Unmarshal(m, b)
+}
+func (m *CommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitRequest.Marshal(b, m, deterministic)
+}
+func (dst *CommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitRequest.Merge(dst, src)
+}
+func (m *CommitRequest) XXX_Size() int {
+	return xxx_messageInfo_CommitRequest.Size(m)
+}
+func (m *CommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitRequest proto.InternalMessageInfo
+
+type SavepointRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SavepointRequest) Reset()         { *m = SavepointRequest{} }
+func (m *SavepointRequest) String() string { return proto.CompactTextString(m) }
+func (*SavepointRequest) ProtoMessage()    {}
+func (*SavepointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{4}
+}
+func (m *SavepointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SavepointRequest.Unmarshal(m, b)
+}
+func (m *SavepointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SavepointRequest.Marshal(b, m, deterministic)
+}
+func (dst *SavepointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SavepointRequest.Merge(dst, src)
+}
+func (m *SavepointRequest) XXX_Size() int {
+	return xxx_messageInfo_SavepointRequest.Size(m)
+}
+func (m *SavepointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SavepointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SavepointRequest proto.InternalMessageInfo
+
+type RollbackToRequest struct {
+	Savepoint            uint64   `protobuf:"varint,1,opt,name=savepoint,proto3" json:"savepoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollbackToRequest) Reset()         { *m = RollbackToRequest{} }
+func (m *RollbackToRequest) String() string { return proto.CompactTextString(m) }
+func (*RollbackToRequest) ProtoMessage()    {}
+func (*RollbackToRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{5}
+}
+func (m *RollbackToRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackToRequest.Unmarshal(m, b)
+}
+func (m *RollbackToRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackToRequest.Marshal(b, m, deterministic)
+}
+func (dst *RollbackToRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackToRequest.Merge(dst, src)
+}
+func (m *RollbackToRequest) XXX_Size() int {
+	return xxx_messageInfo_RollbackToRequest.Size(m)
+}
+func (m *RollbackToRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackToRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackToRequest proto.InternalMessageInfo
+
+func (m *RollbackToRequest) GetSavepoint() uint64 {
+	if m != nil {
+		return m.Savepoint
+	}
+	return 0
+}
+
+type ReleaseRequest struct {
+	Savepoint            uint64   `protobuf:"varint,1,opt,name=savepoint,proto3" json:"savepoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseRequest) Reset()         { *m = ReleaseRequest{} }
+func (m *ReleaseRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseRequest) ProtoMessage()    {}
+func (*ReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{6}
+}
+func (m *ReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseRequest.Unmarshal(m, b)
+}
+func (m *ReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseRequest.Merge(dst, src)
+}
+func (m *ReleaseRequest) XXX_Size() int {
+	return xxx_messageInfo_ReleaseRequest.Size(m)
+}
+func (m *ReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseRequest proto.InternalMessageInfo
+
+func (m *ReleaseRequest) GetSavepoint() uint64 {
+	if m != nil {
+		return m.Savepoint
+	}
+	return 0
+}
+
+// ContinueRequest answers a response that has its "more" field set.
+type ContinueRequest struct {
+	// cancel is true if the client does not require the remaining results. The
+	// server stops producing results and sends the final response.
+	Cancel               bool     `protobuf:"varint,1,opt,name=cancel,proto3" json:"cancel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContinueRequest) Reset()         { *m = ContinueRequest{} }
+func (m *ContinueRequest) String() string { return proto.CompactTextString(m) }
+func (*ContinueRequest) ProtoMessage()    {}
+func (*ContinueRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{7}
+}
+func (m *ContinueRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContinueRequest.Unmarshal(m, b)
+}
+func (m *ContinueRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContinueRequest.Marshal(b, m, deterministic)
+}
+func (dst *ContinueRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContinueRequest.Merge(dst, src)
+}
+func (m *ContinueRequest) XXX_Size() int {
+	return xxx_messageInfo_ContinueRequest.Size(m)
+}
+func (m *ContinueRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContinueRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContinueRequest proto.InternalMessageInfo
+
+func (m *ContinueRequest) GetCancel() bool {
+	if m != nil {
+		return m.Cancel
+	}
+	return false
+}
+
+// AttachmentChunkRequest carries part of the content of an attachment that is
+// being stored by a put-attachment request.
+type AttachmentChunkRequest struct {
+	Content []byte `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	// more is true if further chunks follow.
+	More bool `protobuf:"varint,2,opt,name=more,proto3" json:"more,omitempty"`
+	// cancel is true if the client could not read the remainder of the
+	// content. The attachment is not stored.
+	Cancel               bool     `protobuf:"varint,3,opt,name=cancel,proto3" json:"cancel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttachmentChunkRequest) Reset()         { *m = AttachmentChunkRequest{} }
+func (m *AttachmentChunkRequest) String() string { return proto.CompactTextString(m) }
+func (*AttachmentChunkRequest) ProtoMessage()    {}
+func (*AttachmentChunkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{8}
+}
+func (m *AttachmentChunkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttachmentChunkRequest.Unmarshal(m, b)
+}
+func (m *AttachmentChunkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttachmentChunkRequest.Marshal(b, m, deterministic)
+}
+func (dst *AttachmentChunkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttachmentChunkRequest.Merge(dst, src)
+}
+func (m *AttachmentChunkRequest) XXX_Size() int {
+	return xxx_messageInfo_AttachmentChunkRequest.Size(m)
+}
+func (m *AttachmentChunkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttachmentChunkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttachmentChunkRequest proto.InternalMessageInfo
+
+func (m *AttachmentChunkRequest) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *AttachmentChunkRequest) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+func (m *AttachmentChunkRequest) GetCancel() bool {
+	if m != nil {
+		return m.Cancel
+	}
+	return false
+}
+
+type FetchRequest struct {
+	// filter is absent if every document is to be fetched.
+	Filter               *Filter  `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchRequest) Reset()         { *m = FetchRequest{} }
+func (m *FetchRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchRequest) ProtoMessage()    {}
+func (*FetchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{9}
+}
+func (m *FetchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchRequest.Unmarshal(m, b)
+}
+func (m *FetchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchRequest.Marshal(b, m, deterministic)
+}
+func (dst *FetchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchRequest.Merge(dst, src)
+}
+func (m *FetchRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchRequest.Size(m)
+}
+func (m *FetchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchRequest proto.InternalMessageInfo
+
+func (m *FetchRequest) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type ExplainRequest struct {
+	Filter               *Filter  `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExplainRequest) Reset()         { *m = ExplainRequest{} }
+func (m *ExplainRequest) String() string { return proto.CompactTextString(m) }
+func (*ExplainRequest) ProtoMessage()    {}
+func (*ExplainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{10}
+}
+func (m *ExplainRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainRequest.Unmarshal(m, b)
+}
+func (m *ExplainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExplainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainRequest.Merge(dst, src)
+}
+func (m *ExplainRequest) XXX_Size() int {
+	return xxx_messageInfo_ExplainRequest.Size(m)
+}
+func (m *ExplainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainRequest proto.InternalMessageInfo
+
+func (m *ExplainRequest) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type GetAttachmentRequest struct {
+	DocumentId           string   `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAttachmentRequest) Reset()         { *m = GetAttachmentRequest{} }
+func (m *GetAttachmentRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAttachmentRequest) ProtoMessage()    {}
+func (*GetAttachmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{11}
+}
+func (m *GetAttachmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAttachmentRequest.Unmarshal(m, b)
+}
+func (m *GetAttachmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAttachmentRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetAttachmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAttachmentRequest.Merge(dst, src)
+}
+func (m *GetAttachmentRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAttachmentRequest.Size(m)
+}
+func (m *GetAttachmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAttachmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAttachmentRequest proto.InternalMessageInfo
+
+func (m *GetAttachmentRequest) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+func (m *GetAttachmentRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListAttachmentsRequest struct {
+	DocumentId           string   `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAttachmentsRequest) Reset()         { *m = ListAttachmentsRequest{} }
+func (m *ListAttachmentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAttachmentsRequest) ProtoMessage()    {}
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{12}
+}
+func (m *ListAttachmentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAttachmentsRequest.Unmarshal(m, b)
+}
+func (m *ListAttachmentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAttachmentsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListAttachmentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAttachmentsRequest.Merge(dst, src)
+}
+func (m *ListAttachmentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAttachmentsRequest.Size(m)
+}
+func (m *ListAttachmentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAttachmentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAttachmentsRequest proto.InternalMessageInfo
+
+func (m *ListAttachmentsRequest) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+type ChangesSinceRequest struct {
+	Since                uint64   `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangesSinceRequest) Reset()         { *m = ChangesSinceRequest{} }
+func (m *ChangesSinceRequest) String() string { return proto.CompactTextString(m) }
+func (*ChangesSinceRequest) ProtoMessage()    {}
+func (*ChangesSinceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{13}
+}
+func (m *ChangesSinceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangesSinceRequest.Unmarshal(m, b)
+}
+func (m *ChangesSinceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangesSinceRequest.Marshal(b, m, deterministic)
+}
+func (dst *ChangesSinceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangesSinceRequest.Merge(dst, src)
+}
+func (m *ChangesSinceRequest) XXX_Size() int {
+	return xxx_messageInfo_ChangesSinceRequest.Size(m)
+}
+func (m *ChangesSinceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangesSinceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangesSinceRequest proto.InternalMessageInfo
+
+func (m *ChangesSinceRequest) GetSince() uint64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+type ListKeysRequest struct {
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Type                 uint32   `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListKeysRequest) Reset()         { *m = ListKeysRequest{} }
+func (m *ListKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListKeysRequest) ProtoMessage()    {}
+func (*ListKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{14}
+}
+func (m *ListKeysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListKeysRequest.Unmarshal(m, b)
+}
+func (m *ListKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListKeysRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListKeysRequest.Merge(dst, src)
+}
+func (m *ListKeysRequest) XXX_Size() int {
+	return xxx_messageInfo_ListKeysRequest.Size(m)
+}
+func (m *ListKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListKeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListKeysRequest proto.InternalMessageInfo
+
+func (m *ListKeysRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *ListKeysRequest) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+type SaveRequest struct {
+	Document             *Document         `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	Force                bool              `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	DerivedKeys          map[string]uint32 `protobuf:"bytes,3,rep,name=derived_keys,json=derivedKeys,proto3" json:"derived_keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SaveRequest) Reset()         { *m = SaveRequest{} }
+func (m *SaveRequest) String() string { return proto.CompactTextString(m) }
+func (*SaveRequest) ProtoMessage()    {}
+func (*SaveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{15}
+}
+func (m *SaveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SaveRequest.Unmarshal(m, b)
+}
+func (m *SaveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SaveRequest.Marshal(b, m, deterministic)
+}
+func (dst *SaveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveRequest.Merge(dst, src)
+}
+func (m *SaveRequest) XXX_Size() int {
+	return xxx_messageInfo_SaveRequest.Size(m)
+}
+func (m *SaveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SaveRequest proto.InternalMessageInfo
+
+func (m *SaveRequest) GetDocument() *Document {
+	if m != nil {
+		return m.Document
+	}
+	return nil
+}
+
+func (m *SaveRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *SaveRequest) GetDerivedKeys() map[string]uint32 {
+	if m != nil {
+		return m.DerivedKeys
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	Document             *Document `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{16}
+}
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(dst, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetDocument() *Document {
+	if m != nil {
+		return m.Document
+	}
+	return nil
+}
+
+type DeleteWhereRequest struct {
+	// filter is absent if every document is to be deleted.
+	Filter               *Filter  `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWhereRequest) Reset()         { *m = DeleteWhereRequest{} }
+func (m *DeleteWhereRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteWhereRequest) ProtoMessage()    {}
+func (*DeleteWhereRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{17}
+}
+func (m *DeleteWhereRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWhereRequest.Unmarshal(m, b)
+}
+func (m *DeleteWhereRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWhereRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteWhereRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWhereRequest.Merge(dst, src)
+}
+func (m *DeleteWhereRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteWhereRequest.Size(m)
+}
+func (m *DeleteWhereRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWhereRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWhereRequest proto.InternalMessageInfo
+
+func (m *DeleteWhereRequest) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type DeleteNamespaceRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteNamespaceRequest) Reset()         { *m = DeleteNamespaceRequest{} }
+func (m *DeleteNamespaceRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteNamespaceRequest) ProtoMessage()    {}
+func (*DeleteNamespaceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{18}
+}
+func (m *DeleteNamespaceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteNamespaceRequest.Unmarshal(m, b)
+}
+func (m *DeleteNamespaceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteNamespaceRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteNamespaceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteNamespaceRequest.Merge(dst, src)
+}
+func (m *DeleteNamespaceRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteNamespaceRequest.Size(m)
+}
+func (m *DeleteNamespaceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteNamespaceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteNamespaceRequest proto.InternalMessageInfo
+
+type PutAttachmentRequest struct {
+	DocumentId string `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Content    []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// more is true if the remainder of the content follows in
+	// attachment-chunk requests.
+	More                 bool     `protobuf:"varint,4,opt,name=more,proto3" json:"more,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutAttachmentRequest) Reset()         { *m = PutAttachmentRequest{} }
+func (m *PutAttachmentRequest) String() string { return proto.CompactTextString(m) }
+func (*PutAttachmentRequest) ProtoMessage()    {}
+func (*PutAttachmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{19}
+}
+func (m *PutAttachmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutAttachmentRequest.Unmarshal(m, b)
+}
+func (m *PutAttachmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutAttachmentRequest.Marshal(b, m, deterministic)
+}
+func (dst *PutAttachmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutAttachmentRequest.Merge(dst, src)
+}
+func (m *PutAttachmentRequest) XXX_Size() int {
+	return xxx_messageInfo_PutAttachmentRequest.Size(m)
+}
+func (m *PutAttachmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutAttachmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutAttachmentRequest proto.InternalMessageInfo
+
+func (m *PutAttachmentRequest) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+func (m *PutAttachmentRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PutAttachmentRequest) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *PutAttachmentRequest) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+type DeleteAttachmentRequest struct {
+	DocumentId           string   `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteAttachmentRequest) Reset()         { *m = DeleteAttachmentRequest{} }
+func (m *DeleteAttachmentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteAttachmentRequest) ProtoMessage()    {}
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{20}
+}
+func (m *DeleteAttachmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAttachmentRequest.Unmarshal(m, b)
+}
+func (m *DeleteAttachmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAttachmentRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteAttachmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAttachmentRequest.Merge(dst, src)
+}
+func (m *DeleteAttachmentRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteAttachmentRequest.Size(m)
+}
+func (m *DeleteAttachmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAttachmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAttachmentRequest proto.InternalMessageInfo
+
+func (m *DeleteAttachmentRequest) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+func (m *DeleteAttachmentRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ApplyChangeRequest struct {
+	Change               *Change  `protobuf:"bytes,1,opt,name=change,proto3" json:"change,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplyChangeRequest) Reset()         { *m = ApplyChangeRequest{} }
+func (m *ApplyChangeRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplyChangeRequest) ProtoMessage()    {}
+func (*ApplyChangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{21}
+}
+func (m *ApplyChangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyChangeRequest.Unmarshal(m, b)
+}
+func (m *ApplyChangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyChangeRequest.Marshal(b, m, deterministic)
+}
+func (dst *ApplyChangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyChangeRequest.Merge(dst, src)
+}
+func (m *ApplyChangeRequest) XXX_Size() int {
+	return xxx_messageInfo_ApplyChangeRequest.Size(m)
+}
+func (m *ApplyChangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyChangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyChangeRequest proto.InternalMessageInfo
+
+func (m *ApplyChangeRequest) GetChange() *Change {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+type RestoreRequest struct {
+	DocumentId           string   `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
+func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreRequest) ProtoMessage()    {}
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{22}
+}
+func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
+}
+func (m *RestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreRequest.Marshal(b, m, deterministic)
+}
+func (dst *RestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRequest.Merge(dst, src)
+}
+func (m *RestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreRequest.Size(m)
+}
+func (m *RestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRequest proto.InternalMessageInfo
+
+func (m *RestoreRequest) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+type PurgeDeletedRequest struct {
+	Before               *timestamp.Timestamp `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PurgeDeletedRequest) Reset()         { *m = PurgeDeletedRequest{} }
+func (m *PurgeDeletedRequest) String() string { return proto.CompactTextString(m) }
+func (*PurgeDeletedRequest) ProtoMessage()    {}
+func (*PurgeDeletedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{23}
+}
+func (m *PurgeDeletedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeDeletedRequest.Unmarshal(m, b)
+}
+func (m *PurgeDeletedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeDeletedRequest.Marshal(b, m, deterministic)
+}
+func (dst *PurgeDeletedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeDeletedRequest.Merge(dst, src)
+}
+func (m *PurgeDeletedRequest) XXX_Size() int {
+	return xxx_messageInfo_PurgeDeletedRequest.Size(m)
+}
+func (m *PurgeDeletedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeDeletedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeDeletedRequest proto.InternalMessageInfo
+
+func (m *PurgeDeletedRequest) GetBefore() *timestamp.Timestamp {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+// Error describes the failure of a request.
+type Error struct {
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Errors of the types that clients are expected to inspect are described
+	// in full, such that they can be reconstructed by the client.
+	OptimisticLock       *OptimisticLockError    `protobuf:"bytes,2,opt,name=optimistic_lock,json=optimisticLock,proto3" json:"optimistic_lock,omitempty"`
+	DuplicateKey         *DuplicateKeyError      `protobuf:"bytes,3,opt,name=duplicate_key,json=duplicateKey,proto3" json:"duplicate_key,omitempty"`
+	NotFound             *NotFoundError          `protobuf:"bytes,4,opt,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+	NamespaceNotFound    *NamespaceNotFoundError `protobuf:"bytes,5,opt,name=namespace_not_found,json=namespaceNotFound,proto3" json:"namespace_not_found,omitempty"`
+	DataIntegrity        *DataIntegrityError     `protobuf:"bytes,6,opt,name=data_integrity,json=dataIntegrity,proto3" json:"data_integrity,omitempty"`
+	InvalidDocument      *InvalidDocumentError   `protobuf:"bytes,7,opt,name=invalid_document,json=invalidDocument,proto3" json:"invalid_document,omitempty"`
+	TxClosed             bool                    `protobuf:"varint,8,opt,name=tx_closed,json=txClosed,proto3" json:"tx_closed,omitempty"`
+	Validation           *ValidationError        `protobuf:"bytes,9,opt,name=validation,proto3" json:"validation,omitempty"`
+	StaleSequence        *StaleSequenceError     `protobuf:"bytes,10,opt,name=stale_sequence,json=staleSequence,proto3" json:"stale_sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *Error) Reset()         { *m = Error{} }
+func (m *Error) String() string { return proto.CompactTextString(m) }
+func (*Error) ProtoMessage()    {}
+func (*Error) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{24}
+}
+func (m *Error) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Error.Unmarshal(m, b)
+}
+func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Error.Marshal(b, m, deterministic)
+}
+func (dst *Error) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Error.Merge(dst, src)
+}
+func (m *Error) XXX_Size() int {
+	return xxx_messageInfo_Error.Size(m)
+}
+func (m *Error) XXX_DiscardUnknown() {
+	xxx_messageInfo_Error.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Error proto.InternalMessageInfo
+
+func (m *Error) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Error) GetOptimisticLock() *OptimisticLockError {
+	if m != nil {
+		return m.OptimisticLock
+	}
+	return nil
+}
+
+func (m *Error) GetDuplicateKey() *DuplicateKeyError {
+	if m != nil {
+		return m.DuplicateKey
+	}
+	return nil
+}
+
+func (m *Error) GetNotFound() *NotFoundError {
+	if m != nil {
+		return m.NotFound
+	}
+	return nil
+}
+
+func (m *Error) GetNamespaceNotFound() *NamespaceNotFoundError {
+	if m != nil {
+		return m.NamespaceNotFound
+	}
+	return nil
+}
+
+func (m *Error) GetDataIntegrity() *DataIntegrityError {
+	if m != nil {
+		return m.DataIntegrity
+	}
+	return nil
+}
+
+func (m *Error) GetInvalidDocument() *InvalidDocumentError {
+	if m != nil {
+		return m.InvalidDocument
+	}
+	return nil
+}
+
+func (m *Error) GetTxClosed() bool {
+	if m != nil {
+		return m.TxClosed
+	}
+	return false
+}
+
+func (m *Error) GetValidation() *ValidationError {
+	if m != nil {
+		return m.Validation
+	}
+	return nil
+}
+
+func (m *Error) GetStaleSequence() *StaleSequenceError {
+	if m != nil {
+		return m.StaleSequence
+	}
+	return nil
+}
+
+type OptimisticLockError struct {
+	DocumentId           string   `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	GivenRev             uint64   `protobuf:"varint,2,opt,name=given_rev,json=givenRev,proto3" json:"given_rev,omitempty"`
+	ActualRev            uint64   `protobuf:"varint,3,opt,name=actual_rev,json=actualRev,proto3" json:"actual_rev,omitempty"`
+	Operation            string   `protobuf:"bytes,4,opt,name=operation,proto3" json:"operation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OptimisticLockError) Reset()         { *m = OptimisticLockError{} }
+func (m *OptimisticLockError) String() string { return proto.CompactTextString(m) }
+func (*OptimisticLockError) ProtoMessage()    {}
+func (*OptimisticLockError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{25}
+}
+func (m *OptimisticLockError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OptimisticLockError.Unmarshal(m, b)
+}
+func (m *OptimisticLockError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OptimisticLockError.Marshal(b, m, deterministic)
+}
+func (dst *OptimisticLockError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OptimisticLockError.Merge(dst, src)
+}
+func (m *OptimisticLockError) XXX_Size() int {
+	return xxx_messageInfo_OptimisticLockError.Size(m)
+}
+func (m *OptimisticLockError) XXX_DiscardUnknown() {
+	xxx_messageInfo_OptimisticLockError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OptimisticLockError proto.InternalMessageInfo
+
+func (m *OptimisticLockError) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+func (m *OptimisticLockError) GetGivenRev() uint64 {
+	if m != nil {
+		return m.GivenRev
+	}
+	return 0
+}
+
+func (m *OptimisticLockError) GetActualRev() uint64 {
+	if m != nil {
+		return m.ActualRev
+	}
+	return 0
+}
+
+func (m *OptimisticLockError) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+type DuplicateKeyError struct {
+	DocumentId            string   `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	ConflictingDocumentId string   `protobuf:"bytes,2,opt,name=conflicting_document_id,json=conflictingDocumentId,proto3" json:"conflicting_document_id,omitempty"`
+	UniqueKey             string   `protobuf:"bytes,3,opt,name=unique_key,json=uniqueKey,proto3" json:"unique_key,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *DuplicateKeyError) Reset()         { *m = DuplicateKeyError{} }
+func (m *DuplicateKeyError) String() string { return proto.CompactTextString(m) }
+func (*DuplicateKeyError) ProtoMessage()    {}
+func (*DuplicateKeyError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{26}
+}
+func (m *DuplicateKeyError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DuplicateKeyError.Unmarshal(m, b)
+}
+func (m *DuplicateKeyError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DuplicateKeyError.Marshal(b, m, deterministic)
+}
+func (dst *DuplicateKeyError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicateKeyError.Merge(dst, src)
+}
+func (m *DuplicateKeyError) XXX_Size() int {
+	return xxx_messageInfo_DuplicateKeyError.Size(m)
+}
+func (m *DuplicateKeyError) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicateKeyError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicateKeyError proto.InternalMessageInfo
+
+func (m *DuplicateKeyError) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+func (m *DuplicateKeyError) GetConflictingDocumentId() string {
+	if m != nil {
+		return m.ConflictingDocumentId
+	}
+	return ""
+}
+
+func (m *DuplicateKeyError) GetUniqueKey() string {
+	if m != nil {
+		return m.UniqueKey
+	}
+	return ""
+}
+
+type NotFoundError struct {
+	DocumentId           string   `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Operation            string   `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NotFoundError) Reset()         { *m = NotFoundError{} }
+func (m *NotFoundError) String() string { return proto.CompactTextString(m) }
+func (*NotFoundError) ProtoMessage()    {}
+func (*NotFoundError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{27}
+}
+func (m *NotFoundError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotFoundError.Unmarshal(m, b)
+}
+func (m *NotFoundError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotFoundError.Marshal(b, m, deterministic)
+}
+func (dst *NotFoundError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotFoundError.Merge(dst, src)
+}
+func (m *NotFoundError) XXX_Size() int {
+	return xxx_messageInfo_NotFoundError.Size(m)
+}
+func (m *NotFoundError) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotFoundError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotFoundError proto.InternalMessageInfo
+
+func (m *NotFoundError) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+func (m *NotFoundError) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+type NamespaceNotFoundError struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Operation            string   `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NamespaceNotFoundError) Reset()         { *m = NamespaceNotFoundError{} }
+func (m *NamespaceNotFoundError) String() string { return proto.CompactTextString(m) }
+func (*NamespaceNotFoundError) ProtoMessage()    {}
+func (*NamespaceNotFoundError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{28}
+}
+func (m *NamespaceNotFoundError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NamespaceNotFoundError.Unmarshal(m, b)
+}
+func (m *NamespaceNotFoundError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NamespaceNotFoundError.Marshal(b, m, deterministic)
+}
+func (dst *NamespaceNotFoundError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceNotFoundError.Merge(dst, src)
+}
+func (m *NamespaceNotFoundError) XXX_Size() int {
+	return xxx_messageInfo_NamespaceNotFoundError.Size(m)
+}
+func (m *NamespaceNotFoundError) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceNotFoundError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamespaceNotFoundError proto.InternalMessageInfo
+
+func (m *NamespaceNotFoundError) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *NamespaceNotFoundError) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+type DataIntegrityError struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Bucket               string   `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	DocumentId           string   `protobuf:"bytes,3,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Description          string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataIntegrityError) Reset()         { *m = DataIntegrityError{} }
+func (m *DataIntegrityError) String() string { return proto.CompactTextString(m) }
+func (*DataIntegrityError) ProtoMessage()    {}
+func (*DataIntegrityError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{29}
+}
+func (m *DataIntegrityError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataIntegrityError.Unmarshal(m, b)
+}
+func (m *DataIntegrityError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataIntegrityError.Marshal(b, m, deterministic)
+}
+func (dst *DataIntegrityError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataIntegrityError.Merge(dst, src)
+}
+func (m *DataIntegrityError) XXX_Size() int {
+	return xxx_messageInfo_DataIntegrityError.Size(m)
+}
+func (m *DataIntegrityError) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataIntegrityError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataIntegrityError proto.InternalMessageInfo
+
+func (m *DataIntegrityError) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *DataIntegrityError) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *DataIntegrityError) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+func (m *DataIntegrityError) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+type InvalidDocumentError struct {
+	DocumentId           string   `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InvalidDocumentError) Reset()         { *m = InvalidDocumentError{} }
+func (m *InvalidDocumentError) String() string { return proto.CompactTextString(m) }
+func (*InvalidDocumentError) ProtoMessage()    {}
+func (*InvalidDocumentError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{30}
+}
+func (m *InvalidDocumentError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvalidDocumentError.Unmarshal(m, b)
+}
+func (m *InvalidDocumentError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvalidDocumentError.Marshal(b, m, deterministic)
+}
+func (dst *InvalidDocumentError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvalidDocumentError.Merge(dst, src)
+}
+func (m *InvalidDocumentError) XXX_Size() int {
+	return xxx_messageInfo_InvalidDocumentError.Size(m)
+}
+func (m *InvalidDocumentError) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvalidDocumentError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvalidDocumentError proto.InternalMessageInfo
+
+func (m *InvalidDocumentError) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+func (m *InvalidDocumentError) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ValidationError struct {
+	DocumentId           string   `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Violations           []string `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidationError) Reset()         { *m = ValidationError{} }
+func (m *ValidationError) String() string { return proto.CompactTextString(m) }
+func (*ValidationError) ProtoMessage()    {}
+func (*ValidationError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{31}
+}
+func (m *ValidationError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidationError.Unmarshal(m, b)
+}
+func (m *ValidationError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidationError.Marshal(b, m, deterministic)
+}
+func (dst *ValidationError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidationError.Merge(dst, src)
+}
+func (m *ValidationError) XXX_Size() int {
+	return xxx_messageInfo_ValidationError.Size(m)
+}
+func (m *ValidationError) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidationError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidationError proto.InternalMessageInfo
+
+func (m *ValidationError) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+func (m *ValidationError) GetViolations() []string {
+	if m != nil {
+		return m.Violations
+	}
+	return nil
+}
+
+type StaleSequenceError struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	GivenSeq             uint64   `protobuf:"varint,2,opt,name=given_seq,json=givenSeq,proto3" json:"given_seq,omitempty"`
+	CurrentSeq           uint64   `protobuf:"varint,3,opt,name=current_seq,json=currentSeq,proto3" json:"current_seq,omitempty"`
+	ResetSeq             uint64   `protobuf:"varint,4,opt,name=reset_seq,json=resetSeq,proto3" json:"reset_seq,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StaleSequenceError) Reset()         { *m = StaleSequenceError{} }
+func (m *StaleSequenceError) String() string { return proto.CompactTextString(m) }
+func (*StaleSequenceError) ProtoMessage()    {}
+func (*StaleSequenceError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{32}
+}
+func (m *StaleSequenceError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StaleSequenceError.Unmarshal(m, b)
+}
+func (m *StaleSequenceError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StaleSequenceError.Marshal(b, m, deterministic)
+}
+func (dst *StaleSequenceError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StaleSequenceError.Merge(dst, src)
+}
+func (m *StaleSequenceError) XXX_Size() int {
+	return xxx_messageInfo_StaleSequenceError.Size(m)
+}
+func (m *StaleSequenceError) XXX_DiscardUnknown() {
+	xxx_messageInfo_StaleSequenceError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StaleSequenceError proto.InternalMessageInfo
+
+func (m *StaleSequenceError) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StaleSequenceError) GetGivenSeq() uint64 {
+	if m != nil {
+		return m.GivenSeq
+	}
+	return 0
+}
+
+func (m *StaleSequenceError) GetCurrentSeq() uint64 {
+	if m != nil {
+		return m.CurrentSeq
+	}
+	return 0
+}
+
+func (m *StaleSequenceError) GetResetSeq() uint64 {
+	if m != nil {
+		return m.ResetSeq
+	}
+	return 0
+}
+
+// Document is the wire representation of a document.
+type Document struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Keys                 map[string]uint32    `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Headers              map[string]string    `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Content              *any.Any             `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Revision             uint64               `protobuf:"varint,5,opt,name=revision,proto3" json:"revision,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DerivedKeys          map[string]uint32    `protobuf:"bytes,8,rep,name=derived_keys,json=derivedKeys,proto3" json:"derived_keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Document) Reset()         { *m = Document{} }
+func (m *Document) String() string { return proto.CompactTextString(m) }
+func (*Document) ProtoMessage()    {}
+func (*Document) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{33}
+}
+func (m *Document) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Document.Unmarshal(m, b)
+}
+func (m *Document) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Document.Marshal(b, m, deterministic)
+}
+func (dst *Document) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Document.Merge(dst, src)
+}
+func (m *Document) XXX_Size() int {
+	return xxx_messageInfo_Document.Size(m)
+}
+func (m *Document) XXX_DiscardUnknown() {
+	xxx_messageInfo_Document.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Document proto.InternalMessageInfo
+
+func (m *Document) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Document) GetKeys() map[string]uint32 {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *Document) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *Document) GetContent() *any.Any {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *Document) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *Document) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Document) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *Document) GetDerivedKeys() map[string]uint32 {
+	if m != nil {
+		return m.DerivedKeys
+	}
+	return nil
+}
+
+// Change is the wire representation of a change to a document.
+type Change struct {
+	Sequence   uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	DocumentId string `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// document is absent if the document was deleted.
+	Document             *Document `protobuf:"bytes,3,opt,name=document,proto3" json:"document,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Change) Reset()         { *m = Change{} }
+func (m *Change) String() string { return proto.CompactTextString(m) }
+func (*Change) ProtoMessage()    {}
+func (*Change) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{34}
+}
+func (m *Change) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Change.Unmarshal(m, b)
+}
+func (m *Change) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Change.Marshal(b, m, deterministic)
+}
+func (dst *Change) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Change.Merge(dst, src)
+}
+func (m *Change) XXX_Size() int {
+	return xxx_messageInfo_Change.Size(m)
+}
+func (m *Change) XXX_DiscardUnknown() {
+	xxx_messageInfo_Change.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Change proto.InternalMessageInfo
+
+func (m *Change) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Change) GetDocumentId() string {
+	if m != nil {
+		return m.DocumentId
+	}
+	return ""
+}
+
+func (m *Change) GetDocument() *Document {
+	if m != nil {
+		return m.Document
+	}
+	return nil
+}
+
+type Attachment struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Attachment) Reset()         { *m = Attachment{} }
+func (m *Attachment) String() string { return proto.CompactTextString(m) }
+func (*Attachment) ProtoMessage()    {}
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{35}
+}
+func (m *Attachment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Attachment.Unmarshal(m, b)
+}
+func (m *Attachment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Attachment.Marshal(b, m, deterministic)
+}
+func (dst *Attachment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attachment.Merge(dst, src)
+}
+func (m *Attachment) XXX_Size() int {
+	return xxx_messageInfo_Attachment.Size(m)
+}
+func (m *Attachment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attachment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attachment proto.InternalMessageInfo
+
+func (m *Attachment) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Attachment) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type Key struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 uint32   `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Count                int64    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Key) Reset()         { *m = Key{} }
+func (m *Key) String() string { return proto.CompactTextString(m) }
+func (*Key) ProtoMessage()    {}
+func (*Key) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{36}
+}
+func (m *Key) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Key.Unmarshal(m, b)
+}
+func (m *Key) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Key.Marshal(b, m, deterministic)
+}
+func (dst *Key) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Key.Merge(dst, src)
+}
+func (m *Key) XXX_Size() int {
+	return xxx_messageInfo_Key.Size(m)
+}
+func (m *Key) XXX_DiscardUnknown() {
+	xxx_messageInfo_Key.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Key proto.InternalMessageInfo
+
+func (m *Key) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Key) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *Key) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type QueryPlan struct {
+	Filter               *Filter  `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Strategy             string   `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Cost                 int64    `protobuf:"varint,3,opt,name=cost,proto3" json:"cost,omitempty"`
+	IsFullScan           bool     `protobuf:"varint,4,opt,name=is_full_scan,json=isFullScan,proto3" json:"is_full_scan,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryPlan) Reset()         { *m = QueryPlan{} }
+func (m *QueryPlan) String() string { return proto.CompactTextString(m) }
+func (*QueryPlan) ProtoMessage()    {}
+func (*QueryPlan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{37}
+}
+func (m *QueryPlan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryPlan.Unmarshal(m, b)
+}
+func (m *QueryPlan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryPlan.Marshal(b, m, deterministic)
+}
+func (dst *QueryPlan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryPlan.Merge(dst, src)
+}
+func (m *QueryPlan) XXX_Size() int {
+	return xxx_messageInfo_QueryPlan.Size(m)
+}
+func (m *QueryPlan) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryPlan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryPlan proto.InternalMessageInfo
+
+func (m *QueryPlan) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *QueryPlan) GetStrategy() string {
+	if m != nil {
+		return m.Strategy
+	}
+	return ""
+}
+
+func (m *QueryPlan) GetCost() int64 {
+	if m != nil {
+		return m.Cost
+	}
+	return 0
+}
+
+func (m *QueryPlan) GetIsFullScan() bool {
+	if m != nil {
+		return m.IsFullScan
+	}
+	return false
+}
+
+// Filter is the wire representation of a filter. A filter with no conditions
+// does not match any documents.
+type Filter struct {
+	Conditions           []*Condition `protobuf:"bytes,1,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Filter) Reset()         { *m = Filter{} }
+func (m *Filter) String() string { return proto.CompactTextString(m) }
+func (*Filter) ProtoMessage()    {}
+func (*Filter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{38}
+}
+func (m *Filter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Filter.Unmarshal(m, b)
+}
+func (m *Filter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Filter.Marshal(b, m, deterministic)
+}
+func (dst *Filter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Filter.Merge(dst, src)
+}
+func (m *Filter) XXX_Size() int {
+	return xxx_messageInfo_Filter.Size(m)
+}
+func (m *Filter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Filter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Filter proto.InternalMessageInfo
+
+func (m *Filter) GetConditions() []*Condition {
+	if m != nil {
+		return m.Conditions
+	}
+	return nil
+}
+
+type Condition struct {
+	// Types that are valid to be assigned to Condition:
+	//	*Condition_IsOneOf
+	//	*Condition_HasUniqueKeyIn
+	//	*Condition_HasKeys
+	//	*Condition_MatchesText
+	//	*Condition_HasHeader
+	//	*Condition_HeaderEquals
+	//	*Condition_HeaderIn
+	//	*Condition_CreatedBetween
+	//	*Condition_UpdatedBetween
+	//	*Condition_IsContentType
+	//	*Condition_HasKeyWithPrefix
+	Condition            isCondition_Condition `protobuf_oneof:"condition"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *Condition) Reset()         { *m = Condition{} }
+func (m *Condition) String() string { return proto.CompactTextString(m) }
+func (*Condition) ProtoMessage()    {}
+func (*Condition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{39}
+}
+func (m *Condition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Condition.Unmarshal(m, b)
+}
+func (m *Condition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Condition.Marshal(b, m, deterministic)
+}
+func (dst *Condition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Condition.Merge(dst, src)
+}
+func (m *Condition) XXX_Size() int {
+	return xxx_messageInfo_Condition.Size(m)
+}
+func (m *Condition) XXX_DiscardUnknown() {
+	xxx_messageInfo_Condition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Condition proto.InternalMessageInfo
+
+type isCondition_Condition interface {
+	isCondition_Condition()
+}
+
+type Condition_IsOneOf struct {
+	IsOneOf *Strings `protobuf:"bytes,1,opt,name=is_one_of,json=isOneOf,proto3,oneof"`
+}
+
+type Condition_HasUniqueKeyIn struct {
+	HasUniqueKeyIn *Strings `protobuf:"bytes,2,opt,name=has_unique_key_in,json=hasUniqueKeyIn,proto3,oneof"`
+}
+
+type Condition_HasKeys struct {
+	HasKeys *Strings `protobuf:"bytes,3,opt,name=has_keys,json=hasKeys,proto3,oneof"`
+}
+
+type Condition_MatchesText struct {
+	MatchesText *Strings `protobuf:"bytes,4,opt,name=matches_text,json=matchesText,proto3,oneof"`
+}
+
+type Condition_HasHeader struct {
+	HasHeader *Header `protobuf:"bytes,5,opt,name=has_header,json=hasHeader,proto3,oneof"`
+}
+
+type Condition_HeaderEquals struct {
+	HeaderEquals *Header `protobuf:"bytes,6,opt,name=header_equals,json=headerEquals,proto3,oneof"`
+}
+
+type Condition_HeaderIn struct {
+	HeaderIn *Header `protobuf:"bytes,7,opt,name=header_in,json=headerIn,proto3,oneof"`
+}
+
+type Condition_CreatedBetween struct {
+	CreatedBetween *TimeRange `protobuf:"bytes,8,opt,name=created_between,json=createdBetween,proto3,oneof"`
+}
+
+type Condition_UpdatedBetween struct {
+	UpdatedBetween *TimeRange `protobuf:"bytes,9,opt,name=updated_between,json=updatedBetween,proto3,oneof"`
+}
+
+type Condition_IsContentType struct {
+	IsContentType *Strings `protobuf:"bytes,10,opt,name=is_content_type,json=isContentType,proto3,oneof"`
+}
+
+type Condition_HasKeyWithPrefix struct {
+	HasKeyWithPrefix string `protobuf:"bytes,11,opt,name=has_key_with_prefix,json=hasKeyWithPrefix,proto3,oneof"`
+}
+
+func (*Condition_IsOneOf) isCondition_Condition() {}
+
+func (*Condition_HasUniqueKeyIn) isCondition_Condition() {}
+
+func (*Condition_HasKeys) isCondition_Condition() {}
+
+func (*Condition_MatchesText) isCondition_Condition() {}
+
+func (*Condition_HasHeader) isCondition_Condition() {}
+
+func (*Condition_HeaderEquals) isCondition_Condition() {}
+
+func (*Condition_HeaderIn) isCondition_Condition() {}
+
+func (*Condition_CreatedBetween) isCondition_Condition() {}
+
+func (*Condition_UpdatedBetween) isCondition_Condition() {}
+
+func (*Condition_IsContentType) isCondition_Condition() {}
+
+func (*Condition_HasKeyWithPrefix) isCondition_Condition() {}
+
+func (m *Condition) GetCondition() isCondition_Condition {
+	if m != nil {
+		return m.Condition
+	}
+	return nil
+}
+
+func (m *Condition) GetIsOneOf() *Strings {
+	if x, ok := m.GetCondition().(*Condition_IsOneOf); ok {
+		return x.IsOneOf
+	}
+	return nil
+}
+
+func (m *Condition) GetHasUniqueKeyIn() *Strings {
+	if x, ok := m.GetCondition().(*Condition_HasUniqueKeyIn); ok {
+		return x.HasUniqueKeyIn
+	}
+	return nil
+}
+
+func (m *Condition) GetHasKeys() *Strings {
+	if x, ok := m.GetCondition().(*Condition_HasKeys); ok {
+		return x.HasKeys
+	}
+	return nil
+}
+
+func (m *Condition) GetMatchesText() *Strings {
+	if x, ok := m.GetCondition().(*Condition_MatchesText); ok {
+		return x.MatchesText
+	}
+	return nil
+}
+
+func (m *Condition) GetHasHeader() *Header {
+	if x, ok := m.GetCondition().(*Condition_HasHeader); ok {
+		return x.HasHeader
+	}
+	return nil
+}
+
+func (m *Condition) GetHeaderEquals() *Header {
+	if x, ok := m.GetCondition().(*Condition_HeaderEquals); ok {
+		return x.HeaderEquals
+	}
+	return nil
+}
+
+func (m *Condition) GetHeaderIn() *Header {
+	if x, ok := m.GetCondition().(*Condition_HeaderIn); ok {
+		return x.HeaderIn
+	}
+	return nil
+}
+
+func (m *Condition) GetCreatedBetween() *TimeRange {
+	if x, ok := m.GetCondition().(*Condition_CreatedBetween); ok {
+		return x.CreatedBetween
+	}
+	return nil
+}
+
+func (m *Condition) GetUpdatedBetween() *TimeRange {
+	if x, ok := m.GetCondition().(*Condition_UpdatedBetween); ok {
+		return x.UpdatedBetween
+	}
+	return nil
+}
+
+func (m *Condition) GetIsContentType() *Strings {
+	if x, ok := m.GetCondition().(*Condition_IsContentType); ok {
+		return x.IsContentType
+	}
+	return nil
+}
+
+func (m *Condition) GetHasKeyWithPrefix() string {
+	if x, ok := m.GetCondition().(*Condition_HasKeyWithPrefix); ok {
+		return x.HasKeyWithPrefix
+	}
+	return ""
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Condition) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Condition_OneofMarshaler, _Condition_OneofUnmarshaler, _Condition_OneofSizer, []interface{}{
+		(*Condition_IsOneOf)(nil),
+		(*Condition_HasUniqueKeyIn)(nil),
+		(*Condition_HasKeys)(nil),
+		(*Condition_MatchesText)(nil),
+		(*Condition_HasHeader)(nil),
+		(*Condition_HeaderEquals)(nil),
+		(*Condition_HeaderIn)(nil),
+		(*Condition_CreatedBetween)(nil),
+		(*Condition_UpdatedBetween)(nil),
+		(*Condition_IsContentType)(nil),
+		(*Condition_HasKeyWithPrefix)(nil),
+	}
+}
+
+func _Condition_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Condition)
+	// condition
+	switch x := m.Condition.(type) {
+	case *Condition_IsOneOf:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.IsOneOf); err != nil {
+			return err
+		}
+	case *Condition_HasUniqueKeyIn:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.HasUniqueKeyIn); err != nil {
+			return err
+		}
+	case *Condition_HasKeys:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.HasKeys); err != nil {
+			return err
+		}
+	case *Condition_MatchesText:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.MatchesText); err != nil {
+			return err
+		}
+	case *Condition_HasHeader:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.HasHeader); err != nil {
+			return err
+		}
+	case *Condition_HeaderEquals:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.HeaderEquals); err != nil {
+			return err
+		}
+	case *Condition_HeaderIn:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.HeaderIn); err != nil {
+			return err
+		}
+	case *Condition_CreatedBetween:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.CreatedBetween); err != nil {
+			return err
+		}
+	case *Condition_UpdatedBetween:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.UpdatedBetween); err != nil {
+			return err
+		}
+	case *Condition_IsContentType:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.IsContentType); err != nil {
+			return err
+		}
+	case *Condition_HasKeyWithPrefix:
+		b.EncodeVarint(11<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.HasKeyWithPrefix)
+	case nil:
+	default:
+		return fmt.Errorf("Condition.Condition has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Condition_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Condition)
+	switch tag {
+	case 1: // condition.is_one_of
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Strings)
+		err := b.DecodeMessage(msg)
+		m.Condition = &Condition_IsOneOf{msg}
+		return true, err
+	case 2: // condition.has_unique_key_in
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Strings)
+		err := b.DecodeMessage(msg)
+		m.Condition = &Condition_HasUniqueKeyIn{msg}
+		return true, err
+	case 3: // condition.has_keys
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Strings)
+		err := b.DecodeMessage(msg)
+		m.Condition = &Condition_HasKeys{msg}
+		return true, err
+	case 4: // condition.matches_text
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Strings)
+		err := b.DecodeMessage(msg)
+		m.Condition = &Condition_MatchesText{msg}
+		return true, err
+	case 5: // condition.has_header
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Header)
+		err := b.DecodeMessage(msg)
+		m.Condition = &Condition_HasHeader{msg}
+		return true, err
+	case 6: // condition.header_equals
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Header)
+		err := b.DecodeMessage(msg)
+		m.Condition = &Condition_HeaderEquals{msg}
+		return true, err
+	case 7: // condition.header_in
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Header)
+		err := b.DecodeMessage(msg)
+		m.Condition = &Condition_HeaderIn{msg}
+		return true, err
+	case 8: // condition.created_between
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TimeRange)
+		err := b.DecodeMessage(msg)
+		m.Condition = &Condition_CreatedBetween{msg}
+		return true, err
+	case 9: // condition.updated_between
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TimeRange)
+		err := b.DecodeMessage(msg)
+		m.Condition = &Condition_UpdatedBetween{msg}
+		return true, err
+	case 10: // condition.is_content_type
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Strings)
+		err := b.DecodeMessage(msg)
+		m.Condition = &Condition_IsContentType{msg}
+		return true, err
+	case 11: // condition.has_key_with_prefix
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Condition = &Condition_HasKeyWithPrefix{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Condition_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Condition)
+	// condition
+	switch x := m.Condition.(type) {
+	case *Condition_IsOneOf:
+		s := proto.Size(x.IsOneOf)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Condition_HasUniqueKeyIn:
+		s := proto.Size(x.HasUniqueKeyIn)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Condition_HasKeys:
+		s := proto.Size(x.HasKeys)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Condition_MatchesText:
+		s := proto.Size(x.MatchesText)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Condition_HasHeader:
+		s := proto.Size(x.HasHeader)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Condition_HeaderEquals:
+		s := proto.Size(x.HeaderEquals)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Condition_HeaderIn:
+		s := proto.Size(x.HeaderIn)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Condition_CreatedBetween:
+		s := proto.Size(x.CreatedBetween)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Condition_UpdatedBetween:
+		s := proto.Size(x.UpdatedBetween)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Condition_IsContentType:
+		s := proto.Size(x.IsContentType)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Condition_HasKeyWithPrefix:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.HasKeyWithPrefix)))
+		n += len(x.HasKeyWithPrefix)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type Strings struct {
+	Values               []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Strings) Reset()         { *m = Strings{} }
+func (m *Strings) String() string { return proto.CompactTextString(m) }
+func (*Strings) ProtoMessage()    {}
+func (*Strings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{40}
+}
+func (m *Strings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Strings.Unmarshal(m, b)
+}
+func (m *Strings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Strings.Marshal(b, m, deterministic)
+}
+func (dst *Strings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Strings.Merge(dst, src)
+}
+func (m *Strings) XXX_Size() int {
+	return xxx_messageInfo_Strings.Size(m)
+}
+func (m *Strings) XXX_DiscardUnknown() {
+	xxx_messageInfo_Strings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Strings proto.InternalMessageInfo
+
+func (m *Strings) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type Header struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values               []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Header) Reset()         { *m = Header{} }
+func (m *Header) String() string { return proto.CompactTextString(m) }
+func (*Header) ProtoMessage()    {}
+func (*Header) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{41}
+}
+func (m *Header) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Header.Unmarshal(m, b)
+}
+func (m *Header) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Header.Marshal(b, m, deterministic)
+}
+func (dst *Header) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Header.Merge(dst, src)
+}
+func (m *Header) XXX_Size() int {
+	return xxx_messageInfo_Header.Size(m)
+}
+func (m *Header) XXX_DiscardUnknown() {
+	xxx_messageInfo_Header.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Header proto.InternalMessageInfo
+
+func (m *Header) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Header) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// TimeRange is a range of times. An absent bound means the range is unbounded
+// in that direction.
+type TimeRange struct {
+	After                *timestamp.Timestamp `protobuf:"bytes,1,opt,name=after,proto3" json:"after,omitempty"`
+	Before               *timestamp.Timestamp `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TimeRange) Reset()         { *m = TimeRange{} }
+func (m *TimeRange) String() string { return proto.CompactTextString(m) }
+func (*TimeRange) ProtoMessage()    {}
+func (*TimeRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_b15e73da557cb95a, []int{42}
+}
+func (m *TimeRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimeRange.Unmarshal(m, b)
+}
+func (m *TimeRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimeRange.Marshal(b, m, deterministic)
+}
+func (dst *TimeRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeRange.Merge(dst, src)
+}
+func (m *TimeRange) XXX_Size() int {
+	return xxx_messageInfo_TimeRange.Size(m)
+}
+func (m *TimeRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeRange proto.InternalMessageInfo
+
+func (m *TimeRange) GetAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *TimeRange) GetBefore() *timestamp.Timestamp {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Request)(nil), "protavo.grpc.Request")
+	proto.RegisterType((*Response)(nil), "protavo.grpc.Response")
+	proto.RegisterType((*BeginRequest)(nil), "protavo.grpc.BeginRequest")
+	proto.RegisterType((*CommitRequest)(nil), "protavo.grpc.CommitRequest")
+	proto.RegisterType((*SavepointRequest)(nil), "protavo.grpc.SavepointRequest")
+	proto.RegisterType((*RollbackToRequest)(nil), "protavo.grpc.RollbackToRequest")
+	proto.RegisterType((*ReleaseRequest)(nil), "protavo.grpc.ReleaseRequest")
+	proto.RegisterType((*ContinueRequest)(nil), "protavo.grpc.ContinueRequest")
+	proto.RegisterType((*AttachmentChunkRequest)(nil), "protavo.grpc.AttachmentChunkRequest")
+	proto.RegisterType((*FetchRequest)(nil), "protavo.grpc.FetchRequest")
+	proto.RegisterType((*ExplainRequest)(nil), "protavo.grpc.ExplainRequest")
+	proto.RegisterType((*GetAttachmentRequest)(nil), "protavo.grpc.GetAttachmentRequest")
+	proto.RegisterType((*ListAttachmentsRequest)(nil), "protavo.grpc.ListAttachmentsRequest")
+	proto.RegisterType((*ChangesSinceRequest)(nil), "protavo.grpc.ChangesSinceRequest")
+	proto.RegisterType((*ListKeysRequest)(nil), "protavo.grpc.ListKeysRequest")
+	proto.RegisterType((*SaveRequest)(nil), "protavo.grpc.SaveRequest")
+	proto.RegisterMapType((map[string]uint32)(nil), "protavo.grpc.SaveRequest.DerivedKeysEntry")
+	proto.RegisterType((*DeleteRequest)(nil), "protavo.grpc.DeleteRequest")
+	proto.RegisterType((*DeleteWhereRequest)(nil), "protavo.grpc.DeleteWhereRequest")
+	proto.RegisterType((*DeleteNamespaceRequest)(nil), "protavo.grpc.DeleteNamespaceRequest")
+	proto.RegisterType((*PutAttachmentRequest)(nil), "protavo.grpc.PutAttachmentRequest")
+	proto.RegisterType((*DeleteAttachmentRequest)(nil), "protavo.grpc.DeleteAttachmentRequest")
+	proto.RegisterType((*ApplyChangeRequest)(nil), "protavo.grpc.ApplyChangeRequest")
+	proto.RegisterType((*RestoreRequest)(nil), "protavo.grpc.RestoreRequest")
+	proto.RegisterType((*PurgeDeletedRequest)(nil), "protavo.grpc.PurgeDeletedRequest")
+	proto.RegisterType((*Error)(nil), "protavo.grpc.Error")
+	proto.RegisterType((*OptimisticLockError)(nil), "protavo.grpc.OptimisticLockError")
+	proto.RegisterType((*DuplicateKeyError)(nil), "protavo.grpc.DuplicateKeyError")
+	proto.RegisterType((*NotFoundError)(nil), "protavo.grpc.NotFoundError")
+	proto.RegisterType((*NamespaceNotFoundError)(nil), "protavo.grpc.NamespaceNotFoundError")
+	proto.RegisterType((*DataIntegrityError)(nil), "protavo.grpc.DataIntegrityError")
+	proto.RegisterType((*InvalidDocumentError)(nil), "protavo.grpc.InvalidDocumentError")
+	proto.RegisterType((*ValidationError)(nil), "protavo.grpc.ValidationError")
+	proto.RegisterType((*StaleSequenceError)(nil), "protavo.grpc.StaleSequenceError")
+	proto.RegisterType((*Document)(nil), "protavo.grpc.Document")
+	proto.RegisterMapType((map[string]uint32)(nil), "protavo.grpc.Document.DerivedKeysEntry")
+	proto.RegisterMapType((map[string]string)(nil), "protavo.grpc.Document.HeadersEntry")
+	proto.RegisterMapType((map[string]uint32)(nil), "protavo.grpc.Document.KeysEntry")
+	proto.RegisterType((*Change)(nil), "protavo.grpc.Change")
+	proto.RegisterType((*Attachment)(nil), "protavo.grpc.Attachment")
+	proto.RegisterType((*Key)(nil), "protavo.grpc.Key")
+	proto.RegisterType((*QueryPlan)(nil), "protavo.grpc.QueryPlan")
+	proto.RegisterType((*Filter)(nil), "protavo.grpc.Filter")
+	proto.RegisterType((*Condition)(nil), "protavo.grpc.Condition")
+	proto.RegisterType((*Strings)(nil), "protavo.grpc.Strings")
+	proto.RegisterType((*Header)(nil), "protavo.grpc.Header")
+	proto.RegisterType((*TimeRange)(nil), "protavo.grpc.TimeRange")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ProtavoClient is the client API for Protavo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ProtavoClient interface {
+	// Transaction executes a sequence of operations within a single
+	// transaction.
+	//
+	// The first request must be a begin request. Each request, including the
+	// begin request, is answered by exactly one response, with two exceptions:
+	//
+	// Requests that produce many results, such as fetch requests, may be
+	// answered by a sequence of responses. Each response in the sequence other
+	// than the last has its "more" field set, and the client must answer it
+	// with a continue request before the next response is sent.
+	//
+	// A put-attachment request with its "more" field set is followed by
+	// attachment-chunk requests carrying the remainder of the content, up to
+	// and including a chunk without its "more" field set. Only the final
+	// chunk is answered by a response.
+	//
+	// The transaction ends after a commit request, or is rolled back if the
+	// stream ends before a commit request is sent.
+	Transaction(ctx context.Context, opts ...grpc.CallOption) (Protavo_TransactionClient, error)
+}
+
+type protavoClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewProtavoClient(cc *grpc.ClientConn) ProtavoClient {
+	return &protavoClient{cc}
+}
+
+func (c *protavoClient) Transaction(ctx context.Context, opts ...grpc.CallOption) (Protavo_TransactionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Protavo_serviceDesc.Streams[0], "/protavo.grpc.Protavo/Transaction", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &protavoTransactionClient{stream}
+	return x, nil
+}
+
+type Protavo_TransactionClient interface {
+	Send(*Request) error
+	Recv() (*Response, error)
+	grpc.ClientStream
+}
+
+type protavoTransactionClient struct {
+	grpc.ClientStream
+}
+
+func (x *protavoTransactionClient) Send(m *Request) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *protavoTransactionClient) Recv() (*Response, error) {
+	m := new(Response)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ProtavoServer is the server API for Protavo service.
+type ProtavoServer interface {
+	// Transaction executes a sequence of operations within a single
+	// transaction.
+	//
+	// The first request must be a begin request. Each request, including the
+	// begin request, is answered by exactly one response, with two exceptions:
+	//
+	// Requests that produce many results, such as fetch requests, may be
+	// answered by a sequence of responses. Each response in the sequence other
+	// than the last has its "more" field set, and the client must answer it
+	// with a continue request before the next response is sent.
+	//
+	// A put-attachment request with its "more" field set is followed by
+	// attachment-chunk requests carrying the remainder of the content, up to
+	// and including a chunk without its "more" field set. Only the final
+	// chunk is answered by a response.
+	//
+	// The transaction ends after a commit request, or is rolled back if the
+	// stream ends before a commit request is sent.
+	Transaction(Protavo_TransactionServer) error
+}
+
+func RegisterProtavoServer(s *grpc.Server, srv ProtavoServer) {
+	s.RegisterService(&_Protavo_serviceDesc, srv)
+}
+
+func _Protavo_Transaction_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProtavoServer).Transaction(&protavoTransactionServer{stream})
+}
+
+type Protavo_TransactionServer interface {
+	Send(*Response) error
+	Recv() (*Request, error)
+	grpc.ServerStream
+}
+
+type protavoTransactionServer struct {
+	grpc.ServerStream
+}
+
+func (x *protavoTransactionServer) Send(m *Response) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *protavoTransactionServer) Recv() (*Request, error) {
+	m := new(Request)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Protavo_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protavo.grpc.Protavo",
+	HandlerType: (*ProtavoServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Transaction",
+			Handler:       _Protavo_Transaction_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "src/protavogrpc/internal/rpc/rpc.proto",
+}
+
+func init() {
+	proto.RegisterFile("src/protavogrpc/internal/rpc/rpc.proto", fileDescriptor_rpc_b15e73da557cb95a)
+}
+
+var fileDescriptor_rpc_b15e73da557cb95a = []byte{
+	// 2296 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x59, 0xeb, 0x72, 0xdc, 0xb6,
+	0x15, 0xee, 0xde, 0x97, 0x67, 0x57, 0x37, 0x48, 0x96, 0x19, 0xd9, 0x89, 0x65, 0xb6, 0x69, 0x9d,
+	0xa6, 0x23, 0x25, 0x8a, 0xd3, 0x38, 0x4e, 0xec, 0x56, 0x17, 0x3b, 0x56, 0x94, 0xd8, 0x0e, 0xa5,
+	0x36, 0x33, 0xfd, 0x51, 0x0e, 0x44, 0x42, 0xbb, 0x18, 0x51, 0x24, 0x45, 0x60, 0x65, 0x6d, 0x7f,
+	0xf5, 0x7f, 0xa7, 0x33, 0x7d, 0x88, 0x3e, 0x40, 0x9f, 0xa5, 0x7d, 0x87, 0x3e, 0x47, 0x07, 0x17,
+	0x92, 0x20, 0x45, 0x69, 0x63, 0xf7, 0xdf, 0xe2, 0x9c, 0xef, 0x1c, 0xe2, 0x1c, 0x9c, 0x1b, 0xb0,
+	0xf0, 0x4b, 0x96, 0xfa, 0x9b, 0x49, 0x1a, 0x73, 0x7c, 0x11, 0x8f, 0xd2, 0xc4, 0xdf, 0xa4, 0x11,
+	0x27, 0x69, 0x84, 0xc3, 0x4d, 0xb1, 0x48, 0x13, 0x7f, 0x43, 0x30, 0x63, 0x34, 0xd4, 0x98, 0x0d,
+	0x01, 0x5a, 0x7b, 0x6f, 0x14, 0xc7, 0xa3, 0x90, 0x48, 0xc1, 0xf8, 0x78, 0x72, 0xb2, 0x89, 0xa3,
+	0xa9, 0x02, 0xae, 0xdd, 0xab, 0xb2, 0x38, 0x3d, 0x23, 0x8c, 0xe3, 0xb3, 0x44, 0x01, 0x9c, 0xff,
+	0x0c, 0xa0, 0xe7, 0x92, 0xf3, 0x09, 0x61, 0x1c, 0x6d, 0x41, 0xe7, 0x98, 0x8c, 0x68, 0x64, 0x37,
+	0xd6, 0x1b, 0x0f, 0x06, 0x5b, 0x6b, 0x1b, 0xe6, 0x57, 0x36, 0x76, 0x04, 0x4b, 0x43, 0x5f, 0xfc,
+	0xcc, 0x55, 0x50, 0xf4, 0x39, 0x74, 0xfd, 0xf8, 0xec, 0x8c, 0x72, 0xbb, 0x29, 0x85, 0xee, 0x94,
+	0x85, 0x76, 0x25, 0xaf, 0x90, 0xd2, 0x60, 0xf4, 0x14, 0x2c, 0x86, 0x2f, 0x48, 0x12, 0xd3, 0x88,
+	0xdb, 0x2d, 0x29, 0xf9, 0x41, 0x59, 0xf2, 0x30, 0x63, 0x17, 0xc2, 0x85, 0x08, 0xda, 0x81, 0x41,
+	0x1a, 0x87, 0xe1, 0x31, 0xf6, 0x4f, 0x3d, 0x1e, 0xdb, 0x6d, 0xa9, 0xe1, 0x5e, 0x59, 0x83, 0xab,
+	0x01, 0x47, 0x71, 0xa1, 0x02, 0xd2, 0x9c, 0x88, 0x1e, 0x41, 0x2f, 0x25, 0x21, 0xc1, 0x8c, 0xd8,
+	0x1d, 0x29, 0x7f, 0xb7, 0x22, 0xaf, 0x98, 0x85, 0x70, 0x06, 0x47, 0x5f, 0x41, 0xdf, 0x8f, 0x23,
+	0x4e, 0xa3, 0x09, 0xb1, 0xbb, 0x52, 0xf4, 0xfd, 0xaa, 0xd9, 0x8a, 0x5b, 0xc8, 0xe6, 0x02, 0xe8,
+	0x07, 0x58, 0xc4, 0x9c, 0x63, 0x7f, 0x7c, 0x46, 0x22, 0xee, 0xf9, 0xe3, 0x49, 0x74, 0x6a, 0xf7,
+	0xa4, 0x92, 0x5f, 0x94, 0x95, 0x6c, 0xe7, 0xa8, 0x5d, 0x01, 0x2a, 0x74, 0x2d, 0xe0, 0x32, 0x47,
+	0x1c, 0xdc, 0x09, 0xe1, 0xfe, 0xd8, 0x86, 0xba, 0x83, 0x7b, 0x2e, 0x58, 0xc6, 0xc1, 0x49, 0xa8,
+	0xb0, 0x9e, 0x5c, 0x26, 0x21, 0xa6, 0x91, 0x3d, 0xa8, 0xb3, 0xfe, 0x99, 0x62, 0x1a, 0xd6, 0x6b,
+	0x38, 0x3a, 0x80, 0xf9, 0x11, 0xe1, 0x5e, 0xb1, 0x09, 0x7b, 0x28, 0x15, 0x38, 0x65, 0x05, 0xdf,
+	0x10, 0x5e, 0x58, 0x50, 0xa8, 0x99, 0x1b, 0x99, 0x74, 0xe1, 0x8d, 0x90, 0x32, 0x53, 0x1b, 0xb3,
+	0xe7, 0xea, 0xbc, 0xf1, 0x1d, 0x65, 0x86, 0x1c, 0x33, 0xbc, 0x11, 0x96, 0x39, 0xe8, 0x05, 0xcc,
+	0xf9, 0x63, 0x1c, 0x8d, 0x08, 0xf3, 0x18, 0x8d, 0x7c, 0x62, 0xcf, 0x4b, 0x7d, 0xf7, 0x2b, 0x47,
+	0xa4, 0x20, 0x87, 0x02, 0x51, 0x28, 0x1b, 0xfa, 0x06, 0x19, 0x7d, 0x0d, 0x96, 0xdc, 0xdc, 0x29,
+	0x99, 0x32, 0x7b, 0xa1, 0xee, 0xa0, 0xc5, 0xae, 0x0e, 0xc8, 0xd4, 0xd8, 0x4e, 0x3f, 0xd4, 0x24,
+	0xb4, 0x09, 0x6d, 0x11, 0xb0, 0xf6, 0x8a, 0x14, 0x7c, 0xef, 0x6a, 0x78, 0x17, 0x42, 0x12, 0x28,
+	0x72, 0x29, 0x20, 0x21, 0xe1, 0xc4, 0xbe, 0x55, 0x97, 0x4b, 0x7b, 0x92, 0x67, 0xe4, 0x92, 0x02,
+	0xa3, 0x67, 0x30, 0x54, 0xbf, 0xbc, 0x37, 0x63, 0x92, 0x12, 0x7b, 0x55, 0x0a, 0xaf, 0xd7, 0x09,
+	0xff, 0x28, 0x00, 0x85, 0x86, 0x41, 0x50, 0x50, 0xc5, 0x49, 0x68, 0x35, 0x11, 0x3e, 0x23, 0x2c,
+	0xc1, 0x3e, 0xb1, 0x6f, 0xd7, 0x9d, 0x84, 0x52, 0xf5, 0x32, 0x03, 0x19, 0x27, 0x11, 0x94, 0x39,
+	0x22, 0x52, 0x92, 0x49, 0x29, 0x52, 0xec, 0xba, 0x48, 0x79, 0x3d, 0xa9, 0x8f, 0x94, 0xc4, 0xa4,
+	0xa3, 0x23, 0x58, 0xd2, 0xfb, 0x33, 0xf4, 0xbd, 0x27, 0xf5, 0x7d, 0x58, 0xb7, 0xc1, 0x3a, 0x95,
+	0x8b, 0x41, 0x85, 0x25, 0x9c, 0x87, 0x93, 0x24, 0x9c, 0x7a, 0xea, 0xe0, 0xed, 0xb5, 0x3a, 0xe7,
+	0x6d, 0x0b, 0x84, 0x0a, 0x18, 0xc3, 0x79, 0xb8, 0xa0, 0xaa, 0x5a, 0xc2, 0x78, 0x9c, 0x12, 0xfb,
+	0x4e, 0x7d, 0x2d, 0x91, 0xcc, 0x52, 0x2d, 0x91, 0x14, 0x11, 0xad, 0xc9, 0x24, 0x1d, 0x11, 0x4f,
+	0x6d, 0x2d, 0xb0, 0xef, 0xd6, 0x45, 0xeb, 0x6b, 0x01, 0x51, 0x76, 0x05, 0x46, 0xb4, 0x26, 0x06,
+	0x79, 0xc7, 0x12, 0x7b, 0x90, 0x2c, 0xe7, 0x5f, 0x2d, 0xe8, 0xbb, 0x84, 0x25, 0x71, 0xc4, 0x08,
+	0xfa, 0x08, 0x3a, 0x24, 0x4d, 0xe3, 0x54, 0x97, 0xf5, 0xe5, 0x4a, 0x9e, 0x0b, 0x96, 0xab, 0x10,
+	0xe8, 0x21, 0x58, 0x41, 0xec, 0x4f, 0x54, 0x1a, 0x36, 0xd7, 0x5b, 0x0f, 0x06, 0x5b, 0xab, 0x15,
+	0xdf, 0x6a, 0xb6, 0x5b, 0x00, 0xd1, 0xc7, 0xd0, 0x4e, 0x42, 0x1c, 0xe9, 0x3a, 0x7e, 0xbb, 0x2c,
+	0xf0, 0xc3, 0x84, 0xa4, 0xd3, 0xd7, 0x21, 0x8e, 0x5c, 0x09, 0x42, 0xf7, 0x61, 0x98, 0x49, 0x7a,
+	0x34, 0x60, 0x76, 0x7b, 0xbd, 0xf5, 0xc0, 0x72, 0x07, 0x19, 0x6d, 0x3f, 0x60, 0x68, 0x05, 0x3a,
+	0x27, 0xf1, 0x24, 0x0a, 0x64, 0x59, 0xee, 0xbb, 0x6a, 0x81, 0x6c, 0xe8, 0x89, 0x1a, 0x2a, 0x4e,
+	0x5d, 0xd4, 0xdc, 0xa1, 0x9b, 0x2d, 0xd1, 0x63, 0x18, 0x98, 0xe5, 0xa3, 0x27, 0xf7, 0x6d, 0x5f,
+	0x57, 0x4c, 0x5d, 0x13, 0x8c, 0x36, 0xa0, 0xa7, 0x53, 0xde, 0xee, 0x4b, 0xb9, 0x95, 0xba, 0x32,
+	0xe1, 0x66, 0x20, 0x74, 0xd7, 0x6c, 0x5c, 0xd6, 0x7a, 0xe3, 0x41, 0xdb, 0x6c, 0x4b, 0x1f, 0x42,
+	0x5b, 0xd6, 0x0a, 0x90, 0xaa, 0x96, 0xca, 0xaa, 0x0e, 0xc8, 0xd4, 0x95, 0x6c, 0x84, 0xa0, 0x7d,
+	0x26, 0x42, 0x65, 0x20, 0xed, 0x93, 0xbf, 0x9d, 0x1d, 0x18, 0x9a, 0x1d, 0x56, 0x7c, 0xa8, 0xc8,
+	0x43, 0x71, 0x72, 0x96, 0x5b, 0x10, 0x84, 0x8b, 0xde, 0xa4, 0x94, 0x13, 0xd9, 0x75, 0xfb, 0xae,
+	0x5a, 0x38, 0x0b, 0x30, 0x57, 0x6a, 0xb8, 0x0e, 0x82, 0xc5, 0x6a, 0x1f, 0x75, 0x3e, 0x85, 0xa5,
+	0x2b, 0x9d, 0xb1, 0x6c, 0x56, 0xa3, 0x62, 0x96, 0xb3, 0x01, 0xf3, 0xe5, 0x66, 0x38, 0x03, 0xff,
+	0x11, 0x2c, 0x54, 0x3a, 0x20, 0x5a, 0x85, 0xae, 0x8f, 0x23, 0x9f, 0x84, 0x12, 0xdd, 0x77, 0xf5,
+	0xca, 0xf9, 0x33, 0xac, 0xd6, 0xf7, 0x39, 0xf3, 0xbc, 0x1b, 0xe5, 0xf3, 0xce, 0xdc, 0xd7, 0x2c,
+	0xdc, 0x67, 0xe8, 0x6f, 0x95, 0xf4, 0x7f, 0x0d, 0x43, 0xb3, 0xff, 0xa1, 0xdf, 0x40, 0xf7, 0x84,
+	0x86, 0x9c, 0x64, 0xd9, 0x50, 0x39, 0xee, 0xe7, 0x92, 0xe7, 0x6a, 0x8c, 0xf3, 0x14, 0xe6, 0xcb,
+	0x7d, 0xf0, 0x2d, 0xe5, 0x0f, 0x60, 0xa5, 0xae, 0x0d, 0xa2, 0x7b, 0x30, 0x30, 0x92, 0x40, 0x1f,
+	0x2f, 0x14, 0x39, 0x20, 0x4c, 0x14, 0x87, 0x2d, 0x4d, 0xb4, 0x5c, 0xf9, 0xdb, 0xf9, 0x12, 0x56,
+	0xeb, 0x9b, 0xe0, 0x4c, 0x75, 0xce, 0xc7, 0xb0, 0x5c, 0xd3, 0xef, 0x44, 0x14, 0xa9, 0x0e, 0xa9,
+	0x4e, 0x50, 0x2d, 0x9c, 0x27, 0xb0, 0x50, 0x69, 0x6b, 0xc2, 0xbb, 0x49, 0x4a, 0x4e, 0xe8, 0xa5,
+	0xd6, 0xad, 0x57, 0x62, 0x9b, 0x7c, 0x9a, 0xa8, 0x6d, 0xce, 0xb9, 0xf2, 0xb7, 0xf3, 0xdf, 0x06,
+	0x0c, 0x8c, 0xee, 0x86, 0xb6, 0xa0, 0x9f, 0xed, 0x44, 0xfb, 0xec, 0xba, 0x92, 0x92, 0xe3, 0x54,
+	0x05, 0x48, 0xfd, 0x3c, 0xbc, 0xe5, 0x02, 0x7d, 0x2f, 0x1a, 0x5d, 0x4a, 0x2f, 0x48, 0xa0, 0x3a,
+	0x72, 0x4b, 0x66, 0xd9, 0xaf, 0xaf, 0x6d, 0xac, 0x1b, 0x7b, 0x0a, 0x2d, 0x2c, 0x79, 0x16, 0xf1,
+	0x74, 0x2a, 0x1a, 0x5e, 0x4e, 0x59, 0x7b, 0x0a, 0x8b, 0x55, 0x00, 0x5a, 0x84, 0xd6, 0x29, 0x99,
+	0x6a, 0x2b, 0xc5, 0x4f, 0xb1, 0x95, 0x0b, 0x1c, 0x4e, 0x32, 0x1b, 0xd5, 0xe2, 0x71, 0xf3, 0x51,
+	0xc3, 0xd9, 0x85, 0xb9, 0x52, 0x4b, 0x7e, 0x17, 0x4b, 0x9d, 0x1d, 0x40, 0x57, 0x5b, 0xf3, 0x5b,
+	0x46, 0x99, 0x0d, 0xab, 0xf5, 0x3d, 0xd9, 0x99, 0xc2, 0x4a, 0x5d, 0x73, 0x7d, 0xa7, 0xf8, 0x33,
+	0x13, 0xb2, 0x55, 0x9f, 0x90, 0x6d, 0xa3, 0x9e, 0xbd, 0x84, 0xdb, 0xd7, 0xf4, 0xe1, 0x77, 0x8b,
+	0xfe, 0x1d, 0x40, 0x57, 0xdb, 0xb0, 0x70, 0x94, 0x6e, 0xdc, 0xb5, 0x8e, 0xd2, 0x60, 0x8d, 0x71,
+	0x3e, 0x15, 0x75, 0xcc, 0x6c, 0xc4, 0xb3, 0x33, 0x67, 0x1f, 0x96, 0x6b, 0x7a, 0x2f, 0xda, 0x82,
+	0xee, 0x31, 0x39, 0x11, 0x36, 0x67, 0x77, 0x25, 0x75, 0xd1, 0xda, 0xc8, 0x2e, 0x5a, 0x1b, 0x47,
+	0xd9, 0x45, 0xcb, 0xd5, 0x48, 0xe7, 0xaf, 0x1d, 0xe8, 0xc8, 0x6e, 0x2b, 0x3c, 0x79, 0x46, 0x18,
+	0xc3, 0xa3, 0xac, 0xb2, 0x67, 0x4b, 0xf4, 0x2d, 0x2c, 0xc4, 0x09, 0xa7, 0x67, 0x94, 0x71, 0xea,
+	0x7b, 0x61, 0xec, 0x9f, 0xda, 0xcd, 0xba, 0x79, 0xe0, 0x55, 0x0e, 0xfa, 0x2e, 0xf6, 0x4f, 0x55,
+	0x0f, 0x9f, 0x8f, 0x4b, 0x44, 0xb4, 0x07, 0x73, 0xc1, 0x24, 0x09, 0xa9, 0x8f, 0x39, 0x11, 0x09,
+	0x63, 0xb7, 0xea, 0x6e, 0x49, 0x7b, 0x19, 0xe4, 0x80, 0x4c, 0x95, 0x9e, 0x61, 0x60, 0x90, 0xd0,
+	0x23, 0xb0, 0xa2, 0x98, 0x7b, 0xaa, 0x21, 0xb7, 0xeb, 0xe6, 0xd2, 0x97, 0x31, 0x7f, 0x2e, 0xb8,
+	0x4a, 0xba, 0x1f, 0xe9, 0x25, 0x3a, 0x82, 0xe5, 0xbc, 0x61, 0x79, 0x85, 0x8e, 0x4e, 0xdd, 0x4c,
+	0x99, 0x47, 0x6e, 0x59, 0xd9, 0x52, 0x54, 0xa5, 0xa3, 0x6f, 0x60, 0x3e, 0xc0, 0x1c, 0x7b, 0x34,
+	0xe2, 0x64, 0x94, 0x52, 0x3e, 0xb5, 0xbb, 0x75, 0x23, 0xdb, 0x1e, 0xe6, 0x78, 0x3f, 0x83, 0x28,
+	0x65, 0x73, 0x81, 0x49, 0x43, 0xdf, 0xc3, 0x22, 0x8d, 0x2e, 0x70, 0x48, 0x03, 0x2f, 0xcf, 0xda,
+	0x5e, 0xdd, 0x78, 0xba, 0xaf, 0x50, 0x59, 0xf2, 0x2a, 0x65, 0x0b, 0xb4, 0x4c, 0x45, 0x77, 0xc0,
+	0xe2, 0x97, 0x9e, 0x1f, 0xc6, 0x8c, 0x04, 0x76, 0x5f, 0x26, 0x42, 0x9f, 0x5f, 0xee, 0xca, 0x35,
+	0x7a, 0x02, 0x20, 0xd1, 0x98, 0xd3, 0x38, 0xb2, 0xad, 0xba, 0x9b, 0xc4, 0x1f, 0x73, 0xbe, 0xfa,
+	0x80, 0x21, 0x20, 0x6c, 0x66, 0x1c, 0x87, 0xc4, 0x63, 0x22, 0xfc, 0x44, 0xc1, 0x86, 0x3a, 0x9b,
+	0x0f, 0x05, 0xe6, 0x50, 0x43, 0xb4, 0xcd, 0xcc, 0xa4, 0x39, 0xff, 0x68, 0xc0, 0x72, 0x4d, 0xe8,
+	0xcc, 0xce, 0xc8, 0x3b, 0x60, 0x8d, 0xe8, 0x05, 0x89, 0xbc, 0x94, 0x5c, 0xc8, 0x88, 0x6c, 0xbb,
+	0x7d, 0x49, 0x70, 0xc9, 0x05, 0x7a, 0x1f, 0x00, 0xfb, 0x7c, 0x82, 0x43, 0xc9, 0x6d, 0x49, 0xae,
+	0xa5, 0x28, 0x82, 0x7d, 0x17, 0xac, 0x38, 0x21, 0xa9, 0xb2, 0xbd, 0xad, 0x26, 0x99, 0x9c, 0xe0,
+	0xfc, 0xad, 0x01, 0x4b, 0x57, 0x62, 0x70, 0xf6, 0x86, 0x7e, 0x0b, 0xb7, 0xfd, 0x38, 0x3a, 0x09,
+	0xa9, 0xcf, 0x69, 0x34, 0xf2, 0x4c, 0xb0, 0xaa, 0x1a, 0xb7, 0x0c, 0xf6, 0x5e, 0x21, 0xf7, 0x3e,
+	0xc0, 0x24, 0xa2, 0xe7, 0x93, 0x22, 0x23, 0x2c, 0xd7, 0x52, 0x94, 0x03, 0x32, 0x75, 0x5e, 0xc2,
+	0x5c, 0x29, 0x02, 0x67, 0x6f, 0xa4, 0x64, 0x5d, 0xb3, 0x6a, 0xdd, 0x11, 0xac, 0xd6, 0x87, 0xf6,
+	0x8c, 0xf9, 0xee, 0x66, 0xad, 0x7f, 0x6f, 0x00, 0xba, 0x1a, 0xe0, 0x33, 0x54, 0xae, 0x42, 0xf7,
+	0x78, 0xe2, 0x9f, 0x12, 0xae, 0xf5, 0xe9, 0x55, 0xd5, 0xc2, 0xd6, 0x15, 0x0b, 0xd7, 0x61, 0x10,
+	0x10, 0xe6, 0xa7, 0x34, 0x31, 0x4e, 0xd0, 0x24, 0x39, 0xaf, 0x60, 0xa5, 0x2e, 0x49, 0x66, 0x3b,
+	0x6f, 0x15, 0xba, 0x29, 0xc1, 0x2c, 0xb7, 0x51, 0xaf, 0x1c, 0x17, 0x16, 0x2a, 0xf9, 0x30, 0x5b,
+	0xd7, 0x07, 0x00, 0x17, 0x34, 0x0e, 0xa5, 0x88, 0xba, 0xbc, 0x58, 0xae, 0x41, 0x91, 0x4e, 0xbb,
+	0x9a, 0x21, 0x33, 0x9c, 0x96, 0xc7, 0x3d, 0x23, 0xe7, 0xa5, 0xb8, 0x3f, 0x24, 0xe7, 0x62, 0x4b,
+	0xfe, 0x24, 0x4d, 0xc5, 0x8e, 0x04, 0x5b, 0x05, 0x3e, 0x68, 0x92, 0x00, 0xdc, 0x01, 0x2b, 0x25,
+	0x8c, 0x28, 0x76, 0x5b, 0x49, 0x4b, 0xc2, 0x21, 0x39, 0x77, 0xfe, 0xdd, 0x86, 0x7e, 0x5e, 0x3d,
+	0xe6, 0xa1, 0x99, 0x1b, 0xd5, 0xa4, 0x01, 0x7a, 0xa8, 0x2f, 0x12, 0xea, 0x0e, 0xb6, 0x5e, 0x3f,
+	0x46, 0x6c, 0x14, 0x83, 0x8d, 0x44, 0xa3, 0x27, 0xd0, 0x1b, 0x13, 0x1c, 0x90, 0x34, 0x9b, 0x8d,
+	0x7e, 0x7e, 0x8d, 0xe0, 0x0b, 0x85, 0x52, 0xb2, 0x99, 0x8c, 0xbc, 0x0b, 0xe9, 0x06, 0xdf, 0xd6,
+	0xdd, 0xb4, 0xda, 0xd5, 0xb6, 0xa3, 0x69, 0xd1, 0xf6, 0xd7, 0xa0, 0x9f, 0x92, 0x0b, 0xca, 0x44,
+	0x54, 0x74, 0x32, 0xeb, 0xd4, 0x1a, 0x7d, 0x09, 0xe0, 0xa7, 0x04, 0x73, 0x12, 0x78, 0x98, 0xdb,
+	0xdd, 0x99, 0x4d, 0xd2, 0xd2, 0xe8, 0x6d, 0x2e, 0x44, 0x27, 0x49, 0x90, 0x89, 0xf6, 0x66, 0x8b,
+	0x6a, 0xf4, 0x36, 0x47, 0xdf, 0x56, 0x26, 0x44, 0x75, 0xa5, 0xfb, 0xd5, 0x35, 0x5e, 0xb8, 0x79,
+	0x3c, 0xfc, 0x02, 0xac, 0x77, 0x9a, 0x0b, 0xd7, 0x1e, 0xc3, 0xd0, 0xf4, 0xef, 0x2c, 0x59, 0xcb,
+	0x94, 0xfd, 0x7f, 0x67, 0xd2, 0x29, 0x74, 0xf5, 0x8b, 0xc4, 0x1a, 0xf4, 0xf3, 0x6e, 0xa1, 0xc6,
+	0xfb, 0x7c, 0x5d, 0xcd, 0xa5, 0xe6, 0x95, 0x5c, 0x32, 0x27, 0xd9, 0xd6, 0x4f, 0x9c, 0x64, 0x1f,
+	0x02, 0x18, 0xef, 0x2a, 0xd9, 0x08, 0xd7, 0x30, 0x06, 0x48, 0x04, 0x6d, 0x46, 0xff, 0xa2, 0x76,
+	0xdd, 0x72, 0xe5, 0x6f, 0x67, 0x17, 0x5a, 0x62, 0xca, 0xb8, 0x06, 0x5e, 0xbd, 0x5c, 0x08, 0xcb,
+	0xfd, 0x78, 0xa2, 0x77, 0xd5, 0x72, 0xd5, 0x42, 0xf4, 0x10, 0x2b, 0x7f, 0x67, 0x78, 0xbb, 0xe1,
+	0x59, 0xfa, 0x89, 0xa7, 0x98, 0x93, 0xd1, 0x54, 0x3b, 0x22, 0x5f, 0x8b, 0x1d, 0xf8, 0x31, 0xcb,
+	0x3e, 0x26, 0x7f, 0xa3, 0x75, 0x18, 0x52, 0xe6, 0x9d, 0x4c, 0xc2, 0xd0, 0x63, 0x3e, 0x8e, 0xf4,
+	0xcc, 0x0b, 0x94, 0x3d, 0x9f, 0x84, 0xe1, 0xa1, 0x8f, 0x23, 0x67, 0x1b, 0xba, 0xea, 0x1b, 0xe8,
+	0x0b, 0x00, 0x3f, 0x8e, 0x02, 0xaa, 0x4a, 0x52, 0x63, 0xbd, 0x75, 0xf5, 0x79, 0x64, 0x37, 0xe3,
+	0xbb, 0x06, 0xd4, 0xf9, 0x67, 0x07, 0xac, 0x9c, 0x83, 0x3e, 0x03, 0x8b, 0x32, 0x2f, 0x8e, 0x88,
+	0x17, 0x9f, 0x68, 0x9b, 0x6e, 0x55, 0x3b, 0x7f, 0x4a, 0xa3, 0x11, 0x13, 0xef, 0x4a, 0x94, 0xbd,
+	0x8a, 0xc8, 0xab, 0x13, 0xb4, 0x03, 0x4b, 0x63, 0xcc, 0xbc, 0xa2, 0xd9, 0x79, 0x34, 0xb2, 0x9b,
+	0x37, 0x0b, 0xcf, 0x8f, 0x31, 0xfb, 0x43, 0xd6, 0x0b, 0xf7, 0x23, 0x11, 0x06, 0x42, 0x87, 0xbe,
+	0x6c, 0xdd, 0xfc, 0xdd, 0x31, 0x66, 0xf2, 0xd5, 0xf3, 0x31, 0x0c, 0xcf, 0x30, 0xf7, 0xc7, 0x84,
+	0x79, 0x9c, 0x5c, 0x66, 0x95, 0xe4, 0x5a, 0xb9, 0x81, 0x06, 0x1f, 0x91, 0x4b, 0x8e, 0x3e, 0x07,
+	0x10, 0xdf, 0x53, 0xf5, 0xc8, 0xee, 0xd4, 0x9d, 0x9e, 0xca, 0x2c, 0xf1, 0x67, 0xc0, 0x18, 0x33,
+	0xb5, 0x40, 0x5f, 0xc1, 0x9c, 0x12, 0xf1, 0xc8, 0xf9, 0x04, 0x87, 0xcc, 0xee, 0xde, 0x28, 0x39,
+	0x54, 0xe0, 0x67, 0x12, 0x2b, 0x9c, 0xab, 0x85, 0x69, 0x64, 0xf7, 0x6e, 0x14, 0xec, 0x2b, 0xe0,
+	0x7e, 0x84, 0x76, 0x60, 0x21, 0xab, 0x6e, 0xc7, 0x84, 0xbf, 0x21, 0x24, 0x92, 0x23, 0xdf, 0x95,
+	0xd3, 0x15, 0x45, 0xca, 0x15, 0xe9, 0x28, 0x9c, 0xab, 0x25, 0x76, 0x94, 0x80, 0xd0, 0x91, 0x95,
+	0xb9, 0x4c, 0x87, 0x35, 0x53, 0x87, 0x96, 0xc8, 0x74, 0xfc, 0x0e, 0x16, 0x28, 0xf3, 0x74, 0x3d,
+	0xf6, 0x64, 0xb6, 0xc0, 0xcd, 0xfe, 0x9e, 0xa3, 0x6c, 0x57, 0xc1, 0x8f, 0x44, 0x3e, 0x6d, 0xc2,
+	0xb2, 0x3e, 0x61, 0xef, 0x0d, 0xe5, 0x63, 0x4f, 0xdf, 0xf2, 0xc5, 0xc3, 0x94, 0x25, 0xde, 0x4b,
+	0xd5, 0xa9, 0xfe, 0x48, 0xf9, 0xf8, 0xb5, 0xe4, 0xec, 0x0c, 0xc0, 0xca, 0xe3, 0xd4, 0xb9, 0x0f,
+	0x3d, 0xad, 0x59, 0x74, 0x72, 0x59, 0x85, 0x54, 0x98, 0x5b, 0xae, 0x5e, 0x39, 0x0f, 0xa1, 0xab,
+	0x4f, 0xa9, 0x2e, 0xc5, 0x0b, 0xa9, 0x66, 0x49, 0xea, 0x1c, 0xac, 0xdc, 0x6c, 0xf4, 0x09, 0x74,
+	0xf0, 0x49, 0x91, 0xce, 0x37, 0xb5, 0x02, 0x05, 0x34, 0x6e, 0x67, 0xcd, 0x9f, 0x7a, 0x3b, 0xdb,
+	0x3a, 0x80, 0xde, 0x6b, 0xe5, 0x32, 0xf4, 0x7b, 0x18, 0x1c, 0xa5, 0x38, 0x62, 0xd8, 0x97, 0xe9,
+	0x77, 0xab, 0xfa, 0x94, 0x2b, 0xaf, 0x80, 0x6b, 0xab, 0x55, 0xb2, 0x7a, 0x6e, 0x7d, 0xd0, 0xf8,
+	0xa4, 0xb1, 0xd3, 0xf9, 0x53, 0x2b, 0x4d, 0xfc, 0xe3, 0xae, 0xfc, 0xde, 0x67, 0xff, 0x1b, 0x00,
+	0xab, 0x81, 0x8c, 0x63, 0xd7, 0x1b, 0x00, 0x00,
+}
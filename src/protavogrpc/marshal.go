@@ -0,0 +1,541 @@
+package protavogrpc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavo/filter"
+	"github.com/jmalloc/protavo/src/protavogrpc/internal/rpc"
+)
+
+// marshalDocument converts a document from the public API to wire format.
+func marshalDocument(doc *document.Document) (*rpc.Document, error) {
+	d := &rpc.Document{
+		Id:          doc.ID,
+		Keys:        marshalKeys(doc.Keys),
+		Headers:     doc.Headers,
+		Revision:    doc.Revision,
+		DerivedKeys: marshalKeys(doc.DerivedKeys),
+	}
+
+	var err error
+
+	if d.Content, err = ptypes.MarshalAny(doc.Content); err != nil {
+		return nil, err
+	}
+
+	if d.CreatedAt, err = marshalTime(doc.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	d.UpdatedAt, err = marshalTime(doc.UpdatedAt)
+	return d, err
+}
+
+// unmarshalDocument converts a document from wire format to the public API.
+//
+// The content type must be registered with the protocol buffers package in
+// the receiving process.
+func unmarshalDocument(d *rpc.Document) (*document.Document, error) {
+	doc := &document.Document{
+		ID:       d.Id,
+		Keys:     unmarshalKeys(d.Keys),
+		Headers:  d.Headers,
+		Revision: d.Revision,
+	}
+
+	if len(d.DerivedKeys) != 0 {
+		doc.DerivedKeys = unmarshalKeys(d.DerivedKeys)
+	}
+
+	var x ptypes.DynamicAny
+	if err := ptypes.UnmarshalAny(d.Content, &x); err != nil {
+		return nil, err
+	}
+
+	doc.Content = x.Message
+
+	var err error
+
+	if doc.CreatedAt, err = unmarshalTime(d.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	doc.UpdatedAt, err = unmarshalTime(d.UpdatedAt)
+	return doc, err
+}
+
+// marshalKeys converts a key map from the public API to wire format.
+func marshalKeys(keys document.Keys) map[string]uint32 {
+	r := make(map[string]uint32, len(keys))
+
+	for k, t := range keys {
+		r[k] = uint32(t)
+	}
+
+	return r
+}
+
+// unmarshalKeys converts a key map from wire format to the public API.
+func unmarshalKeys(keys map[string]uint32) document.Keys {
+	r := make(document.Keys, len(keys))
+
+	for k, t := range keys {
+		r[k] = document.KeyType(t)
+	}
+
+	return r
+}
+
+// marshalChange converts a change from the public API to wire format.
+func marshalChange(c *driver.Change) (*rpc.Change, error) {
+	m := &rpc.Change{
+		Sequence:   c.Sequence,
+		DocumentId: c.DocumentID,
+	}
+
+	if c.IsDeleted() {
+		return m, nil
+	}
+
+	var err error
+	m.Document, err = marshalDocument(c.Document)
+	return m, err
+}
+
+// unmarshalChange converts a change from wire format to the public API.
+func unmarshalChange(m *rpc.Change) (*driver.Change, error) {
+	c := &driver.Change{
+		Sequence:   m.Sequence,
+		DocumentID: m.DocumentId,
+	}
+
+	if m.Document == nil {
+		return c, nil
+	}
+
+	var err error
+	c.Document, err = unmarshalDocument(m.Document)
+	return c, err
+}
+
+// marshalPlan converts a query plan from the public API to wire format.
+func marshalPlan(p *driver.QueryPlan) (*rpc.QueryPlan, error) {
+	f, err := marshalFilter(p.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpc.QueryPlan{
+		Filter:     f,
+		Strategy:   p.Strategy,
+		Cost:       int64(p.Cost),
+		IsFullScan: p.IsFullScan,
+	}, nil
+}
+
+// unmarshalPlan converts a query plan from wire format to the public API.
+func unmarshalPlan(p *rpc.QueryPlan) (*driver.QueryPlan, error) {
+	f, err := unmarshalFilter(p.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &driver.QueryPlan{
+		Filter:     f,
+		Strategy:   p.Strategy,
+		Cost:       int(p.Cost),
+		IsFullScan: p.IsFullScan,
+	}, nil
+}
+
+// marshalFilter converts a filter from the public API to wire format.
+//
+// A nil filter, which matches every document, is represented by a nil message.
+func marshalFilter(f *filter.Filter) (*rpc.Filter, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	m := &conditionMarshaler{
+		conds: []*rpc.Condition{},
+	}
+
+	for _, c := range f.Conditions {
+		if _, err := c.Accept(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return &rpc.Filter{Conditions: m.conds}, nil
+}
+
+// unmarshalFilter converts a filter from wire format to the public API.
+func unmarshalFilter(m *rpc.Filter) (*filter.Filter, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	conds := make([]filter.Condition, 0, len(m.Conditions))
+
+	for _, c := range m.Conditions {
+		cond, err := unmarshalCondition(c)
+		if err != nil {
+			return nil, err
+		}
+
+		conds = append(conds, cond)
+	}
+
+	return filter.New(conds), nil
+}
+
+// unmarshalCondition converts a filter condition from wire format to the public
+// API.
+func unmarshalCondition(m *rpc.Condition) (filter.Condition, error) {
+	switch c := m.Condition.(type) {
+	case *rpc.Condition_IsOneOf:
+		return &filter.IsOneOf{Values: filter.NewSet(c.IsOneOf.Values...)}, nil
+	case *rpc.Condition_HasUniqueKeyIn:
+		return &filter.HasUniqueKeyIn{Values: filter.NewSet(c.HasUniqueKeyIn.Values...)}, nil
+	case *rpc.Condition_HasKeys:
+		return &filter.HasKeys{Values: filter.NewSet(c.HasKeys.Values...)}, nil
+	case *rpc.Condition_HasKeyWithPrefix:
+		return &filter.HasKeyWithPrefix{Prefix: c.HasKeyWithPrefix}, nil
+	case *rpc.Condition_MatchesText:
+		return &filter.MatchesText{Terms: filter.NewSet(c.MatchesText.Values...)}, nil
+	case *rpc.Condition_HasHeader:
+		return &filter.HasHeader{Name: c.HasHeader.Name}, nil
+	case *rpc.Condition_HeaderEquals:
+		if len(c.HeaderEquals.Values) != 1 {
+			return nil, errors.New("header-equals condition must have exactly one value")
+		}
+
+		return &filter.HeaderEquals{
+			Name:  c.HeaderEquals.Name,
+			Value: c.HeaderEquals.Values[0],
+		}, nil
+	case *rpc.Condition_HeaderIn:
+		return &filter.HeaderIn{
+			Name:   c.HeaderIn.Name,
+			Values: filter.NewSet(c.HeaderIn.Values...),
+		}, nil
+	case *rpc.Condition_CreatedBetween:
+		after, before, err := unmarshalTimeRange(c.CreatedBetween)
+		return &filter.CreatedBetween{After: after, Before: before}, err
+	case *rpc.Condition_UpdatedBetween:
+		after, before, err := unmarshalTimeRange(c.UpdatedBetween)
+		return &filter.UpdatedBetween{After: after, Before: before}, err
+	case *rpc.Condition_IsContentType:
+		return &filter.IsContentType{TypeURLs: filter.NewSet(c.IsContentType.Values...)}, nil
+	default:
+		return nil, errors.New("unrecognized filter condition")
+	}
+}
+
+// conditionMarshaler is a filter.Visitor that converts filter conditions from
+// the public API to wire format.
+type conditionMarshaler struct {
+	conds []*rpc.Condition
+}
+
+func (m *conditionMarshaler) IsOneOf(c *filter.IsOneOf) (bool, error) {
+	m.add(&rpc.Condition{
+		Condition: &rpc.Condition_IsOneOf{IsOneOf: marshalSet(c.Values)},
+	})
+	return true, nil
+}
+
+func (m *conditionMarshaler) HasUniqueKeyIn(c *filter.HasUniqueKeyIn) (bool, error) {
+	m.add(&rpc.Condition{
+		Condition: &rpc.Condition_HasUniqueKeyIn{HasUniqueKeyIn: marshalSet(c.Values)},
+	})
+	return true, nil
+}
+
+func (m *conditionMarshaler) HasKeys(c *filter.HasKeys) (bool, error) {
+	m.add(&rpc.Condition{
+		Condition: &rpc.Condition_HasKeys{HasKeys: marshalSet(c.Values)},
+	})
+	return true, nil
+}
+
+func (m *conditionMarshaler) HasKeyWithPrefix(c *filter.HasKeyWithPrefix) (bool, error) {
+	m.add(&rpc.Condition{
+		Condition: &rpc.Condition_HasKeyWithPrefix{HasKeyWithPrefix: c.Prefix},
+	})
+	return true, nil
+}
+
+func (m *conditionMarshaler) MatchesText(c *filter.MatchesText) (bool, error) {
+	m.add(&rpc.Condition{
+		Condition: &rpc.Condition_MatchesText{MatchesText: marshalSet(c.Terms)},
+	})
+	return true, nil
+}
+
+func (m *conditionMarshaler) HasHeader(c *filter.HasHeader) (bool, error) {
+	m.add(&rpc.Condition{
+		Condition: &rpc.Condition_HasHeader{
+			HasHeader: &rpc.Header{Name: c.Name},
+		},
+	})
+	return true, nil
+}
+
+func (m *conditionMarshaler) HeaderEquals(c *filter.HeaderEquals) (bool, error) {
+	m.add(&rpc.Condition{
+		Condition: &rpc.Condition_HeaderEquals{
+			HeaderEquals: &rpc.Header{Name: c.Name, Values: []string{c.Value}},
+		},
+	})
+	return true, nil
+}
+
+func (m *conditionMarshaler) HeaderIn(c *filter.HeaderIn) (bool, error) {
+	m.add(&rpc.Condition{
+		Condition: &rpc.Condition_HeaderIn{
+			HeaderIn: &rpc.Header{Name: c.Name, Values: marshalSet(c.Values).Values},
+		},
+	})
+	return true, nil
+}
+
+func (m *conditionMarshaler) CreatedBetween(c *filter.CreatedBetween) (bool, error) {
+	r, err := marshalTimeRange(c.After, c.Before)
+	if err != nil {
+		return false, err
+	}
+
+	m.add(&rpc.Condition{
+		Condition: &rpc.Condition_CreatedBetween{CreatedBetween: r},
+	})
+	return true, nil
+}
+
+func (m *conditionMarshaler) UpdatedBetween(c *filter.UpdatedBetween) (bool, error) {
+	r, err := marshalTimeRange(c.After, c.Before)
+	if err != nil {
+		return false, err
+	}
+
+	m.add(&rpc.Condition{
+		Condition: &rpc.Condition_UpdatedBetween{UpdatedBetween: r},
+	})
+	return true, nil
+}
+
+func (m *conditionMarshaler) IsContentType(c *filter.IsContentType) (bool, error) {
+	m.add(&rpc.Condition{
+		Condition: &rpc.Condition_IsContentType{IsContentType: marshalSet(c.TypeURLs)},
+	})
+	return true, nil
+}
+
+// add appends a condition to the marshaled conditions.
+func (m *conditionMarshaler) add(c *rpc.Condition) {
+	m.conds = append(m.conds, c)
+}
+
+// marshalSet converts a set from the public API to wire format.
+func marshalSet(s filter.Set) *rpc.Strings {
+	m := &rpc.Strings{
+		Values: make([]string, 0, len(s)),
+	}
+
+	for v := range s {
+		m.Values = append(m.Values, v)
+	}
+
+	return m
+}
+
+// marshalTimeRange converts a time range from the public API to wire format.
+func marshalTimeRange(after, before time.Time) (*rpc.TimeRange, error) {
+	a, err := marshalTime(after)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := marshalTime(before)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpc.TimeRange{After: a, Before: b}, nil
+}
+
+// unmarshalTimeRange converts a time range from wire format to the public API.
+func unmarshalTimeRange(r *rpc.TimeRange) (after, before time.Time, err error) {
+	if after, err = unmarshalTime(r.After); err != nil {
+		return
+	}
+
+	before, err = unmarshalTime(r.Before)
+	return
+}
+
+// marshalTime converts a time from the public API to wire format. The zero time
+// is represented by a nil message.
+func marshalTime(t time.Time) (*timestamp.Timestamp, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+
+	return ptypes.TimestampProto(t)
+}
+
+// unmarshalTime converts a time from wire format to the public API.
+func unmarshalTime(t *timestamp.Timestamp) (time.Time, error) {
+	if t == nil {
+		return time.Time{}, nil
+	}
+
+	return ptypes.Timestamp(t)
+}
+
+// marshalError converts an error to wire format.
+func marshalError(err error) *rpc.Error {
+	if err == nil {
+		return nil
+	}
+
+	m := &rpc.Error{
+		Message: err.Error(),
+	}
+
+	switch e := err.(type) {
+	case *protavo.OptimisticLockError:
+		m.OptimisticLock = &rpc.OptimisticLockError{
+			DocumentId: e.DocumentID,
+			GivenRev:   e.GivenRev,
+			ActualRev:  e.ActualRev,
+			Operation:  e.Operation,
+		}
+	case *protavo.DuplicateKeyError:
+		m.DuplicateKey = &rpc.DuplicateKeyError{
+			DocumentId:            e.DocumentID,
+			ConflictingDocumentId: e.ConflictingDocumentID,
+			UniqueKey:             e.UniqueKey,
+		}
+	case *protavo.NotFoundError:
+		m.NotFound = &rpc.NotFoundError{
+			DocumentId: e.DocumentID,
+			Operation:  e.Operation,
+		}
+	case *protavo.NamespaceNotFoundError:
+		m.NamespaceNotFound = &rpc.NamespaceNotFoundError{
+			Namespace: e.Namespace,
+			Operation: e.Operation,
+		}
+	case *protavo.DataIntegrityError:
+		m.DataIntegrity = &rpc.DataIntegrityError{
+			Namespace:   e.Namespace,
+			Bucket:      e.Bucket,
+			DocumentId:  e.DocumentID,
+			Description: e.Description,
+		}
+	case *protavo.InvalidDocumentError:
+		m.InvalidDocument = &rpc.InvalidDocumentError{
+			DocumentId: e.DocumentID,
+			Reason:     e.Reason,
+		}
+	case *document.ValidationError:
+		m.Validation = &rpc.ValidationError{
+			DocumentId: e.DocumentID,
+			Violations: e.Violations,
+		}
+	case *protavo.StaleSequenceError:
+		m.StaleSequence = &rpc.StaleSequenceError{
+			Namespace:  e.Namespace,
+			GivenSeq:   e.GivenSeq,
+			CurrentSeq: e.CurrentSeq,
+			ResetSeq:   e.ResetSeq,
+		}
+	default:
+		m.TxClosed = err == protavo.ErrTxClosed
+	}
+
+	return m
+}
+
+// unmarshalError converts an error from wire format.
+func unmarshalError(m *rpc.Error) error {
+	if m == nil {
+		return nil
+	}
+
+	if e := m.OptimisticLock; e != nil {
+		return &protavo.OptimisticLockError{
+			DocumentID: e.DocumentId,
+			GivenRev:   e.GivenRev,
+			ActualRev:  e.ActualRev,
+			Operation:  e.Operation,
+		}
+	}
+
+	if e := m.DuplicateKey; e != nil {
+		return &protavo.DuplicateKeyError{
+			DocumentID:            e.DocumentId,
+			ConflictingDocumentID: e.ConflictingDocumentId,
+			UniqueKey:             e.UniqueKey,
+		}
+	}
+
+	if e := m.NotFound; e != nil {
+		return &protavo.NotFoundError{
+			DocumentID: e.DocumentId,
+			Operation:  e.Operation,
+		}
+	}
+
+	if e := m.NamespaceNotFound; e != nil {
+		return &protavo.NamespaceNotFoundError{
+			Namespace: e.Namespace,
+			Operation: e.Operation,
+		}
+	}
+
+	if e := m.DataIntegrity; e != nil {
+		return &protavo.DataIntegrityError{
+			Namespace:   e.Namespace,
+			Bucket:      e.Bucket,
+			DocumentID:  e.DocumentId,
+			Description: e.Description,
+		}
+	}
+
+	if e := m.InvalidDocument; e != nil {
+		return &protavo.InvalidDocumentError{
+			DocumentID: e.DocumentId,
+			Reason:     e.Reason,
+		}
+	}
+
+	if e := m.Validation; e != nil {
+		return &document.ValidationError{
+			DocumentID: e.DocumentId,
+			Violations: e.Violations,
+		}
+	}
+
+	if e := m.StaleSequence; e != nil {
+		return &protavo.StaleSequenceError{
+			Namespace:  e.Namespace,
+			GivenSeq:   e.GivenSeq,
+			CurrentSeq: e.CurrentSeq,
+			ResetSeq:   e.ResetSeq,
+		}
+	}
+
+	if m.TxClosed {
+		return protavo.ErrTxClosed
+	}
+
+	return errors.New(m.Message)
+}
@@ -0,0 +1,176 @@
+package protavogrpc
+
+import (
+	"errors"
+	"io"
+
+	"github.com/jmalloc/protavo/src/protavogrpc/internal/rpc"
+)
+
+const (
+	// pageSize is the approximate maximum size, in bytes, of the results
+	// carried by a single response in a sequence of responses. A response
+	// always carries at least one result, regardless of its size.
+	pageSize = 1 << 20
+
+	// chunkSize is the maximum size, in bytes, of the attachment content
+	// carried by a single request or response.
+	chunkSize = 64 << 10
+)
+
+// errCanceled is returned by the server when the client cancels the remainder
+// of a request's results, or of an attachment's content.
+var errCanceled = errors.New("the request was canceled by the client")
+
+// pager sends the results of a request to the client as a sequence of
+// responses.
+type pager struct {
+	stream rpc.Protavo_TransactionServer
+
+	// res is the response that is currently being populated. The same value
+	// is reused for each response in the sequence.
+	res  *rpc.Response
+	size int
+}
+
+// reserve makes room in the current response for a result of n bytes,
+// sending the current response first if the result would not fit.
+//
+// It returns false if the client cancels the remaining results.
+func (p *pager) reserve(n int) (bool, error) {
+	if p.size != 0 && p.size+n > pageSize {
+		if ok, err := p.flush(); !ok || err != nil {
+			return false, err
+		}
+	}
+
+	p.size += n
+
+	return true, nil
+}
+
+// flush sends the current response with its "more" field set, and waits for
+// the client to request the next.
+//
+// It returns false if the client cancels the remaining results.
+func (p *pager) flush() (bool, error) {
+	p.res.More = true
+
+	if err := p.stream.Send(p.res); err != nil {
+		return false, err
+	}
+
+	*p.res = rpc.Response{}
+	p.size = 0
+
+	req, err := p.stream.Recv()
+	if err != nil {
+		return false, err
+	}
+
+	c := req.GetContinue()
+	if c == nil {
+		return false, errors.New("expected a continue request")
+	}
+
+	return !c.Cancel, nil
+}
+
+// chunkWriter is an io.Writer that sends attachment content to the client in
+// chunks.
+type chunkWriter struct {
+	pager *pager
+}
+
+func (w *chunkWriter) Write(b []byte) (int, error) {
+	n := 0
+
+	for len(b) != 0 {
+		room := chunkSize - len(w.pager.res.Content)
+
+		if room == 0 {
+			ok, err := w.pager.flush()
+			if err != nil {
+				return n, err
+			} else if !ok {
+				return n, errCanceled
+			}
+
+			continue
+		}
+
+		if room > len(b) {
+			room = len(b)
+		}
+
+		w.pager.res.Content = append(w.pager.res.Content, b[:room]...)
+		b = b[room:]
+		n += room
+	}
+
+	return n, nil
+}
+
+// chunkReader is an io.Reader that reads attachment content that is sent by
+// the client in chunks.
+type chunkReader struct {
+	stream  rpc.Protavo_TransactionServer
+	content []byte
+	more    bool
+}
+
+func (r *chunkReader) Read(b []byte) (int, error) {
+	for len(r.content) == 0 {
+		if !r.more {
+			return 0, io.EOF
+		}
+
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(b, r.content)
+	r.content = r.content[n:]
+
+	return n, nil
+}
+
+// drain discards the chunks that have not yet been read, such that the next
+// request can be read from the stream.
+func (r *chunkReader) drain() error {
+	r.content = nil
+
+	for r.more {
+		if err := r.next(); err != nil && err != errCanceled {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// next receives the next chunk from the client.
+func (r *chunkReader) next() error {
+	// no further chunks are expected if this one can not be received
+	r.more = false
+
+	req, err := r.stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	c := req.GetAttachmentChunk()
+	if c == nil {
+		return errors.New("expected an attachment-chunk request")
+	}
+
+	if c.Cancel {
+		return errCanceled
+	}
+
+	r.content = c.Content
+	r.more = c.More
+
+	return nil
+}
@@ -0,0 +1,4 @@
+// Package protavogrpc provides a gRPC service that exposes a Protavo driver to
+// remote clients, and a Protavo driver that uses that service, such that
+// multiple processes and hosts can share a single store.
+package protavogrpc
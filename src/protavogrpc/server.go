@@ -0,0 +1,492 @@
+package protavogrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavogrpc/internal/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Register registers a gRPC service that exposes d to remote clients with s.
+//
+// Clients use Driver to access the service. Each client transaction is
+// executed within a transaction of d, which is held open for the duration of
+// the client's transaction.
+func Register(s *grpc.Server, d driver.Driver) {
+	rpc.RegisterProtavoServer(s, &server{d})
+}
+
+// server is the implementation of rpc.ProtavoServer.
+type server struct {
+	driver driver.Driver
+}
+
+// Transaction executes a sequence of operations within a single transaction.
+func (s *server) Transaction(stream rpc.Protavo_TransactionServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	begin := req.GetBegin()
+	if begin == nil {
+		return status.Error(
+			codes.InvalidArgument,
+			"the first request in a transaction must be a begin request",
+		)
+	}
+
+	ctx := stream.Context()
+
+	var (
+		rtx driver.ReadTx
+		wtx driver.WriteTx
+	)
+
+	if begin.Write {
+		wtx, err = s.driver.BeginWrite(ctx, begin.Namespace)
+		rtx = wtx
+	} else {
+		rtx, err = s.driver.BeginRead(ctx, begin.Namespace)
+	}
+
+	if err := stream.Send(&rpc.Response{Error: marshalError(err)}); err != nil || rtx == nil {
+		return err
+	}
+
+	// the transaction is rolled back if the stream ends without a commit, or
+	// is a no-op if it has already been committed
+	defer rtx.Close()
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		res := &rpc.Response{}
+		pager := &pager{stream: stream, res: res}
+		commit := false
+
+		if c := req.GetCommit(); c != nil {
+			commit = true
+
+			if wtx == nil {
+				err = errors.New("can not commit a read-only transaction")
+			} else {
+				err = wtx.Commit()
+			}
+		} else {
+			err = execute(ctx, rtx, wtx, req, pager)
+		}
+
+		res.Error = marshalError(err)
+
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+
+		if commit {
+			return nil
+		}
+	}
+}
+
+// execute performs the operation described by req within a transaction,
+// populating p's response with the result.
+//
+// wtx is nil if the transaction is read-only.
+func execute(
+	ctx context.Context,
+	rtx driver.ReadTx,
+	wtx driver.WriteTx,
+	req *rpc.Request,
+	p *pager,
+) error {
+	res := p.res
+
+	switch r := req.Request.(type) {
+	case *rpc.Request_Fetch:
+		return executeFetch(ctx, rtx, r.Fetch, p)
+	case *rpc.Request_Explain:
+		return executeExplain(ctx, rtx, r.Explain, res)
+	case *rpc.Request_GetAttachment:
+		return executeGetAttachment(ctx, rtx, r.GetAttachment, p)
+	case *rpc.Request_ListAttachments:
+		return executeListAttachments(ctx, rtx, r.ListAttachments, res)
+	case *rpc.Request_ChangesSince:
+		return executeChangesSince(ctx, rtx, r.ChangesSince, p)
+	case *rpc.Request_ListKeys:
+		return executeListKeys(ctx, rtx, r.ListKeys, res)
+	}
+
+	if wtx == nil {
+		return errors.New("the operation requires a write transaction")
+	}
+
+	switch r := req.Request.(type) {
+	case *rpc.Request_Savepoint:
+		sp, err := wtx.Savepoint(ctx)
+		res.Savepoint = uint64(sp)
+		return err
+	case *rpc.Request_RollbackTo:
+		return wtx.RollbackTo(ctx, driver.Savepoint(r.RollbackTo.Savepoint))
+	case *rpc.Request_Release:
+		return wtx.Release(ctx, driver.Savepoint(r.Release.Savepoint))
+	case *rpc.Request_Save:
+		return executeSave(ctx, wtx, r.Save, res)
+	case *rpc.Request_Delete:
+		return executeDelete(ctx, wtx, r.Delete)
+	case *rpc.Request_DeleteWhere:
+		return executeDeleteWhere(ctx, wtx, r.DeleteWhere, res)
+	case *rpc.Request_DeleteNamespace:
+		return executeWrite(ctx, wtx, &driver.DeleteNamespace{})
+	case *rpc.Request_PutAttachment:
+		return executePutAttachment(ctx, wtx, r.PutAttachment, p.stream)
+	case *rpc.Request_DeleteAttachment:
+		return executeWrite(ctx, wtx, &driver.DeleteAttachment{
+			DocumentID: r.DeleteAttachment.DocumentId,
+			Name:       r.DeleteAttachment.Name,
+		})
+	case *rpc.Request_ApplyChange:
+		c, err := unmarshalChange(r.ApplyChange.Change)
+		if err != nil {
+			return err
+		}
+
+		return executeWrite(ctx, wtx, &driver.ApplyChange{Change: c})
+	case *rpc.Request_Restore:
+		return executeRestore(ctx, wtx, r.Restore, res)
+	case *rpc.Request_PurgeDeleted:
+		return executePurgeDeleted(ctx, wtx, r.PurgeDeleted, res)
+	default:
+		return errors.New("unrecognized request")
+	}
+}
+
+// executeRead executes a read-only operation and returns its error.
+func executeRead(ctx context.Context, tx driver.ReadTx, op driver.ReadOnlyOperation) error {
+	op.ExecuteInReadTx(ctx, tx)
+	return op.Err()
+}
+
+// executeWrite executes an operation and returns its error.
+func executeWrite(ctx context.Context, tx driver.WriteTx, op driver.Operation) error {
+	op.ExecuteInWriteTx(ctx, tx)
+	return op.Err()
+}
+
+// executeFetch executes a fetch request.
+func executeFetch(
+	ctx context.Context,
+	tx driver.ReadTx,
+	req *rpc.FetchRequest,
+	p *pager,
+) error {
+	f, err := unmarshalFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+
+	return executeRead(ctx, tx, &driver.Fetch{
+		Filter: f,
+		Each: func(doc *document.Document) (bool, error) {
+			d, err := marshalDocument(doc)
+			if err != nil {
+				return false, err
+			}
+
+			if ok, err := p.reserve(proto.Size(d)); !ok || err != nil {
+				return false, err
+			}
+
+			p.res.Documents = append(p.res.Documents, d)
+			return true, nil
+		},
+	})
+}
+
+// executeExplain executes an explain request.
+func executeExplain(
+	ctx context.Context,
+	tx driver.ReadTx,
+	req *rpc.ExplainRequest,
+	res *rpc.Response,
+) error {
+	f, err := unmarshalFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+
+	op := &driver.Explain{Filter: f}
+	if err := executeRead(ctx, tx, op); err != nil {
+		return err
+	}
+
+	res.Plan, err = marshalPlan(op.Plan)
+	return err
+}
+
+// executeGetAttachment executes a get-attachment request.
+func executeGetAttachment(
+	ctx context.Context,
+	tx driver.ReadTx,
+	req *rpc.GetAttachmentRequest,
+	p *pager,
+) error {
+	op := &driver.GetAttachment{
+		DocumentID: req.DocumentId,
+		Name:       req.Name,
+		Content:    &chunkWriter{p},
+	}
+
+	if err := executeRead(ctx, tx, op); err != nil {
+		if err == errCanceled {
+			return nil
+		}
+
+		return err
+	}
+
+	p.res.Found = op.Found
+
+	return nil
+}
+
+// executePutAttachment executes a put-attachment request, reading any
+// remaining content from the attachment-chunk requests that follow it.
+func executePutAttachment(
+	ctx context.Context,
+	tx driver.WriteTx,
+	req *rpc.PutAttachmentRequest,
+	stream rpc.Protavo_TransactionServer,
+) error {
+	r := &chunkReader{
+		stream:  stream,
+		content: req.Content,
+		more:    req.More,
+	}
+
+	err := executeWrite(ctx, tx, &driver.PutAttachment{
+		DocumentID: req.DocumentId,
+		Name:       req.Name,
+		Content:    r,
+	})
+
+	// the operation may fail before all of the content is read, in which case
+	// the remaining chunks must still be received before the response is sent
+	if derr := r.drain(); derr != nil {
+		return derr
+	}
+
+	return err
+}
+
+// executeListAttachments executes a list-attachments request.
+func executeListAttachments(
+	ctx context.Context,
+	tx driver.ReadTx,
+	req *rpc.ListAttachmentsRequest,
+	res *rpc.Response,
+) error {
+	op := &driver.ListAttachments{
+		DocumentID: req.DocumentId,
+	}
+
+	if err := executeRead(ctx, tx, op); err != nil {
+		return err
+	}
+
+	for _, a := range op.Attachments {
+		res.Attachments = append(res.Attachments, &rpc.Attachment{
+			Name: a.Name,
+			Size: a.Size,
+		})
+	}
+
+	return nil
+}
+
+// executeChangesSince executes a changes-since request.
+func executeChangesSince(
+	ctx context.Context,
+	tx driver.ReadTx,
+	req *rpc.ChangesSinceRequest,
+	p *pager,
+) error {
+	return executeRead(ctx, tx, &driver.ChangesSince{
+		Since: req.Since,
+		Each: func(c *driver.Change) (bool, error) {
+			m, err := marshalChange(c)
+			if err != nil {
+				return false, err
+			}
+
+			if ok, err := p.reserve(proto.Size(m)); !ok || err != nil {
+				return false, err
+			}
+
+			p.res.Changes = append(p.res.Changes, m)
+			return true, nil
+		},
+	})
+}
+
+// executeListKeys executes a list-keys request.
+func executeListKeys(
+	ctx context.Context,
+	tx driver.ReadTx,
+	req *rpc.ListKeysRequest,
+	res *rpc.Response,
+) error {
+	op := &driver.ListKeys{
+		Prefix: req.Prefix,
+		Type:   document.KeyType(req.Type),
+	}
+
+	if err := executeRead(ctx, tx, op); err != nil {
+		return err
+	}
+
+	for _, k := range op.Keys {
+		res.Keys = append(res.Keys, &rpc.Key{
+			Name:  k.Name,
+			Type:  uint32(k.Type),
+			Count: int64(k.Count),
+		})
+	}
+
+	return nil
+}
+
+// executeSave executes a save request.
+//
+// The saved document is included in the response, as the driver updates its
+// revision, timestamps and derived keys.
+func executeSave(
+	ctx context.Context,
+	tx driver.WriteTx,
+	req *rpc.SaveRequest,
+	res *rpc.Response,
+) error {
+	doc, err := unmarshalDocument(req.Document)
+	if err != nil {
+		return err
+	}
+
+	// the document is validated by the client's DB, but the server must not
+	// rely on the client to do so
+	if err := doc.Validate(); err != nil {
+		return err
+	}
+
+	op := &driver.Save{
+		Document: doc,
+		Force:    req.Force,
+	}
+
+	if len(req.DerivedKeys) != 0 {
+		op.DerivedKeys = unmarshalKeys(req.DerivedKeys)
+	}
+
+	if err := executeWrite(ctx, tx, op); err != nil {
+		return err
+	}
+
+	d, err := marshalDocument(doc)
+	if err != nil {
+		return err
+	}
+
+	res.Documents = []*rpc.Document{d}
+
+	return nil
+}
+
+// executeDelete executes a delete request.
+func executeDelete(
+	ctx context.Context,
+	tx driver.WriteTx,
+	req *rpc.DeleteRequest,
+) error {
+	doc, err := unmarshalDocument(req.Document)
+	if err != nil {
+		return err
+	}
+
+	return executeWrite(ctx, tx, &driver.Delete{
+		Document: doc,
+	})
+}
+
+// executeDeleteWhere executes a delete-where request.
+func executeDeleteWhere(
+	ctx context.Context,
+	tx driver.WriteTx,
+	req *rpc.DeleteWhereRequest,
+	res *rpc.Response,
+) error {
+	f, err := unmarshalFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+
+	return executeWrite(ctx, tx, &driver.DeleteWhere{
+		Filter: f,
+		Each: func(id string) error {
+			res.DocumentIds = append(res.DocumentIds, id)
+			return nil
+		},
+	})
+}
+
+// executeRestore executes a restore request.
+func executeRestore(
+	ctx context.Context,
+	tx driver.WriteTx,
+	req *rpc.RestoreRequest,
+	res *rpc.Response,
+) error {
+	op := &driver.Restore{
+		DocumentID: req.DocumentId,
+	}
+
+	if err := executeWrite(ctx, tx, op); err != nil {
+		return err
+	}
+
+	res.Found = op.Found
+
+	return nil
+}
+
+// executePurgeDeleted executes a purge-deleted request.
+func executePurgeDeleted(
+	ctx context.Context,
+	tx driver.WriteTx,
+	req *rpc.PurgeDeletedRequest,
+	res *rpc.Response,
+) error {
+	before, err := ptypes.Timestamp(req.Before)
+	if err != nil {
+		return err
+	}
+
+	return executeWrite(ctx, tx, &driver.PurgeDeleted{
+		Before: before,
+		Each: func(id string) error {
+			res.DocumentIds = append(res.DocumentIds, id)
+			return nil
+		},
+	})
+}
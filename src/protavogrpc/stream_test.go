@@ -0,0 +1,160 @@
+package protavogrpc_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"strings"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavobolt"
+	. "github.com/jmalloc/protavo/src/protavogrpc"
+	g "github.com/onsi/ginkgo"
+	m "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+var _ = g.Describe("Driver (streaming)", func() {
+	var (
+		ctx    = context.Background()
+		dir    string
+		local  *protavobolt.ExclusiveDriver
+		server *grpc.Server
+		db     *protavo.DB
+
+		// large is content that is too large to fit in a single response
+		// alongside other documents, or in a single attachment chunk
+		large = strings.Repeat("x", 600<<10)
+	)
+
+	g.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "protavogrpc-")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		bdb, err := bolt.Open(path.Join(dir, "bolt.db"), 0600, nil)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		local = &protavobolt.ExclusiveDriver{DB: bdb}
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		server = grpc.NewServer()
+		Register(server, local)
+		go server.Serve(lis)
+
+		db, err = Dial(lis.Addr().String(), grpc.WithInsecure())
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+
+		err = db.Save(
+			ctx,
+			&document.Document{ID: "doc-1", Content: document.StringContent(large)},
+			&document.Document{ID: "doc-2", Content: document.StringContent(large)},
+			&document.Document{ID: "doc-3", Content: document.StringContent(large)},
+		)
+		m.Expect(err).ShouldNot(m.HaveOccurred())
+	})
+
+	g.AfterEach(func() {
+		_ = db.Close()
+		server.Stop()
+		_ = local.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	g.Describe("Fetch", func() {
+		g.It("fetches documents that span several responses", func() {
+			var ids []string
+			err := db.FetchAll(ctx, func(doc *document.Document) (bool, error) {
+				ids = append(ids, doc.ID)
+				return true, nil
+			})
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ids).To(m.ConsistOf("doc-1", "doc-2", "doc-3"))
+		})
+
+		g.It("allows further operations in the transaction when iteration is stopped early", func() {
+			var ids []string
+			load := protavo.FetchAll(func(doc *document.Document) (bool, error) {
+				ids = append(ids, doc.ID)
+				return true, nil
+			})
+
+			err := db.Read(
+				ctx,
+				protavo.FetchAll(func(doc *document.Document) (bool, error) {
+					return false, nil
+				}),
+				load,
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ids).To(m.HaveLen(3))
+		})
+
+		g.It("closes the transaction when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			tx, err := db.BeginRead(ctx)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			defer tx.Close()
+
+			op := &driver.Fetch{
+				Each: func(doc *document.Document) (bool, error) {
+					cancel()
+					return true, nil
+				},
+			}
+
+			op.ExecuteInReadTx(ctx, tx)
+			m.Expect(op.Err()).To(m.Equal(context.Canceled))
+
+			op = &driver.Fetch{
+				Each: func(doc *document.Document) (bool, error) {
+					return true, nil
+				},
+			}
+
+			op.ExecuteInReadTx(context.Background(), tx)
+			m.Expect(op.Err()).To(m.Equal(protavo.ErrTxClosed))
+		})
+	})
+
+	g.Describe("attachments", func() {
+		g.It("transfers attachments that span several chunks", func() {
+			err := db.PutAttachment(ctx, "doc-1", "large", strings.NewReader(large))
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+
+			var buf bytes.Buffer
+			ok, err := db.GetAttachment(ctx, "doc-1", "large", &buf)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(ok).To(m.BeTrue())
+			m.Expect(buf.String()).To(m.Equal(large))
+		})
+
+		g.It("allows further operations in the transaction when the attachment can not be stored", func() {
+			var buf bytes.Buffer
+			get := protavo.GetAttachment("doc-1", "large", &buf)
+
+			err := db.Write(
+				ctx,
+				protavo.Attempt(
+					func(context.Context, driver.WriteTx, error) error { return nil },
+					protavo.PutAttachment("<unknown>", "large", strings.NewReader(large)),
+				),
+				protavo.PutAttachment("doc-1", "large", strings.NewReader(large)),
+				get,
+			)
+			m.Expect(err).ShouldNot(m.HaveOccurred())
+			m.Expect(get.Found).To(m.BeTrue())
+			m.Expect(buf.String()).To(m.Equal(large))
+		})
+	})
+})
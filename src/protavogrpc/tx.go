@@ -0,0 +1,571 @@
+package protavogrpc
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jmalloc/protavo/src/protavo"
+	"github.com/jmalloc/protavo/src/protavo/document"
+	"github.com/jmalloc/protavo/src/protavo/driver"
+	"github.com/jmalloc/protavo/src/protavogrpc/internal/rpc"
+)
+
+// readTx is a gRPC implementation of protavo.ReadTx.
+type readTx struct {
+	stream *stream
+}
+
+func (tx *readTx) Fetch(ctx context.Context, op *driver.Fetch) {
+	op.MarkExecuted(
+		tx.stream.fetch(ctx, op),
+	)
+}
+
+func (tx *readTx) Explain(ctx context.Context, op *driver.Explain) {
+	plan, err := tx.stream.explain(ctx, op)
+	op.Plan = plan
+	op.MarkExecuted(err)
+}
+
+func (tx *readTx) GetAttachment(ctx context.Context, op *driver.GetAttachment) {
+	op.MarkExecuted(
+		tx.stream.getAttachment(ctx, op),
+	)
+}
+
+func (tx *readTx) ListAttachments(ctx context.Context, op *driver.ListAttachments) {
+	res, err := tx.stream.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_ListAttachments{
+				ListAttachments: &rpc.ListAttachmentsRequest{
+					DocumentId: op.DocumentID,
+				},
+			},
+		},
+	)
+
+	if err == nil {
+		for _, a := range res.Attachments {
+			op.Attachments = append(op.Attachments, driver.Attachment{
+				Name: a.Name,
+				Size: a.Size,
+			})
+		}
+	}
+
+	op.MarkExecuted(err)
+}
+
+func (tx *readTx) ChangesSince(ctx context.Context, op *driver.ChangesSince) {
+	op.MarkExecuted(
+		tx.stream.changesSince(ctx, op),
+	)
+}
+
+func (tx *readTx) ListKeys(ctx context.Context, op *driver.ListKeys) {
+	res, err := tx.stream.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_ListKeys{
+				ListKeys: &rpc.ListKeysRequest{
+					Prefix: op.Prefix,
+					Type:   uint32(op.Type),
+				},
+			},
+		},
+	)
+
+	if err == nil {
+		for _, k := range res.Keys {
+			op.Keys = append(op.Keys, driver.Key{
+				Name:  k.Name,
+				Type:  document.KeyType(k.Type),
+				Count: int(k.Count),
+			})
+		}
+	}
+
+	op.MarkExecuted(err)
+}
+
+func (tx *readTx) Close() error {
+	return tx.stream.close()
+}
+
+// writeTx is a gRPC implementation of protavo.WriteTx.
+type writeTx struct {
+	readTx
+}
+
+func (tx *writeTx) Save(ctx context.Context, op *driver.Save) {
+	op.MarkExecuted(
+		tx.stream.save(ctx, op),
+	)
+}
+
+func (tx *writeTx) Delete(ctx context.Context, op *driver.Delete) {
+	d, err := marshalDocument(op.Document)
+	if err == nil {
+		_, err = tx.stream.call(
+			ctx,
+			&rpc.Request{
+				Request: &rpc.Request_Delete{
+					Delete: &rpc.DeleteRequest{Document: d},
+				},
+			},
+		)
+	}
+
+	op.MarkExecuted(err)
+}
+
+func (tx *writeTx) DeleteWhere(ctx context.Context, op *driver.DeleteWhere) {
+	op.MarkExecuted(
+		tx.stream.deleteWhere(ctx, op),
+	)
+}
+
+func (tx *writeTx) DeleteNamespace(ctx context.Context, op *driver.DeleteNamespace) {
+	_, err := tx.stream.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_DeleteNamespace{
+				DeleteNamespace: &rpc.DeleteNamespaceRequest{},
+			},
+		},
+	)
+
+	op.MarkExecuted(err)
+}
+
+func (tx *writeTx) Restore(ctx context.Context, op *driver.Restore) {
+	res, err := tx.stream.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_Restore{
+				Restore: &rpc.RestoreRequest{DocumentId: op.DocumentID},
+			},
+		},
+	)
+
+	if err == nil {
+		op.Found = res.Found
+	}
+
+	op.MarkExecuted(err)
+}
+
+func (tx *writeTx) PurgeDeleted(ctx context.Context, op *driver.PurgeDeleted) {
+	op.MarkExecuted(
+		tx.stream.purgeDeleted(ctx, op),
+	)
+}
+
+func (tx *writeTx) PutAttachment(ctx context.Context, op *driver.PutAttachment) {
+	op.MarkExecuted(
+		tx.stream.putAttachment(ctx, op),
+	)
+}
+
+func (tx *writeTx) DeleteAttachment(ctx context.Context, op *driver.DeleteAttachment) {
+	_, err := tx.stream.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_DeleteAttachment{
+				DeleteAttachment: &rpc.DeleteAttachmentRequest{
+					DocumentId: op.DocumentID,
+					Name:       op.Name,
+				},
+			},
+		},
+	)
+
+	op.MarkExecuted(err)
+}
+
+func (tx *writeTx) ApplyChange(ctx context.Context, op *driver.ApplyChange) {
+	c, err := marshalChange(op.Change)
+	if err == nil {
+		_, err = tx.stream.call(
+			ctx,
+			&rpc.Request{
+				Request: &rpc.Request_ApplyChange{
+					ApplyChange: &rpc.ApplyChangeRequest{Change: c},
+				},
+			},
+		)
+	}
+
+	op.MarkExecuted(err)
+}
+
+func (tx *writeTx) Savepoint(ctx context.Context) (driver.Savepoint, error) {
+	res, err := tx.stream.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_Savepoint{
+				Savepoint: &rpc.SavepointRequest{},
+			},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return driver.Savepoint(res.Savepoint), nil
+}
+
+func (tx *writeTx) RollbackTo(ctx context.Context, sp driver.Savepoint) error {
+	_, err := tx.stream.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_RollbackTo{
+				RollbackTo: &rpc.RollbackToRequest{Savepoint: uint64(sp)},
+			},
+		},
+	)
+
+	return err
+}
+
+func (tx *writeTx) Release(ctx context.Context, sp driver.Savepoint) error {
+	_, err := tx.stream.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_Release{
+				Release: &rpc.ReleaseRequest{Savepoint: uint64(sp)},
+			},
+		},
+	)
+
+	return err
+}
+
+func (tx *writeTx) Commit() error {
+	_, err := tx.stream.call(
+		context.Background(),
+		&rpc.Request{
+			Request: &rpc.Request_Commit{
+				Commit: &rpc.CommitRequest{},
+			},
+		},
+	)
+
+	// the server ends the transaction after a commit request, regardless of
+	// whether it succeeds
+	tx.stream.closed = true
+
+	return err
+}
+
+// fetch calls op.Each for each document that matches op.Filter.
+func (s *stream) fetch(ctx context.Context, op *driver.Fetch) error {
+	f, err := marshalFilter(op.Filter)
+	if err != nil {
+		return err
+	}
+
+	return s.each(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_Fetch{
+				Fetch: &rpc.FetchRequest{Filter: f},
+			},
+		},
+		func(res *rpc.Response) (bool, error) {
+			for _, d := range res.Documents {
+				if err := ctx.Err(); err != nil {
+					return false, err
+				}
+
+				doc, err := unmarshalDocument(d)
+				if err != nil {
+					return false, err
+				}
+
+				ok, err := op.Each(doc)
+				if !ok || err != nil {
+					return false, err
+				}
+			}
+
+			return true, nil
+		},
+	)
+}
+
+// explain returns the query plan for op.Filter.
+func (s *stream) explain(ctx context.Context, op *driver.Explain) (*driver.QueryPlan, error) {
+	f, err := marshalFilter(op.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_Explain{
+				Explain: &rpc.ExplainRequest{Filter: f},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPlan(res.Plan)
+}
+
+// changesSince calls op.Each for each document that has changed since
+// op.Since.
+func (s *stream) changesSince(ctx context.Context, op *driver.ChangesSince) error {
+	return s.each(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_ChangesSince{
+				ChangesSince: &rpc.ChangesSinceRequest{Since: op.Since},
+			},
+		},
+		func(res *rpc.Response) (bool, error) {
+			for _, m := range res.Changes {
+				if err := ctx.Err(); err != nil {
+					return false, err
+				}
+
+				c, err := unmarshalChange(m)
+				if err != nil {
+					return false, err
+				}
+
+				ok, err := op.Each(c)
+				if !ok || err != nil {
+					return false, err
+				}
+			}
+
+			return true, nil
+		},
+	)
+}
+
+// getAttachment writes the content of an attachment to op.Content, as it is
+// received from the server.
+func (s *stream) getAttachment(ctx context.Context, op *driver.GetAttachment) error {
+	return s.each(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_GetAttachment{
+				GetAttachment: &rpc.GetAttachmentRequest{
+					DocumentId: op.DocumentID,
+					Name:       op.Name,
+				},
+			},
+		},
+		func(res *rpc.Response) (bool, error) {
+			// only the final response indicates whether the attachment was
+			// found, but any content implies that it was
+			if res.Found || len(res.Content) != 0 {
+				op.Found = true
+			}
+
+			if _, err := op.Content.Write(res.Content); err != nil {
+				return false, err
+			}
+
+			return true, nil
+		},
+	)
+}
+
+// putAttachment stores an attachment, sending its content to the server in
+// chunks as it is read from op.Content.
+func (s *stream) putAttachment(ctx context.Context, op *driver.PutAttachment) error {
+	buf := make([]byte, chunkSize)
+
+	content, more, err := readChunk(op.Content, buf)
+	if err != nil {
+		return err
+	}
+
+	req := &rpc.Request{
+		Request: &rpc.Request_PutAttachment{
+			PutAttachment: &rpc.PutAttachmentRequest{
+				DocumentId: op.DocumentID,
+				Name:       op.Name,
+				Content:    content,
+				More:       more,
+			},
+		},
+	}
+
+	for more {
+		if err := s.send(ctx, req); err != nil {
+			return err
+		}
+
+		content, more, err = readChunk(op.Content, buf)
+		if err != nil {
+			// the server is expecting the remainder of the content, so the
+			// request must be canceled explicitly, its response is discarded
+			_, _ = s.call(ctx, &rpc.Request{
+				Request: &rpc.Request_AttachmentChunk{
+					AttachmentChunk: &rpc.AttachmentChunkRequest{Cancel: true},
+				},
+			})
+
+			return err
+		}
+
+		req = &rpc.Request{
+			Request: &rpc.Request_AttachmentChunk{
+				AttachmentChunk: &rpc.AttachmentChunkRequest{
+					Content: content,
+					More:    more,
+				},
+			},
+		}
+	}
+
+	_, err = s.call(ctx, req)
+	return err
+}
+
+// readChunk reads the next chunk of an attachment's content from r into buf.
+// more is false if the end of the content has been reached.
+func readChunk(r io.Reader, buf []byte) (content []byte, more bool, err error) {
+	n, err := io.ReadFull(r, buf)
+
+	switch err {
+	case nil:
+		return buf[:n], true, nil
+	case io.EOF, io.ErrUnexpectedEOF:
+		return buf[:n], false, nil
+	default:
+		return nil, false, err
+	}
+}
+
+// save saves op.Document, and updates its revision and timestamps.
+func (s *stream) save(ctx context.Context, op *driver.Save) error {
+	// content is required to marshal the document, so the check can not be
+	// left to the server
+	if op.Document.Content == nil {
+		return &protavo.InvalidDocumentError{
+			DocumentID: op.Document.ID,
+			Reason:     "the document has no content",
+		}
+	}
+
+	d, err := marshalDocument(op.Document)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_Save{
+				Save: &rpc.SaveRequest{
+					Document:    d,
+					Force:       op.Force,
+					DerivedKeys: marshalKeys(op.DerivedKeys),
+				},
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	saved, err := unmarshalDocument(res.Documents[0])
+	if err != nil {
+		return err
+	}
+
+	op.Document.Revision = saved.Revision
+	op.Document.CreatedAt = saved.CreatedAt
+	op.Document.UpdatedAt = saved.UpdatedAt
+	op.Document.DerivedKeys = saved.DerivedKeys
+
+	return nil
+}
+
+// deleteWhere deletes the documents that match op.Filter, and calls
+// op.Each for each deleted document.
+//
+// If op.Each returns an error, the operation fails, but documents that have
+// already been deleted on the server remain deleted until the transaction, or
+// the enclosing attempt, is rolled back.
+func (s *stream) deleteWhere(ctx context.Context, op *driver.DeleteWhere) error {
+	f, err := marshalFilter(op.Filter)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_DeleteWhere{
+				DeleteWhere: &rpc.DeleteWhereRequest{Filter: f},
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if op.Each == nil {
+		return nil
+	}
+
+	for _, id := range res.DocumentIds {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := op.Each(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// purgeDeleted permanently removes the documents that were soft-deleted at or
+// before op.Before, and calls op.Each for each purged document.
+func (s *stream) purgeDeleted(ctx context.Context, op *driver.PurgeDeleted) error {
+	before, err := ptypes.TimestampProto(op.Before)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.call(
+		ctx,
+		&rpc.Request{
+			Request: &rpc.Request_PurgeDeleted{
+				PurgeDeleted: &rpc.PurgeDeletedRequest{Before: before},
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if op.Each == nil {
+		return nil
+	}
+
+	for _, id := range res.DocumentIds {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := op.Each(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}